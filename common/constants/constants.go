@@ -10,6 +10,17 @@ const (
 	CORE_PROP_TYPE     = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties"
 	EXTENDED_PROP_TYPE = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/extended-properties"
 	StylesType         = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"
+	NumberingType      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering"
+	FootnotesType      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/footnotes"
+	EndnotesType       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/endnotes"
+	HeaderType         = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/header"
+	FooterType         = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/footer"
+	SettingsType       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/settings"
+	WebSettingsType    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/webSettings"
+	FontTableType      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/fontTable"
+	ThemeType          = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme"
+	CustomXMLType      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/customXml"
+	AltChunkType       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/aFChunk"
 )
 
 var (
@@ -81,4 +92,22 @@ const (
 
 const MediaPath = "word/media/"
 
+// Content types of document parts created by the library
+const (
+	NumberingContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml"
+	FootnotesContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.footnotes+xml"
+	EndnotesContentType  = "application/vnd.openxmlformats-officedocument.wordprocessingml.endnotes+xml"
+	CommentsContentType  = "application/vnd.openxmlformats-officedocument.wordprocessingml.comments+xml"
+)
+
+// Content types of external content that can be imported with an altChunk
+const (
+	AltChunkHTML  = "text/html"
+	AltChunkXHTML = "application/xhtml+xml"
+	AltChunkMHT   = "message/rfc822"
+	AltChunkRTF   = "application/rtf"
+	AltChunkText  = "text/plain"
+	AltChunkDocx  = "application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"
+)
+
 const ConentTypeFileIdx = "[Content_Types].xml"
@@ -1,12 +1,40 @@
 package units
 
+import "math"
+
+// Length is a dimension in any unit, converted to EMUs when written to a document.
+type Length interface {
+	ToEmu() Emu
+}
+
 // Inch represents a dimension in inches.
 type Inch float64
 
 // Emu represents a dimension in English Metric Units (EMUs).
 type Emu int64
 
+// Cm represents a dimension in centimeters.
+type Cm float64
+
+// Point represents a dimension in points.
+type Point float64
+
 // ToEmu converts inches to EMUs.
 func (i Inch) ToEmu() Emu {
-	return Emu(i * 914400)
+	return Emu(math.Round(float64(i) * 914400))
+}
+
+// ToEmu returns the EMUs unchanged, which makes Emu a Length.
+func (e Emu) ToEmu() Emu {
+	return e
+}
+
+// ToEmu converts centimeters to EMUs.
+func (c Cm) ToEmu() Emu {
+	return Emu(math.Round(float64(c) * 360000))
+}
+
+// ToEmu converts points to EMUs.
+func (p Point) ToEmu() Emu {
+	return Emu(math.Round(float64(p) * 12700))
 }
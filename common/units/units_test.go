@@ -0,0 +1,24 @@
+package units
+
+import "testing"
+
+func TestToEmu(t *testing.T) {
+	tests := []struct {
+		name     string
+		length   Length
+		expected Emu
+	}{
+		{"Inch", Inch(1.5), 1371600},
+		{"Cm", Cm(2.54), 914400},
+		{"Point", Point(72), 914400},
+		{"Emu", Emu(42), 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.length.ToEmu(); got != tt.expected {
+				t.Errorf("Expected %d but got %d", tt.expected, got)
+			}
+		})
+	}
+}
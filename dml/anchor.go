@@ -68,10 +68,13 @@ type Anchor struct {
 	// 6.2. wrapSquare
 	WrapSquare *WrapSquare `xml:"wrapSquare,omitempty"`
 
-	// 6.3. wrapThrough
+	// 6.3. wrapTight
+	WrapTight *WrapTight `xml:"wrapTight,omitempty"`
+
+	// 6.4. wrapThrough
 	WrapThrough *WrapThrough `xml:"wrapThrough,omitempty"`
 
-	// 6.4. wrapTopAndBottom
+	// 6.5. wrapTopAndBottom
 	WrapTopBtm *WrapTopBtm `xml:"wrapTopAndBottom,omitempty"`
 
 	// 7. Drawing Object Non-Visual Properties
@@ -140,8 +143,10 @@ func (a Anchor) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	}
 
 	// 5. EffectExtent
-	if err := a.EffectExtent.MarshalXML(e, xml.StartElement{}); err != nil {
-		return fmt.Errorf("EffectExtent: %v", err)
+	if a.EffectExtent != nil {
+		if err := a.EffectExtent.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("EffectExtent: %v", err)
+		}
 	}
 
 	// 6. Wrap Choice
@@ -174,6 +179,8 @@ func (a *Anchor) MarshalWrap(e *xml.Encoder) error {
 		return a.WrapNone.MarshalXML(e, xml.StartElement{})
 	} else if a.WrapSquare != nil {
 		return a.WrapSquare.MarshalXML(e, xml.StartElement{})
+	} else if a.WrapTight != nil {
+		return a.WrapTight.MarshalXML(e, xml.StartElement{})
 	} else if a.WrapThrough != nil {
 		return a.WrapThrough.MarshalXML(e, xml.StartElement{})
 	} else if a.WrapTopBtm != nil {
@@ -274,6 +281,11 @@ func (a *Anchor) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 				if err := d.DecodeElement(a.WrapSquare, &elem); err != nil {
 					return err
 				}
+			case "wrapTight":
+				a.WrapTight = &WrapTight{}
+				if err := d.DecodeElement(a.WrapTight, &elem); err != nil {
+					return err
+				}
 			case "wrapThrough":
 				a.WrapThrough = &WrapThrough{}
 				if err := d.DecodeElement(a.WrapThrough, &elem); err != nil {
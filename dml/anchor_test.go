@@ -2,6 +2,7 @@ package dml
 
 import (
 	"encoding/xml"
+	"strings"
 	"testing"
 
 	"github.com/bfoley13/godocx/dml/dmlct"
@@ -184,3 +185,41 @@ func TestUnmarshalAnchor(t *testing.T) {
 		})
 	}
 }
+
+func TestAnchor_WrapTight(t *testing.T) {
+	anchor := Anchor{
+		PositionH: PoistionH{RelativeFrom: dmlst.RelFromHMargin, Align: dmlst.AlignHRight},
+		PositionV: PoistionV{RelativeFrom: dmlst.RelFromVParagraph, PosOffset: 100},
+		Extent:    dmlct.PSize2D{Width: 100, Height: 200},
+		WrapTight: &WrapTight{
+			WrapText: dmlst.WrapTextBothSides,
+			WrapPolygon: WrapPolygon{
+				Start:  dmlct.Point2D{XAxis: 0, YAxis: 0},
+				LineTo: []dmlct.Point2D{{XAxis: 0, YAxis: 21600}, {XAxis: 21600, YAxis: 21600}},
+			},
+		},
+		DocProp: DocProp{ID: 1, Name: "test"},
+	}
+
+	generatedXML, err := xml.Marshal(anchor)
+	if err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+	if strings.Contains(string(generatedXML), "effectExtent") {
+		t.Errorf("Expected no effectExtent in %s", generatedXML)
+	}
+
+	var loaded Anchor
+	if err := xml.Unmarshal(generatedXML, &loaded); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+	if loaded.WrapTight == nil {
+		t.Fatalf("Expected wrapTight in %s", generatedXML)
+	}
+	if loaded.WrapTight.WrapText != dmlst.WrapTextBothSides || len(loaded.WrapTight.WrapPolygon.LineTo) != 2 {
+		t.Errorf("Unexpected wrapTight %+v", loaded.WrapTight)
+	}
+	if loaded.PositionH.Align != dmlst.AlignHRight || loaded.PositionV.PosOffset != 100 {
+		t.Errorf("Unexpected positions %+v %+v", loaded.PositionH, loaded.PositionV)
+	}
+}
@@ -0,0 +1,65 @@
+package dmlst
+
+import (
+	"errors"
+)
+
+// AlignH is the horizontal alignment of a floating object relative to the base of its
+// horizontal positioning.
+type AlignH string
+
+const (
+	AlignHLeft    AlignH = "left"    // Left Alignment
+	AlignHRight   AlignH = "right"   // Right Alignment
+	AlignHCenter  AlignH = "center"  // Center Alignment
+	AlignHInside  AlignH = "inside"  // Inside
+	AlignHOutside AlignH = "outside" // Outside
+)
+
+// AlignHFromStr converts a string to AlignH type.
+func AlignHFromStr(value string) (AlignH, error) {
+	switch value {
+	case "left":
+		return AlignHLeft, nil
+	case "right":
+		return AlignHRight, nil
+	case "center":
+		return AlignHCenter, nil
+	case "inside":
+		return AlignHInside, nil
+	case "outside":
+		return AlignHOutside, nil
+	default:
+		return "", errors.New("Invalid AlignH value")
+	}
+}
+
+// AlignV is the vertical alignment of a floating object relative to the base of its
+// vertical positioning.
+type AlignV string
+
+const (
+	AlignVTop     AlignV = "top"     // Top
+	AlignVBottom  AlignV = "bottom"  // Bottom
+	AlignVCenter  AlignV = "center"  // Center Alignment
+	AlignVInside  AlignV = "inside"  // Inside
+	AlignVOutside AlignV = "outside" // Outside
+)
+
+// AlignVFromStr converts a string to AlignV type.
+func AlignVFromStr(value string) (AlignV, error) {
+	switch value {
+	case "top":
+		return AlignVTop, nil
+	case "bottom":
+		return AlignVBottom, nil
+	case "center":
+		return AlignVCenter, nil
+	case "inside":
+		return AlignVInside, nil
+	case "outside":
+		return AlignVOutside, nil
+	default:
+		return "", errors.New("Invalid AlignV value")
+	}
+}
@@ -0,0 +1,65 @@
+package dmlst
+
+import (
+	"testing"
+)
+
+func TestAlignHFromStr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected AlignH
+	}{
+		{"left", AlignHLeft},
+		{"right", AlignHRight},
+		{"center", AlignHCenter},
+		{"inside", AlignHInside},
+		{"outside", AlignHOutside},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := AlignHFromStr(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := AlignHFromStr("top"); err == nil {
+		t.Errorf("Expected error for invalid value")
+	}
+}
+
+func TestAlignVFromStr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected AlignV
+	}{
+		{"top", AlignVTop},
+		{"bottom", AlignVBottom},
+		{"center", AlignVCenter},
+		{"inside", AlignVInside},
+		{"outside", AlignVOutside},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := AlignVFromStr(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := AlignVFromStr("left"); err == nil {
+		t.Errorf("Expected error for invalid value")
+	}
+}
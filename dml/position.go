@@ -9,12 +9,22 @@ import (
 
 type PoistionH struct {
 	RelativeFrom dmlst.RelFromH `xml:"relativeFrom,attr"`
-	PosOffset    int            `xml:"posOffset"`
+
+	// Relative Horizontal Alignment. When set, it is written instead of PosOffset.
+	Align dmlst.AlignH `xml:"align,omitempty"`
+
+	// Absolute Position Offset in EMUs
+	PosOffset int `xml:"posOffset"`
 }
 
 type PoistionV struct {
 	RelativeFrom dmlst.RelFromV `xml:"relativeFrom,attr"`
-	PosOffset    int            `xml:"posOffset"`
+
+	// Relative Vertical Alignment. When set, it is written instead of PosOffset.
+	Align dmlst.AlignV `xml:"align,omitempty"`
+
+	// Absolute Position Offset in EMUs
+	PosOffset int `xml:"posOffset"`
 }
 
 func (p PoistionH) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
@@ -32,8 +42,7 @@ func (p PoistionH) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		return err
 	}
 
-	offsetElem := xml.StartElement{Name: xml.Name{Local: "wp:posOffset"}}
-	if err = e.EncodeElement(p.PosOffset, offsetElem); err != nil {
+	if err = marshalPosition(e, string(p.Align), p.PosOffset); err != nil {
 		return err
 	}
 
@@ -54,8 +63,7 @@ func (p PoistionV) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		return err
 	}
 
-	offsetElem := xml.StartElement{Name: xml.Name{Local: "wp:posOffset"}}
-	if err = e.EncodeElement(p.PosOffset, offsetElem); err != nil {
+	if err = marshalPosition(e, string(p.Align), p.PosOffset); err != nil {
 		return err
 	}
 
@@ -80,6 +88,16 @@ func (p *PoistionH) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		switch elem := token.(type) {
 		case xml.StartElement:
 			switch elem.Name.Local {
+			case "align":
+				var value string
+				if err := d.DecodeElement(&value, &elem); err != nil {
+					return err
+				}
+				align, err := dmlst.AlignHFromStr(value)
+				if err != nil {
+					return err
+				}
+				p.Align = align
 			case "posOffset":
 				if err := d.DecodeElement(&p.PosOffset, &elem); err != nil {
 					return err
@@ -113,6 +131,16 @@ func (p *PoistionV) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		switch elem := token.(type) {
 		case xml.StartElement:
 			switch elem.Name.Local {
+			case "align":
+				var value string
+				if err := d.DecodeElement(&value, &elem); err != nil {
+					return err
+				}
+				align, err := dmlst.AlignVFromStr(value)
+				if err != nil {
+					return err
+				}
+				p.Align = align
 			case "posOffset":
 				if err := d.DecodeElement(&p.PosOffset, &elem); err != nil {
 					return err
@@ -127,3 +155,12 @@ func (p *PoistionV) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		}
 	}
 }
+
+// marshalPosition writes the wp:align element when align is set, and the wp:posOffset element
+// otherwise.
+func marshalPosition(e *xml.Encoder, align string, offset int) error {
+	if align != "" {
+		return e.EncodeElement(align, xml.StartElement{Name: xml.Name{Local: "wp:align"}})
+	}
+	return e.EncodeElement(offset, xml.StartElement{Name: xml.Name{Local: "wp:posOffset"}})
+}
@@ -19,6 +19,14 @@ func TestMarshalPoistionH(t *testing.T) {
 			},
 			expectedXML: `<wp:positionH relativeFrom="margin"><wp:posOffset>100</wp:posOffset></wp:positionH>`,
 		},
+		{
+			positionH: &PoistionH{
+				RelativeFrom: dmlst.RelFromHPage,
+				Align:        dmlst.AlignHRight,
+				PosOffset:    100,
+			},
+			expectedXML: `<wp:positionH relativeFrom="page"><wp:align>right</wp:align></wp:positionH>`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -47,6 +55,13 @@ func TestUnmarshalPoistionH(t *testing.T) {
 				PosOffset:    100,
 			},
 		},
+		{
+			inputXML: `<wp:positionH relativeFrom="column"><wp:align>center</wp:align></wp:positionH>`,
+			expectedPos: PoistionH{
+				RelativeFrom: dmlst.RelFromHColumn,
+				Align:        dmlst.AlignHCenter,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -61,6 +76,9 @@ func TestUnmarshalPoistionH(t *testing.T) {
 			if pos.RelativeFrom != tt.expectedPos.RelativeFrom {
 				t.Errorf("Expected RelativeFrom %s, but got %s", tt.expectedPos.RelativeFrom, pos.RelativeFrom)
 			}
+			if pos.Align != tt.expectedPos.Align {
+				t.Errorf("Expected Align %s, but got %s", tt.expectedPos.Align, pos.Align)
+			}
 			if pos.PosOffset != tt.expectedPos.PosOffset {
 				t.Errorf("Expected PosOffset %d, but got %d", tt.expectedPos.PosOffset, pos.PosOffset)
 			}
@@ -80,6 +98,13 @@ func TestMarshalPoistionV(t *testing.T) {
 			},
 			expectedXML: `<wp:positionV relativeFrom="paragraph"><wp:posOffset>200</wp:posOffset></wp:positionV>`,
 		},
+		{
+			positionV: &PoistionV{
+				RelativeFrom: dmlst.RelFromVMargin,
+				Align:        dmlst.AlignVTop,
+			},
+			expectedXML: `<wp:positionV relativeFrom="margin"><wp:align>top</wp:align></wp:positionV>`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -108,6 +133,13 @@ func TestUnmarshalPoistionV(t *testing.T) {
 				PosOffset:    200,
 			},
 		},
+		{
+			inputXML: `<wp:positionV relativeFrom="page"><wp:align>bottom</wp:align></wp:positionV>`,
+			expectedPos: PoistionV{
+				RelativeFrom: dmlst.RelFromVPage,
+				Align:        dmlst.AlignVBottom,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -122,6 +154,9 @@ func TestUnmarshalPoistionV(t *testing.T) {
 			if pos.RelativeFrom != tt.expectedPos.RelativeFrom {
 				t.Errorf("Expected RelativeFrom %s, but got %s", tt.expectedPos.RelativeFrom, pos.RelativeFrom)
 			}
+			if pos.Align != tt.expectedPos.Align {
+				t.Errorf("Expected Align %s, but got %s", tt.expectedPos.Align, pos.Align)
+			}
 			if pos.PosOffset != tt.expectedPos.PosOffset {
 				t.Errorf("Expected PosOffset %d, but got %d", tt.expectedPos.PosOffset, pos.PosOffset)
 			}
@@ -0,0 +1,52 @@
+package docx
+
+import (
+	"fmt"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
+)
+
+// altChunkExtensions maps the content types Word can import with an altChunk to the
+// extensions of their parts.
+var altChunkExtensions = map[string]string{
+	constants.AltChunkHTML:  "html",
+	constants.AltChunkXHTML: "xhtml",
+	constants.AltChunkMHT:   "mht",
+	constants.AltChunkRTF:   "rtf",
+	"text/rtf":              "rtf",
+	constants.AltChunkText:  "txt",
+	constants.AltChunkDocx:  "docx",
+}
+
+// AddAltChunk appends external content, such as HTML, RTF, plain text or another document,
+// to the body of the document. The content is stored in a part of its own and converted by
+// Word when the document is opened.
+//
+// The content type must be one of the AltChunk content types in the constants package.
+// The returned element can be used to set whether the content keeps its own formatting.
+func (rd *RootDoc) AddAltChunk(content []byte, contentType string) (*ctypes.AltChunk, error) {
+	ext, ok := altChunkExtensions[contentType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported altChunk content type %q", contentType)
+	}
+
+	target := ""
+	for n := 1; ; n++ {
+		target = fmt.Sprintf("afchunk%d.%s", n, ext)
+		if _, exists := rd.FileMap.Load(rd.partPath(target)); !exists {
+			break
+		}
+	}
+	partName := rd.partPath(target)
+
+	if err := rd.ContentType.AddOverride("/"+partName, contentType); err != nil {
+		return nil, err
+	}
+	rd.FileMap.Store(partName, content)
+
+	chunk := &ctypes.AltChunk{ID: rd.Document.addRelation(constants.AltChunkType, target)}
+	rd.Document.Body.Children = append(rd.Document.Body.Children, DocumentChild{AltChunk: chunk})
+
+	return chunk, nil
+}
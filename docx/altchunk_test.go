@@ -0,0 +1,56 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddAltChunk(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.AddParagraph("Before")
+
+	chunk, err := rd.AddAltChunk([]byte("<p>Imported</p>"), constants.AltChunkHTML)
+	require.NoError(t, err)
+
+	children := rd.Document.Body.Children
+	require.Len(t, children, 2)
+	assert.Same(t, chunk, children[1].AltChunk)
+
+	rel := rd.Document.GetRelationByID(chunk.ID)
+	require.NotNil(t, rel)
+	assert.Equal(t, constants.AltChunkType, rel.Type)
+	assert.Equal(t, "afchunk1.html", rel.Target)
+
+	content, ok := rd.ReadPart(rel.Target)
+	require.True(t, ok)
+	assert.Equal(t, "<p>Imported</p>", string(content))
+	assert.Contains(t, rd.ContentType.Override, Override{PartName: "/word/afchunk1.html", ContentType: constants.AltChunkHTML})
+
+	second, err := rd.AddAltChunk([]byte(`{\rtf1 text}`), constants.AltChunkRTF)
+	require.NoError(t, err)
+	assert.Equal(t, "afchunk1.rtf", rd.Document.GetRelationByID(second.ID).Target)
+
+	_, err = rd.AddAltChunk([]byte("data"), "application/octet-stream")
+	assert.Error(t, err)
+}
+
+func TestBody_AltChunkRoundTrip(t *testing.T) {
+	input := `<w:body xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<w:p></w:p><w:altChunk r:id="rId5"><w:altChunkPr><w:matchSrc/></w:altChunkPr></w:altChunk><w:p></w:p></w:body>`
+
+	body := NewBody(nil)
+	require.NoError(t, xml.Unmarshal([]byte(input), body))
+	require.Len(t, body.Children, 3)
+	require.NotNil(t, body.Children[1].AltChunk)
+	assert.Equal(t, "rId5", body.Children[1].AltChunk.ID)
+	assert.True(t, body.Children[1].AltChunk.MatchSrc.Bool())
+
+	output, err := xml.Marshal(body)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), `<w:altChunk r:id="rId5"><w:altChunkPr><w:matchSrc></w:matchSrc></w:altChunkPr></w:altChunk>`)
+}
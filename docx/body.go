@@ -14,10 +14,13 @@ type Body struct {
 	SectPr   *ctypes.SectionProp
 }
 
-// DocumentChild represents a child element within a Word document, which can be a Paragraph or a Table.
+// DocumentChild represents a child element within a Word document, which can be a Paragraph, a Table,
+// an AltChunk importing external content or a block-level content control.
 type DocumentChild struct {
-	Para  *Paragraph
-	Table *Table
+	Para     *Paragraph
+	Table    *Table
+	AltChunk *ctypes.AltChunk
+	Sdt      *ctypes.StructuredDocumentTag
 }
 
 // Use this function to initialize a new Body before adding content to it.
@@ -50,6 +53,18 @@ func (b Body) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
 					return err
 				}
 			}
+
+			if child.AltChunk != nil {
+				if err = child.AltChunk.MarshalXML(e, xml.StartElement{}); err != nil {
+					return err
+				}
+			}
+
+			if child.Sdt != nil {
+				if err = child.Sdt.MarshalXML(e, xml.StartElement{}); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
@@ -87,6 +102,18 @@ func (body *Body) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err erro
 					return err
 				}
 				body.Children = append(body.Children, DocumentChild{Table: tbl})
+			case "altChunk":
+				chunk := &ctypes.AltChunk{}
+				if err := d.DecodeElement(chunk, &elem); err != nil {
+					return err
+				}
+				body.Children = append(body.Children, DocumentChild{AltChunk: chunk})
+			case "sdt":
+				sdt := &ctypes.StructuredDocumentTag{}
+				if err := d.DecodeElement(sdt, &elem); err != nil {
+					return err
+				}
+				body.Children = append(body.Children, DocumentChild{Sdt: sdt})
 			case "sectPr":
 				body.SectPr = ctypes.NewSectionProper()
 				if err := d.DecodeElement(body.SectPr, &elem); err != nil {
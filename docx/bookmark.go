@@ -0,0 +1,85 @@
+package docx
+
+import (
+	"strconv"
+
+	"github.com/bfoley13/godocx/wml/ctypes"
+)
+
+// AddBookmark marks the current content of the paragraph with a bookmark, so that REF and
+// PAGEREF fields can refer to it by name.
+func (p *Paragraph) AddBookmark(name string) {
+	id := 0
+	if p.root != nil {
+		id = p.root.nextBookmarkID()
+	}
+	addBookmark(&p.ct, id, name)
+}
+
+// addBookmark wraps the content of a paragraph with the start and end of a bookmark.
+func addBookmark(para *ctypes.Paragraph, id int, name string) {
+	start := ctypes.ParagraphChild{BookmarkStart: &ctypes.BookmarkStart{
+		ID:   ctypes.NewDecimalNum(id),
+		Name: ctypes.NewCTString(name),
+	}}
+	end := ctypes.ParagraphChild{BookmarkEnd: &ctypes.BookmarkEnd{ID: ctypes.NewDecimalNum(id)}}
+
+	para.Children = append([]ctypes.ParagraphChild{start}, append(para.Children, end)...)
+}
+
+// insertBookmark adds a bookmark holding the children of a paragraph from index from to index
+// to.
+func insertBookmark(para *ctypes.Paragraph, id int, name string, from, to int) {
+	start := ctypes.ParagraphChild{BookmarkStart: &ctypes.BookmarkStart{
+		ID:   ctypes.NewDecimalNum(id),
+		Name: ctypes.NewCTString(name),
+	}}
+	end := ctypes.ParagraphChild{BookmarkEnd: &ctypes.BookmarkEnd{ID: ctypes.NewDecimalNum(id)}}
+
+	children := make([]ctypes.ParagraphChild, 0, len(para.Children)+2)
+	children = append(children, para.Children[:from]...)
+	children = append(children, start)
+	children = append(children, para.Children[from:to+1]...)
+	children = append(children, end)
+	para.Children = append(children, para.Children[to+1:]...)
+}
+
+// Bookmarks returns the names of the bookmarks of the document body, in document order.
+func (rd *RootDoc) Bookmarks() []string {
+	var names []string
+	for _, para := range rd.bodyParagraphs() {
+		for _, child := range para.Children {
+			if child.BookmarkStart != nil && child.BookmarkStart.Name != nil {
+				names = append(names, child.BookmarkStart.Name.Val)
+			}
+		}
+	}
+	return names
+}
+
+// nextBookmarkID returns a bookmark ID that is not used in the document body.
+func (rd *RootDoc) nextBookmarkID() int {
+	next := 0
+	for _, para := range rd.bodyParagraphs() {
+		for _, child := range para.Children {
+			if child.BookmarkStart != nil && child.BookmarkStart.ID != nil && child.BookmarkStart.ID.Val >= next {
+				next = child.BookmarkStart.ID.Val + 1
+			}
+		}
+	}
+	return next
+}
+
+// newBookmarkName returns the first name made of the prefix and a number that is not the name
+// of a bookmark of the document body.
+func (rd *RootDoc) newBookmarkName(prefix string) string {
+	used := make(map[string]bool)
+	for _, name := range rd.Bookmarks() {
+		used[name] = true
+	}
+	n := 1
+	for used[prefix+strconv.Itoa(n)] {
+		n++
+	}
+	return prefix + strconv.Itoa(n)
+}
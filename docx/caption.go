@@ -0,0 +1,237 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bfoley13/godocx/internal"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+const (
+	captionStyle        = "Caption"
+	tableOfFiguresStyle = "TableofFigures"
+	tofNoEntriesText    = "No table of figures entries found."
+)
+
+// CaptionPosition is the place of a caption relative to the item it describes.
+type CaptionPosition int
+
+const (
+	// CaptionDefault places captions below pictures and above tables.
+	CaptionDefault CaptionPosition = iota
+	CaptionAbove
+	CaptionBelow
+)
+
+// CaptionOptions configures a caption added with AddCaption.
+type CaptionOptions struct {
+	// Position is the place of the caption relative to the item.
+	Position CaptionPosition
+
+	// ChapterLevel is the heading level, from 1 to 9, whose number prefixes the caption
+	// number, as in "Figure 2-1". Captions are numbered from 1 again after each heading of
+	// that level. The chapter number counts the headings of the level, which matches heading
+	// numbering that is not restarted. No chapter number is shown when it is 0.
+	ChapterLevel int
+
+	// Separator separates the chapter number from the caption number. It defaults to "-".
+	Separator string
+}
+
+// AddCaption adds a caption paragraph, such as "Figure 1: Overview", below the picture. The
+// number is a SEQ field of the label sequence, computed from the captions before it, and
+// text follows it after a colon when it is not empty.
+//
+// Parameters:
+//   - label: The sequence the caption is numbered in, such as "Figure".
+//   - text: The text of the caption.
+//   - opts: Optional placement and chapter numbering of the caption.
+//
+// Returns:
+//   - *Paragraph: The caption paragraph.
+//   - error: An error if the options are invalid or the picture is not in the document body.
+func (pm *PicMeta) AddCaption(label, text string, opts ...CaptionOptions) (*Paragraph, error) {
+	return pm.Para.root.addCaption(&pm.Para.ct, nil, label, text, CaptionBelow, opts)
+}
+
+// AddCaption adds a caption paragraph, such as "Table 1: Results", above the table. See
+// PicMeta.AddCaption for the caption content.
+func (t *Table) AddCaption(label, text string, opts ...CaptionOptions) (*Paragraph, error) {
+	return t.root.addCaption(nil, &t.ct, label, text, CaptionAbove, opts)
+}
+
+// addCaption adds a caption next to the paragraph para or the table tbl and renumbers the
+// captions of the document.
+func (rd *RootDoc) addCaption(para *ctypes.Paragraph, tbl *ctypes.Table, label, text string, position CaptionPosition, opts []CaptionOptions) (*Paragraph, error) {
+	var opt CaptionOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Position != CaptionDefault {
+		position = opt.Position
+	}
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return nil, errors.New("caption label is empty")
+	}
+	if opt.ChapterLevel < 0 || opt.ChapterLevel > 9 {
+		return nil, fmt.Errorf("invalid caption chapter level %d", opt.ChapterLevel)
+	}
+
+	p := newParagraph(rd)
+	p.Style(captionStyle)
+	if position == CaptionAbove {
+		p.ct.Property.KeepNext = onOffElem(true)
+	}
+
+	p.AddText(label + " ")
+	seq := "SEQ " + quoteFieldArg(label) + ` \* ARABIC`
+	if opt.ChapterLevel > 0 {
+		separator := opt.Separator
+		if separator == "" {
+			separator = "-"
+		}
+		p.AddField(fmt.Sprintf(`STYLEREF %d \s`, opt.ChapterLevel), "")
+		p.AddText(separator)
+		seq += fmt.Sprintf(` \s %d`, opt.ChapterLevel)
+	}
+	p.AddField(seq, "")
+	if text != "" {
+		p.AddText(": " + text)
+	}
+
+	if err := rd.insertNextTo(para, tbl, p, position != CaptionAbove); err != nil {
+		return nil, err
+	}
+	rd.ensureCaptionStyle()
+	rd.renumberCaptions()
+
+	return p, nil
+}
+
+// insertNextTo inserts p before or after the paragraph para or the table tbl, which is found
+// in the document body or in a table cell.
+func (rd *RootDoc) insertNextTo(para *ctypes.Paragraph, tbl *ctypes.Table, p *Paragraph, after bool) error {
+	for i, child := range rd.Document.Body.Children {
+		if (child.Para != nil && &child.Para.ct == para) || (child.Table != nil && &child.Table.ct == tbl) {
+			if after {
+				i++
+			}
+			rd.insertBodyChildren(i, DocumentChild{Para: p})
+			return nil
+		}
+		if child.Table != nil && insertInTable(&child.Table.ct, para, tbl, &p.ct, after) {
+			return nil
+		}
+	}
+	return errors.New("item not found in the document body")
+}
+
+// insertInTable inserts p before or after the paragraph para or the table tbl in the cells of
+// a table and its nested tables. It reports whether the item was found.
+func insertInTable(table *ctypes.Table, para *ctypes.Paragraph, tbl *ctypes.Table, p *ctypes.Paragraph, after bool) bool {
+	for _, rowContent := range table.RowContents {
+		if rowContent.Row == nil {
+			continue
+		}
+		for _, cellContent := range rowContent.Row.Contents {
+			cell := cellContent.Cell
+			if cell == nil {
+				continue
+			}
+			for i, block := range cell.Contents {
+				if (para != nil && block.Paragraph == para) || (tbl != nil && block.Table == tbl) {
+					if after {
+						i++
+					}
+					contents := make([]ctypes.TCBlockContent, 0, len(cell.Contents)+1)
+					contents = append(contents, cell.Contents[:i]...)
+					contents = append(contents, ctypes.TCBlockContent{Paragraph: p})
+					cell.Contents = append(contents, cell.Contents[i:]...)
+					return true
+				}
+				if block.Table != nil && insertInTable(block.Table, para, tbl, p, after) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// renumberCaptions updates the results of the SEQ fields of the document body, and of the
+// STYLEREF fields giving their chapter numbers, after content is inserted.
+func (rd *RootDoc) renumberCaptions() {
+	if rd.Document == nil || rd.Document.Body == nil {
+		return
+	}
+	e := &fieldEngine{rd: rd, only: map[string]bool{"SEQ": true, "STYLEREF": true}}
+	e.run()
+}
+
+// InsertTableOfFigures inserts a list of the captions of a sequence, such as "Figure", before
+// the anchor paragraph, or at the end of the body when anchor is nil. The list is a TOC field
+// whose result is filled in with an entry for every caption, styled TableofFigures and
+// linking to a _Toc bookmark added to the caption, as InsertTOC does for headings.
+func (rd *RootDoc) InsertTableOfFigures(anchor *Paragraph, label string) error {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return errors.New("caption label is empty")
+	}
+	index, err := rd.anchorIndex(anchor)
+	if err != nil {
+		return err
+	}
+
+	rd.renumberCaptions()
+	entries := rd.tocEntries(func(para *ctypes.Paragraph) int {
+		if seqFieldCount(para, label) > 0 {
+			return 1
+		}
+		return 0
+	})
+	rd.addMissingStyle(ctypes.Style{
+		Type:           internal.ToPtr(stypes.StyleTypeParagraph),
+		ID:             internal.ToPtr(tableOfFiguresStyle),
+		Name:           ctypes.NewCTString("table of figures"),
+		BasedOn:        rd.styleIfDefined("Normal"),
+		Next:           rd.styleIfDefined("Normal"),
+		UIPriority:     ctypes.NewDecimalNum(99),
+		UnhideWhenUsed: onOffElem(true),
+	})
+
+	instr := fmt.Sprintf(`TOC \h \z \c "%s"`, label)
+	paras := rd.tocFieldParagraphs(instr, entries, tofNoEntriesText, func(tocEntry) string {
+		return tableOfFiguresStyle
+	})
+	children := make([]DocumentChild, 0, len(paras))
+	for _, p := range paras {
+		children = append(children, DocumentChild{Para: p})
+	}
+	rd.insertBodyChildren(index, children...)
+
+	return rd.SetUpdateFieldsOnOpen(true)
+}
+
+// ensureCaptionStyle adds the Caption style when the document does not define it.
+func (rd *RootDoc) ensureCaptionStyle() {
+	rd.addMissingStyle(ctypes.Style{
+		Type:           internal.ToPtr(stypes.StyleTypeParagraph),
+		ID:             internal.ToPtr(captionStyle),
+		Name:           ctypes.NewCTString("caption"),
+		BasedOn:        rd.styleIfDefined("Normal"),
+		Next:           rd.styleIfDefined("Normal"),
+		UIPriority:     ctypes.NewDecimalNum(35),
+		UnhideWhenUsed: onOffElem(true),
+		QFormat:        onOffElem(true),
+		ParaProp:       &ctypes.ParagraphProp{Spacing: &ctypes.Spacing{After: internal.ToPtr(uint64(200))}},
+		RunProp: &ctypes.RunProperty{
+			Italic: onOffElem(true),
+			Color:  ctypes.NewColor("44546A"),
+			Size:   ctypes.NewFontSize(18),
+		},
+	})
+}
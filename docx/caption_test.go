@@ -0,0 +1,161 @@
+package docx
+
+import (
+	"testing"
+
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addTestPicture(t *testing.T, rd *RootDoc) *PicMeta {
+	t.Helper()
+	pic, err := rd.AddEmptyParagraph().AddPictureBytes([]byte("png"), ".png", 1, 1)
+	require.NoError(t, err)
+	return pic
+}
+
+func TestAddCaption(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	first := addTestPicture(t, rd)
+	firstCaption, err := first.AddCaption("Figure", "Overview")
+	require.NoError(t, err)
+
+	tbl := rd.AddTable()
+	tbl.AddRow().AddCell().AddParagraph("Data")
+	tableCaption, err := tbl.AddCaption("Table", "Results")
+	require.NoError(t, err)
+
+	second := addTestPicture(t, rd)
+	secondCaption, err := second.AddCaption("Figure", "")
+	require.NoError(t, err)
+
+	body := rd.Document.Body.Children
+	require.Len(t, body, 6)
+	assert.Same(t, firstCaption, body[1].Para)
+	assert.Same(t, tableCaption, body[2].Para)
+	assert.Same(t, secondCaption, body[5].Para)
+
+	assert.Equal(t, "Figure 1: Overview", paragraphPlainText(&firstCaption.ct))
+	assert.Equal(t, "Table 1: Results", paragraphPlainText(&tableCaption.ct))
+	assert.Equal(t, "Figure 2", paragraphPlainText(&secondCaption.ct))
+	assert.Equal(t, captionStyle, firstCaption.ct.Property.Style.Val)
+	assert.NotNil(t, tableCaption.ct.Property.KeepNext)
+	assert.Nil(t, firstCaption.ct.Property.KeepNext)
+	assert.NotNil(t, rd.GetStyleByID(captionStyle, stypes.StyleTypeParagraph))
+
+	fields := scanFieldsAcrossRuns(paragraphRuns(&firstCaption.ct))
+	require.Len(t, fields, 1)
+	assert.Equal(t, ` SEQ Figure \* ARABIC `, fields[0].code)
+
+	// A caption inserted before the others renumbers them
+	earlier := rd.AddEmptyParagraph()
+	children := rd.Document.Body.Children
+	rd.Document.Body.Children = append([]DocumentChild{children[len(children)-1]}, children[:len(children)-1]...)
+	_, err = (&PicMeta{Para: earlier}).AddCaption("Figure", "Earlier")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Figure 2: Overview", paragraphPlainText(&firstCaption.ct))
+	assert.Equal(t, "Figure 3", paragraphPlainText(&secondCaption.ct))
+	assert.Equal(t, "Table 1: Results", paragraphPlainText(&tableCaption.ct))
+}
+
+func TestAddCaption_ChapterNumbers(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	rd.AddParagraph("Introduction").Style("Heading1")
+	one, err := addTestPicture(t, rd).AddCaption("Figure", "A", CaptionOptions{ChapterLevel: 1})
+	require.NoError(t, err)
+	two, err := addTestPicture(t, rd).AddCaption("Figure", "B", CaptionOptions{ChapterLevel: 1})
+	require.NoError(t, err)
+	rd.AddParagraph("Method").Style("Heading1")
+	rd.AddParagraph("Setup").Style("Heading2")
+	three, err := addTestPicture(t, rd).AddCaption("Figure", "C", CaptionOptions{ChapterLevel: 2, Separator: "."})
+	require.NoError(t, err)
+	above, err := addTestPicture(t, rd).AddCaption("Figure", "D", CaptionOptions{Position: CaptionAbove, ChapterLevel: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Figure 1-1: A", paragraphPlainText(&one.ct))
+	assert.Equal(t, "Figure 1-2: B", paragraphPlainText(&two.ct))
+	assert.Equal(t, "Figure 2.1.1: C", paragraphPlainText(&three.ct))
+	assert.Equal(t, "Figure 2-1: D", paragraphPlainText(&above.ct))
+
+	fields := scanFieldsAcrossRuns(paragraphRuns(&one.ct))
+	require.Len(t, fields, 2)
+	assert.Equal(t, ` STYLEREF 1 \s `, fields[0].code)
+	assert.Equal(t, ` SEQ Figure \* ARABIC \s 1 `, fields[1].code)
+
+	body := rd.Document.Body.Children
+	assert.Same(t, above, body[len(body)-2].Para)
+}
+
+func TestAddCaption_InCell(t *testing.T) {
+	rd := setupRootDoc(t)
+	cell := rd.AddTable().AddRow().AddCell()
+	pic := &PicMeta{Para: cell.AddParagraph("picture")}
+
+	caption, err := pic.AddCaption("Figure", "In a cell")
+	require.NoError(t, err)
+
+	require.Len(t, cell.ct.Contents, 2)
+	assert.Same(t, &caption.ct, cell.ct.Contents[1].Paragraph)
+	assert.Equal(t, "Figure 1: In a cell", paragraphPlainText(&caption.ct))
+}
+
+func TestAddCaption_Errors(t *testing.T) {
+	rd := setupRootDoc(t)
+	pic := addTestPicture(t, rd)
+
+	_, err := pic.AddCaption(" ", "text")
+	assert.Error(t, err)
+	_, err = pic.AddCaption("Figure", "text", CaptionOptions{ChapterLevel: 10})
+	assert.Error(t, err)
+	_, err = (&PicMeta{Para: newParagraph(rd)}).AddCaption("Figure", "text")
+	assert.Error(t, err)
+	assert.Len(t, rd.Document.Body.Children, 1)
+}
+
+func TestInsertTableOfFigures(t *testing.T) {
+	rd := setupRootDoc(t)
+	intro := rd.AddParagraph("Introduction")
+	_, err := addTestPicture(t, rd).AddCaption("Figure", "Overview")
+	require.NoError(t, err)
+	rd.AddPageBreak()
+	_, err = addTestPicture(t, rd).AddCaption("Figure", "Detail")
+	require.NoError(t, err)
+	tbl := rd.AddTable()
+	tbl.AddRow().AddCell().AddParagraph("Data")
+	_, err = tbl.AddCaption("Table", "Results")
+	require.NoError(t, err)
+
+	require.NoError(t, rd.InsertTableOfFigures(intro, "Figure"))
+
+	body := rd.Document.Body.Children
+	first := body[0].Para
+	require.NotNil(t, first)
+	assert.Equal(t, tableOfFiguresStyle, first.ct.Property.Style.Val)
+	assert.Equal(t, ` TOC \h \z \c "Figure" `, first.ct.Children[0].Run.Children[1].InstrText.Text)
+
+	expected := []struct {
+		text string
+		page string
+	}{
+		{"Figure 1: Overview", "1"},
+		{"Figure 2: Detail", "2"},
+	}
+	for i, entry := range expected {
+		p := body[i].Para
+		link := p.ct.Children[len(p.ct.Children)-1].Link
+		require.NotNil(t, link)
+		assert.Equal(t, entry.text, link.Children[0].Run.Children[0].Text.Text)
+		assert.Equal(t, entry.page, link.Children[2].Run.Children[3].Text.Text)
+	}
+	assert.Same(t, intro, body[3].Para)
+	assert.Equal(t, []string{"_Toc1", "_Toc2"}, rd.Bookmarks())
+	assert.NotNil(t, rd.GetStyleByID(tableOfFiguresStyle, stypes.StyleTypeParagraph))
+
+	require.NoError(t, rd.InsertTableOfFigures(nil, "Equation"))
+	last := rd.Document.Body.Children[len(rd.Document.Body.Children)-1].Para
+	assert.Equal(t, tofNoEntriesText, paragraphPlainText(&last.ct))
+}
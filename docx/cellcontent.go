@@ -0,0 +1,76 @@
+package docx
+
+import (
+	"github.com/bfoley13/godocx/common/units"
+	"github.com/bfoley13/godocx/wml/ctypes"
+)
+
+// AddTable adds a table nested in the cell and returns it. The table shares the root
+// document of the cell.
+//
+// As a cell must end with a paragraph, the table is followed by an empty paragraph, which the
+// next paragraph added to the cell with AddParagraph, AddEmptyPara, AddPicture,
+// AddContentControl or AddLink replaces.
+func (c *Cell) AddTable() *Table {
+	tbl := &Table{
+		root: c.root,
+		ct:   *ctypes.DefaultTable(),
+	}
+
+	c.ct.Contents = append(c.ct.Contents,
+		ctypes.TCBlockContent{Table: &tbl.ct},
+		ctypes.TCBlockContent{Paragraph: &ctypes.Paragraph{}},
+	)
+
+	return tbl
+}
+
+// AddPicture adds a paragraph holding an image to the cell.
+//
+// Parameters:
+//   - path: The path of the image file to be added.
+//   - width: The width of the image in inches.
+//   - height: The height of the image in inches.
+//
+// Returns:
+//   - *PicMeta: Metadata about the added picture, including the Paragraph instance and Inline element.
+//   - error: An error, if any occurred during the process.
+func (c *Cell) AddPicture(path string, width units.Inch, height units.Inch) (*PicMeta, error) {
+	return c.addParagraph(newParagraph(c.root)).AddPicture(path, width, height)
+}
+
+// AddContentControl adds a paragraph holding a basic content control to the cell.
+func (c *Cell) AddContentControl(alias, tag string, controlType ContentControlType) *ContentControl {
+	sdt := c.root.newContentControlSdt(alias, tag, controlType)
+
+	p := c.addParagraph(newParagraph(c.root))
+	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Sdt: sdt})
+
+	return newContentControl(c.root, sdt)
+}
+
+// AddLink adds a paragraph holding a hyperlink to the cell.
+func (c *Cell) AddLink(text string, link string) *Hyperlink {
+	return c.addParagraph(newParagraph(c.root)).AddLink(text, link)
+}
+
+// AddInternalLink adds a paragraph holding a hyperlink to a bookmark to the cell.
+func (c *Cell) AddInternalLink(text string, bookmark string) *Hyperlink {
+	return c.addParagraph(newParagraph(c.root)).AddInternalLink(text, bookmark)
+}
+
+// addParagraph appends a paragraph to the cell. It replaces the empty paragraph that follows
+// a nested table at the end of the cell.
+func (c *Cell) addParagraph(p *Paragraph) *Paragraph {
+	if n := len(c.ct.Contents); n >= 2 && c.ct.Contents[n-2].Table != nil {
+		last := c.ct.Contents[n-1].Paragraph
+		if last != nil && last.Property == nil && len(last.Children) == 0 &&
+			(c.root == nil || !c.root.emptyCellParas[last]) {
+			c.ct.Contents[n-1].Paragraph = &p.ct
+			return p
+		}
+	}
+
+	c.ct.Contents = append(c.ct.Contents, ctypes.TCBlockContent{Paragraph: &p.ct})
+	return p
+}
@@ -0,0 +1,87 @@
+package docx
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCell_AddTable(t *testing.T) {
+	rd := setupRootDoc(t)
+	outer := rd.AddTable()
+	cell := outer.AddRow().AddCell()
+	cell.AddParagraph("Address")
+
+	inner := cell.AddTable()
+	inner.AddRow().AddCell().AddParagraph("Street")
+	inner.AddRow().AddCell().AddParagraph("City")
+
+	contents := cell.GetCT().Contents
+	require.Len(t, contents, 3)
+	assert.Same(t, inner.GetCT(), contents[1].Table)
+	assert.Equal(t, 2, inner.RowCount())
+	require.NotNil(t, contents[2].Paragraph)
+	assert.Empty(t, contents[2].Paragraph.Children)
+	assert.Equal(t, [][]string{{"Street"}, {"City"}}, inner.ToRecords(CSVOptions{}))
+
+	// The next paragraph takes the place of the closing paragraph
+	p := cell.AddParagraph("Notes")
+	contents = cell.GetCT().Contents
+	require.Len(t, contents, 3)
+	assert.Same(t, p.GetCT(), contents[2].Paragraph)
+
+	// An empty paragraph takes that place too
+	cell.AddTable()
+	empty := cell.AddEmptyPara()
+	contents = cell.GetCT().Contents
+	require.Len(t, contents, 5)
+	assert.Same(t, empty.GetCT(), contents[4].Paragraph)
+	next := cell.AddParagraph("More")
+	contents = cell.GetCT().Contents
+	require.Len(t, contents, 6)
+	assert.Same(t, empty.GetCT(), contents[4].Paragraph)
+	assert.Same(t, next.GetCT(), contents[5].Paragraph)
+	cell.GetCT().Contents = contents[:3]
+
+	// Consecutive tables stay separated by a paragraph
+	cell.AddTable()
+	cell.AddTable()
+	contents = cell.GetCT().Contents
+	require.Len(t, contents, 7)
+	assert.NotNil(t, contents[4].Paragraph)
+	assert.NotNil(t, contents[6].Paragraph)
+
+	output, err := xml.Marshal(outer.GetCT())
+	require.NoError(t, err)
+	assert.Contains(t, string(output), `<w:t>City</w:t></w:r></w:p></w:tc></w:tr></w:tbl><w:p><w:r><w:t>Notes</w:t>`)
+}
+
+func TestCell_AddBlocks(t *testing.T) {
+	rd := setupRootDoc(t)
+	cell := rd.AddTable().AddRow().AddCell()
+	cell.AddTable()
+
+	link := cell.AddLink("Docs", "https://example.com")
+	require.NotNil(t, link)
+	contents := cell.GetCT().Contents
+	require.Len(t, contents, 2)
+	assert.Same(t, link.ct, contents[1].Paragraph.Children[0].Link)
+
+	cc := cell.AddContentControl("Name", "name", ContentControlTypeText)
+	require.NotNil(t, cc)
+	contents = cell.GetCT().Contents
+	require.Len(t, contents, 3)
+	assert.NotNil(t, contents[2].Paragraph.Children[0].Sdt)
+
+	path := filepath.Join(t.TempDir(), "pixel.png")
+	require.NoError(t, os.WriteFile(path, []byte("png"), 0o644))
+	pic, err := cell.AddPicture(path, 1, 1)
+	require.NoError(t, err)
+	contents = cell.GetCT().Contents
+	require.Len(t, contents, 4)
+	assert.Same(t, pic.Para.GetCT(), contents[3].Paragraph)
+}
@@ -0,0 +1,133 @@
+package docx
+
+import (
+	"github.com/bfoley13/godocx/wml/ctypes"
+)
+
+// Checkpoint is a record of the state of a document, taken before a change that is undone when
+// it fails part way.
+//
+// It holds the body elements and final section properties, the style list, the numbering
+// definitions, the footnotes and endnotes, the relationships of the main document, the content
+// types and the files of the package. The content of existing body elements, styles and
+// definitions is not copied, so changes made to it are not undone.
+type Checkpoint struct {
+	rd *RootDoc
+
+	children []DocumentChild
+	sectPr   *ctypes.SectionProp
+
+	styles    *ctypes.Styles
+	styleList []ctypes.Style
+
+	numbering    *ctypes.Numbering
+	nums         []ctypes.Num
+	abstractNums []ctypes.AbstractNum
+
+	footnotes, endnotes       *ctypes.Footnotes
+	footnoteList, endnoteList []ctypes.Footnote
+
+	rels            []*Relationship
+	relID, docRelID int
+
+	defaults   []Default
+	overrides  []Override
+	files      map[any]any
+	imageCount uint
+}
+
+// Checkpoint records the state of the document, so that the changes made after it can be undone
+// with Restore.
+//
+// Returns:
+//   - *Checkpoint: The recorded state of the document.
+func (rd *RootDoc) Checkpoint() *Checkpoint {
+	cp := &Checkpoint{
+		rd:         rd,
+		styles:     rd.DocStyles,
+		numbering:  rd.Numbering,
+		footnotes:  rd.footnotes,
+		endnotes:   rd.endnotes,
+		relID:      rd.rID,
+		defaults:   append([]Default(nil), rd.ContentType.Default...),
+		overrides:  append([]Override(nil), rd.ContentType.Override...),
+		files:      make(map[any]any),
+		imageCount: rd.ImageCount,
+	}
+
+	if doc := rd.Document; doc != nil {
+		cp.rels = append([]*Relationship(nil), doc.DocRels.Relationships...)
+		cp.docRelID = doc.RID
+		if doc.Body != nil {
+			cp.children = append([]DocumentChild(nil), doc.Body.Children...)
+			cp.sectPr = doc.Body.SectPr
+		}
+	}
+	if cp.styles != nil {
+		cp.styleList = append([]ctypes.Style(nil), cp.styles.StyleList...)
+	}
+	if cp.numbering != nil {
+		cp.nums = append([]ctypes.Num(nil), cp.numbering.Nums...)
+		cp.abstractNums = append([]ctypes.AbstractNum(nil), cp.numbering.AbstractNums...)
+	}
+	if cp.footnotes != nil {
+		cp.footnoteList = append([]ctypes.Footnote(nil), cp.footnotes.Notes...)
+	}
+	if cp.endnotes != nil {
+		cp.endnoteList = append([]ctypes.Footnote(nil), cp.endnotes.Notes...)
+	}
+
+	rd.FileMap.Range(func(key, value any) bool {
+		cp.files[key] = value
+		return true
+	})
+
+	return cp
+}
+
+// Restore returns the document to the state recorded by the checkpoint. Files added to the
+// package since are removed and files replaced since get their recorded content back.
+func (cp *Checkpoint) Restore() {
+	rd := cp.rd
+
+	if doc := rd.Document; doc != nil {
+		doc.DocRels.Relationships = cp.rels
+		doc.RID = cp.docRelID
+		if doc.Body != nil {
+			doc.Body.Children = cp.children
+			doc.Body.SectPr = cp.sectPr
+		}
+	}
+
+	rd.DocStyles = cp.styles
+	if cp.styles != nil {
+		cp.styles.StyleList = cp.styleList
+	}
+	rd.Numbering = cp.numbering
+	if cp.numbering != nil {
+		cp.numbering.Nums = cp.nums
+		cp.numbering.AbstractNums = cp.abstractNums
+	}
+	rd.footnotes, rd.endnotes = cp.footnotes, cp.endnotes
+	if cp.footnotes != nil {
+		cp.footnotes.Notes = cp.footnoteList
+	}
+	if cp.endnotes != nil {
+		cp.endnotes.Notes = cp.endnoteList
+	}
+
+	rd.rID = cp.relID
+	rd.ContentType.Default = cp.defaults
+	rd.ContentType.Override = cp.overrides
+	rd.ImageCount = cp.imageCount
+
+	rd.FileMap.Range(func(key, _ any) bool {
+		if _, ok := cp.files[key]; !ok {
+			rd.FileMap.Delete(key)
+		}
+		return true
+	})
+	for key, value := range cp.files {
+		rd.FileMap.Store(key, value)
+	}
+}
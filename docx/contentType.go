@@ -3,6 +3,7 @@ package docx
 import (
 	"encoding/xml"
 	"errors"
+	"path"
 	"strings"
 )
 
@@ -25,7 +26,13 @@ type Override struct {
 	ContentType string `xml:"ContentType,attr"`
 }
 
+// AddExtension adds a default content type for a file extension given without the dot. An
+// extension that already has a default keeps it.
 func (c *ContentTypes) AddExtension(extension, contentType string) error {
+	if _, ok := c.defaultFor(extension); ok {
+		return nil
+	}
+
 	c.Default = append(c.Default, Default{
 		Extension:   extension,
 		ContentType: contentType,
@@ -34,7 +41,16 @@ func (c *ContentTypes) AddExtension(extension, contentType string) error {
 	return nil
 }
 
+// AddOverride sets the content type of a part name, such as "/word/header1.xml", overriding
+// the default of its extension. The override of a part that already has one is replaced.
 func (c *ContentTypes) AddOverride(partName, contentType string) error {
+	for i := range c.Override {
+		if strings.EqualFold(c.Override[i].PartName, partName) {
+			c.Override[i].ContentType = contentType
+			return nil
+		}
+	}
+
 	c.Override = append(c.Override, Override{
 		PartName:    partName,
 		ContentType: contentType,
@@ -42,6 +58,47 @@ func (c *ContentTypes) AddOverride(partName, contentType string) error {
 	return nil
 }
 
+// removeOverride removes the override of a part name, if there is one.
+func (c *ContentTypes) removeOverride(partName string) {
+	kept := c.Override[:0]
+	for _, override := range c.Override {
+		if !strings.EqualFold(override.PartName, partName) {
+			kept = append(kept, override)
+		}
+	}
+	c.Override = kept
+}
+
+// partContentType returns the content type of a part name, from its override or the default
+// of its extension.
+func (c *ContentTypes) partContentType(partName string) (string, bool) {
+	if contentType, ok := c.overrideFor(partName); ok {
+		return contentType, true
+	}
+	return c.defaultFor(strings.TrimPrefix(path.Ext(partName), "."))
+}
+
+// overrideFor returns the content type overriding the default of a part name, such as
+// "/word/header1.xml".
+func (c *ContentTypes) overrideFor(partName string) (string, bool) {
+	for _, override := range c.Override {
+		if strings.EqualFold(override.PartName, partName) {
+			return override.ContentType, true
+		}
+	}
+	return "", false
+}
+
+// defaultFor returns the default content type of a file extension given without the dot.
+func (c *ContentTypes) defaultFor(extension string) (string, bool) {
+	for _, def := range c.Default {
+		if strings.EqualFold(def.Extension, extension) {
+			return def.ContentType, true
+		}
+	}
+	return "", false
+}
+
 func MIMEFromExt(extension string) (string, error) {
 	if strings.HasPrefix(extension, ".") {
 		extension = strings.TrimPrefix(extension, ".")
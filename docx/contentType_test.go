@@ -122,6 +122,14 @@ func TestAddExtension(t *testing.T) {
 	if !reflect.DeepEqual(types.Default, expected.Default) {
 		t.Errorf("AddDefault did not add correctly. Got: %+v, Expected: %+v", types.Default, expected.Default)
 	}
+
+	// An extension is added once
+	if err = types.AddExtension("PNG", "image/x-png"); err != nil {
+		t.Errorf("Expected no error but got %v", err)
+	}
+	if !reflect.DeepEqual(types.Default, expected.Default) {
+		t.Errorf("AddDefault added an existing extension. Got: %+v, Expected: %+v", types.Default, expected.Default)
+	}
 }
 
 func TestAddOverride(t *testing.T) {
@@ -137,4 +145,16 @@ func TestAddOverride(t *testing.T) {
 	if !reflect.DeepEqual(types.Override, expected.Override) {
 		t.Errorf("AddOverride did not add correctly. Got: %+v, Expected: %+v", types.Override, expected.Override)
 	}
+
+	// The override of a part is replaced
+	types.AddOverride("/customXml/item2.xml", "application/xml")
+	expected.Override[0].ContentType = "application/xml"
+	if !reflect.DeepEqual(types.Override, expected.Override) {
+		t.Errorf("AddOverride did not replace the override. Got: %+v, Expected: %+v", types.Override, expected.Override)
+	}
+
+	types.removeOverride("/customXml/item2.xml")
+	if len(types.Override) != 0 {
+		t.Errorf("removeOverride did not remove the override. Got: %+v", types.Override)
+	}
 }
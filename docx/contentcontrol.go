@@ -24,6 +24,17 @@ func newContentControl(root *RootDoc, sdt *ctypes.StructuredDocumentTag) *Conten
 
 // AddContentControl adds a basic content control to the document
 func (rd *RootDoc) AddContentControl(alias, tag string, controlType ContentControlType) *ContentControl {
+	sdt := rd.newContentControlSdt(alias, tag, controlType)
+
+	// Add to document body
+	para := rd.AddEmptyParagraph()
+	para.ct.Children = append(para.ct.Children, ctypes.ParagraphChild{Sdt: sdt})
+
+	return newContentControl(rd, sdt)
+}
+
+// newContentControlSdt creates the structured document tag of a basic content control
+func (rd *RootDoc) newContentControlSdt(alias, tag string, controlType ContentControlType) *ctypes.StructuredDocumentTag {
 	sdt := &ctypes.StructuredDocumentTag{
 		Properties: &ctypes.SdtProperties{
 			Alias: ctypes.NewCTString(alias),
@@ -45,11 +56,7 @@ func (rd *RootDoc) AddContentControl(alias, tag string, controlType ContentContr
 		sdt.Properties.Group = &ctypes.Empty{}
 	}
 
-	// Add to document body
-	para := rd.AddEmptyParagraph()
-	para.ct.Children = append(para.ct.Children, ctypes.ParagraphChild{Sdt: sdt})
-
-	return newContentControl(rd, sdt)
+	return sdt
 }
 
 // AddTextContentControl adds a text content control with initial content
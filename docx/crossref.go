@@ -0,0 +1,164 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// refBookmarkPrefix starts the names of the bookmarks added for cross-references. Names
+// starting with an underscore are hidden bookmarks in Word.
+const refBookmarkPrefix = "_Ref"
+
+// CrossRefKind is the content of the target shown by a cross-reference.
+type CrossRefKind int
+
+const (
+	// CrossRefText shows the text of the target paragraph, such as a heading title.
+	CrossRefText CrossRefKind = iota
+	// CrossRefNumber shows the heading number of the target heading, such as "3.2".
+	CrossRefNumber
+	// CrossRefPage shows the number of the page the target is on.
+	CrossRefPage
+	// CrossRefCaption shows the label and number of the target caption, such as "Figure 2".
+	CrossRefCaption
+)
+
+// AddCrossReference appends a cross-reference to the target paragraph, such as a heading or
+// a caption added with AddCaption. The reference is a REF field, or a PAGEREF field for
+// CrossRefPage, linking to a hidden _Ref bookmark that is added to the target unless it has
+// one already. The cached result is computed from the document.
+//
+// Example:
+//
+//	p := document.AddParagraph("see Section ")
+//	p.AddCrossReference(heading, docx.CrossRefNumber)
+//
+// Parameters:
+//   - target: The paragraph referred to, in the document body.
+//   - kind: The content of the target shown by the reference.
+//
+// Returns:
+//   - *Field: The added field.
+//   - error: An error if the target is not in the document body, or does not have the content
+//     shown: a heading for CrossRefNumber, a SEQ field for CrossRefCaption.
+func (p *Paragraph) AddCrossReference(target *Paragraph, kind CrossRefKind) (*Field, error) {
+	rd := p.root
+	if rd == nil || rd.Document == nil || rd.Document.Body == nil || target == nil {
+		return nil, errors.New("target paragraph not found in the document body")
+	}
+
+	found := false
+	rd.walkBodyParagraphs(func(para *ctypes.Paragraph, _ int) {
+		found = found || para == &target.ct
+	})
+	if !found {
+		return nil, errors.New("target paragraph not found in the document body")
+	}
+
+	from, to := contentSpan(&target.ct)
+	if from > to {
+		return nil, errors.New("target paragraph is empty")
+	}
+
+	var instr string
+	switch kind {
+	case CrossRefText:
+		instr = `REF %s \h`
+	case CrossRefNumber:
+		if rd.OutlineLevel(&target.ct) < 0 {
+			return nil, errors.New("target paragraph is not a heading")
+		}
+		instr = `REF %s \r \h`
+	case CrossRefPage:
+		instr = `PAGEREF %s \h`
+	case CrossRefCaption:
+		// The bookmark holds the label and number, up to the end of the SEQ field
+		to = seqFieldEnd(&target.ct)
+		if to < 0 {
+			return nil, errors.New("target paragraph is not a caption")
+		}
+		instr = `REF %s \h`
+	default:
+		return nil, fmt.Errorf("invalid cross-reference kind %d", kind)
+	}
+
+	name := refBookmark(&target.ct, from, to)
+	if name == "" {
+		name = rd.newBookmarkName(refBookmarkPrefix)
+		insertBookmark(&target.ct, rd.nextBookmarkID(), name, from, to)
+	}
+	instr = fmt.Sprintf(instr, name)
+
+	e := &fieldEngine{rd: rd, bookmarks: rd.collectBookmarks()}
+	cached, _ := e.result(instr)
+
+	return p.AddField(instr, cached), nil
+}
+
+// contentSpan returns the indexes of the first and last children of a paragraph that are
+// not bookmark starts or ends. from is greater than to when there are none.
+func contentSpan(para *ctypes.Paragraph) (from, to int) {
+	from, to = len(para.Children), -1
+	for i, child := range para.Children {
+		if isBookmarkMark(child) {
+			continue
+		}
+		if i < from {
+			from = i
+		}
+		to = i
+	}
+	return from, to
+}
+
+// isBookmarkMark reports whether a paragraph child is a bookmark start or end.
+func isBookmarkMark(child ctypes.ParagraphChild) bool {
+	return child.BookmarkStart != nil || child.BookmarkEnd != nil
+}
+
+// seqFieldEnd returns the index of the paragraph child ending the first SEQ field of the
+// paragraph, or -1 when it has none.
+func seqFieldEnd(para *ctypes.Paragraph) int {
+	inSeq := false
+	for i, child := range para.Children {
+		if child.Run == nil {
+			continue
+		}
+		for _, runChild := range child.Run.Children {
+			switch {
+			case runChild.InstrText != nil:
+				args := splitFieldInstruction(runChild.InstrText.Text)
+				if len(args) > 0 && strings.EqualFold(args[0], "SEQ") {
+					inSeq = true
+				}
+			case inSeq && runChild.FldChar != nil && runChild.FldChar.FldCharType != nil &&
+				runChild.FldChar.FldCharType.Val == stypes.FldCharTypeEnd:
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// refBookmark returns the name of a _Ref bookmark of the paragraph holding exactly the
+// children from index from to index to, or the empty string when there is none.
+func refBookmark(para *ctypes.Paragraph, from, to int) string {
+	for i := from - 1; i >= 0 && isBookmarkMark(para.Children[i]); i-- {
+		start := para.Children[i].BookmarkStart
+		if start == nil || start.ID == nil || start.Name == nil ||
+			!strings.HasPrefix(start.Name.Val, refBookmarkPrefix) {
+			continue
+		}
+		for j := to + 1; j < len(para.Children) && isBookmarkMark(para.Children[j]); j++ {
+			end := para.Children[j].BookmarkEnd
+			if end != nil && end.ID != nil && end.ID.Val == start.ID.Val {
+				return start.Name.Val
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,112 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddInternalLink(t *testing.T) {
+	rd := setupRootDoc(t)
+	target := rd.AddParagraph("Terms")
+	target.AddBookmark("terms")
+
+	p := rd.AddParagraph("See ")
+	link := p.AddInternalLink("the terms", "terms").Tooltip("Go to the terms").Bold(true)
+
+	assert.Equal(t, "terms", link.ct.Anchor)
+	assert.Empty(t, link.ct.ID)
+	assert.Empty(t, rd.Document.DocRels.Relationships)
+	assert.Equal(t, "See the terms", paragraphPlainText(&p.ct))
+
+	output, err := xml.Marshal(p.ct.Children[1].Link)
+	require.NoError(t, err)
+	assert.Equal(t, `<w:hyperlink w:anchor="terms" w:tooltip="Go to the terms" w:history="true">`+
+		`<w:r><w:rPr><w:rStyle w:val="Hyperlink"></w:rStyle><w:b w:val="true"></w:b></w:rPr><w:t>the terms</w:t></w:r></w:hyperlink>`, string(output))
+
+	link.History(false)
+	assert.False(t, link.ct.History.Bool())
+}
+
+func TestAddCrossReference(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	rd.AddParagraph("Introduction").Style("Heading1")
+	rd.AddParagraph("Specification").Style("Heading1")
+	rd.AddParagraph("Inputs").Style("Heading2")
+	section := rd.AddParagraph("Limits")
+	section.Style("Heading2")
+	rd.AddPageBreak()
+	caption, err := addTestPicture(t, rd).AddCaption("Figure", "Layout")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		target *Paragraph
+		kind   CrossRefKind
+		instr  string
+		result string
+	}{
+		{"Text", section, CrossRefText, ` REF _Ref1 \h `, "Limits"},
+		{"Number", section, CrossRefNumber, ` REF _Ref1 \r \h `, "2.2"},
+		{"Page", section, CrossRefPage, ` PAGEREF _Ref1 \h `, "1"},
+		{"Caption", caption, CrossRefCaption, ` REF _Ref2 \h `, "Figure 1"},
+		{"Caption page", caption, CrossRefPage, ` PAGEREF _Ref3 \h `, "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := rd.AddParagraph("see ")
+			field, err := p.AddCrossReference(tt.target, tt.kind)
+			require.NoError(t, err)
+			require.NotNil(t, field)
+
+			fields := scanFieldsAcrossRuns(paragraphRuns(&p.ct))
+			require.Len(t, fields, 1)
+			assert.Equal(t, tt.instr, fields[0].code)
+			assert.Equal(t, "see "+tt.result, paragraphPlainText(&p.ct))
+		})
+	}
+
+	// The references to a paragraph share its bookmark, and the caption bookmark holds the
+	// label and number only
+	assert.Equal(t, []string{"_Ref1", "_Ref2", "_Ref3"}, rd.Bookmarks())
+	assert.NotNil(t, section.ct.Children[0].BookmarkStart)
+	assert.NotNil(t, section.ct.Children[2].BookmarkEnd)
+	bookmarks := rd.collectBookmarks()
+	assert.Equal(t, "Figure 1", bookmarks["_Ref2"].text)
+	assert.Equal(t, "Figure 1: Layout", bookmarks["_Ref3"].text)
+	assert.Equal(t, "2.2", bookmarks["_Ref1"].number)
+}
+
+func TestAddCrossReference_Errors(t *testing.T) {
+	rd := setupRootDoc(t)
+	body := rd.AddParagraph("Body text")
+	empty := rd.AddEmptyParagraph()
+	p := rd.AddParagraph("see ")
+
+	tests := []struct {
+		name   string
+		target *Paragraph
+		kind   CrossRefKind
+	}{
+		{"Not in body", newParagraph(rd, paraWithText("Detached")), CrossRefText},
+		{"Nil target", nil, CrossRefText},
+		{"Empty target", empty, CrossRefText},
+		{"Number of body text", body, CrossRefNumber},
+		{"Caption of body text", body, CrossRefCaption},
+		{"Invalid kind", body, CrossRefKind(9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.AddCrossReference(tt.target, tt.kind)
+			assert.Error(t, err)
+		})
+	}
+
+	assert.Empty(t, rd.Bookmarks())
+	assert.Equal(t, "see ", paragraphPlainText(&p.ct))
+}
@@ -170,9 +170,24 @@ func (rd *RootDoc) replaceInParagraph(para *Paragraph, oldText, newText string)
 		if child.Run != nil {
 			replacements += rd.replaceInRun(child.Run, oldText, newText)
 		} else if child.Link != nil {
-			if child.Link.Run != nil {
-				replacements += rd.replaceInRun(child.Link.Run, oldText, newText)
-			}
+			replacements += rd.replaceInLink(child.Link, oldText, newText)
+		} else if child.Sdt != nil {
+			replacements += rd.replaceInContentControl(child.Sdt, oldText, newText)
+		}
+	}
+
+	return replacements
+}
+
+// replaceInLink replaces text within the runs and content controls of a hyperlink
+func (rd *RootDoc) replaceInLink(link *ctypes.Hyperlink, oldText, newText string) int {
+	replacements := 0
+
+	for _, child := range link.Content() {
+		if child.Run != nil {
+			replacements += rd.replaceInRun(child.Run, oldText, newText)
+		} else if child.Link != nil {
+			replacements += rd.replaceInLink(child.Link, oldText, newText)
 		} else if child.Sdt != nil {
 			replacements += rd.replaceInContentControl(child.Sdt, oldText, newText)
 		}
@@ -249,9 +264,7 @@ func (rd *RootDoc) replaceInCTypeParagraph(para *ctypes.Paragraph, oldText, newT
 		if child.Run != nil {
 			replacements += rd.replaceInRun(child.Run, oldText, newText)
 		} else if child.Link != nil {
-			if child.Link.Run != nil {
-				replacements += rd.replaceInRun(child.Link.Run, oldText, newText)
-			}
+			replacements += rd.replaceInLink(child.Link, oldText, newText)
 		}
 	}
 
@@ -342,8 +355,8 @@ func (rd *RootDoc) replaceFieldsInParagraph(para *Paragraph, fieldMap map[string
 			runs = append(runs, child.Run)
 			runIndices = append(runIndices, i)
 		} else if child.Link != nil {
-			if child.Link.Run != nil {
-				runs = append(runs, child.Link.Run)
+			for _, run := range linkRuns(child.Link) {
+				runs = append(runs, run)
 				runIndices = append(runIndices, i)
 			}
 		} else if child.Sdt != nil {
@@ -405,17 +418,7 @@ func (rd *RootDoc) replaceFieldsInCTypeParagraph(para *ctypes.Paragraph, fieldMa
 	replacements := 0
 
 	// Collect all runs from the paragraph for field processing
-	var runs []*ctypes.Run
-
-	for _, child := range para.Children {
-		if child.Run != nil {
-			runs = append(runs, child.Run)
-		} else if child.Link != nil {
-			if child.Link.Run != nil {
-				runs = append(runs, child.Link.Run)
-			}
-		}
-	}
+	runs := paragraphRuns(para)
 
 	// Process fields across all runs in the paragraph
 	if len(runs) > 0 {
@@ -458,130 +461,92 @@ func (rd *RootDoc) replaceFieldsInContentControl(sdt *ctypes.StructuredDocumentT
 func (rd *RootDoc) replaceFieldsAcrossRuns(runs []*ctypes.Run, fieldMap map[string]string) int {
 	replacements := 0
 
-	// Track field state across all runs
-	fieldState := "none" // none, begin, instrText, separate, result
-	var currentFieldCode strings.Builder
-	var resultElements []fieldResultElement // stores references to text elements containing field results
-
-	// Iterate through all runs and their children to find field sequences
-	for runIdx, run := range runs {
-		for childIdx, child := range run.Children {
-			if child.FldChar != nil {
-				switch child.FldChar.FldCharType.Val {
-				case stypes.FldCharTypeBegin:
-					currentFieldCode.Reset()
-					resultElements = []fieldResultElement{}
-					fieldState = "begin"
-
-					if child.FldChar.FFData != nil && child.FldChar.FFData.TextInput != nil && child.FldChar.FFData.TextInput.Default != nil {
-						if _, exists := fieldMap[strings.TrimSpace(child.FldChar.FFData.TextInput.Default.Val)]; exists {
-							currentFieldCode.WriteString(child.FldChar.FFData.TextInput.Default.Val)
-						}
-					}
-				case stypes.FldCharTypeSeparate:
-					fieldState = "separate"
-					resultElements = []fieldResultElement{}
-				case stypes.FldCharTypeEnd:
-					if fieldState == "separate" {
-						// We have a complete field, try to replace it
-						fieldCode := strings.TrimSpace(currentFieldCode.String())
-						if replacement, exists := fieldMap[fieldCode]; exists {
-							// Replace all result text elements with the replacement
-							rd.replaceFieldResultAcrossRuns(resultElements, replacement)
-							replacements++
-						}
-					}
-					fieldState = "none"
-					currentFieldCode.Reset()
-					resultElements = []fieldResultElement{}
-				}
-			} else if child.InstrText != nil && (fieldState == "begin" || fieldState == "instrText") {
-				fieldState = "instrText"
-				currentFieldCode.WriteString(child.InstrText.Text)
-			} else if child.Text != nil && fieldState == "separate" {
-				resultElements = append(resultElements, fieldResultElement{
-					run:      run,
-					runIdx:   runIdx,
-					childIdx: childIdx,
-				})
+	for _, field := range scanFieldsAcrossRuns(runs) {
+		fieldCode := field.code
+		if ffData := field.begin.FFData; ffData != nil && ffData.TextInput != nil && ffData.TextInput.Default != nil {
+			if _, exists := fieldMap[strings.TrimSpace(ffData.TextInput.Default.Val)]; exists {
+				fieldCode = ffData.TextInput.Default.Val + fieldCode
 			}
 		}
+
+		if replacement, exists := fieldMap[strings.TrimSpace(fieldCode)]; exists {
+			// Replace all result text elements with the replacement
+			if len(field.results) > 0 {
+				setResultTexts(field.results, replacement)
+			}
+			replacements++
+		}
 	}
 
 	return replacements
 }
 
-// fieldResultElement stores a reference to a text element within a field result
-type fieldResultElement struct {
-	run      *ctypes.Run
-	runIdx   int
-	childIdx int
+// paragraphRuns returns the runs of a paragraph, including the runs of its hyperlinks.
+func paragraphRuns(para *ctypes.Paragraph) []*ctypes.Run {
+	var runs []*ctypes.Run
+	for _, child := range para.Children {
+		if child.Run != nil {
+			runs = append(runs, child.Run)
+		} else if child.Link != nil {
+			runs = append(runs, linkRuns(child.Link)...)
+		}
+	}
+	return runs
 }
 
-// replaceFieldResultAcrossRuns replaces field result text elements across multiple runs
-func (rd *RootDoc) replaceFieldResultAcrossRuns(elements []fieldResultElement, replacement string) {
-	if len(elements) == 0 {
-		return
+// linkRuns returns the runs of a hyperlink, in order.
+func linkRuns(link *ctypes.Hyperlink) []*ctypes.Run {
+	var runs []*ctypes.Run
+	for _, child := range link.Content() {
+		if child.Run != nil {
+			runs = append(runs, child.Run)
+		} else if child.Link != nil {
+			runs = append(runs, linkRuns(child.Link)...)
+		}
 	}
+	return runs
+}
 
-	// Replace the first text element with the replacement text
-	if elements[0].childIdx < len(elements[0].run.Children) &&
-		elements[0].run.Children[elements[0].childIdx].Text != nil {
-		elements[0].run.Children[elements[0].childIdx].Text.Text = replacement
-	}
+// runField is a complete field found in a sequence of runs.
+type runField struct {
+	*fieldNode
 
-	// Clear the remaining text elements
-	for i := 1; i < len(elements); i++ {
-		elem := elements[i]
-		if elem.childIdx < len(elem.run.Children) &&
-			elem.run.Children[elem.childIdx].Text != nil {
-			elem.run.Children[elem.childIdx].Text.Text = ""
-		}
-	}
+	// code is the instruction of the field, with the fields nested in it replaced by their
+	// results
+	code string
 }
 
-// replaceFieldsInRun replaces fields within a single run by analyzing field patterns
-func (rd *RootDoc) replaceFieldsInRun(run *ctypes.Run, fieldMap map[string]string) int {
-	replacements := 0
+// scanFieldsAcrossRuns returns the complete fields of a sequence of runs that have a result,
+// in order of their begin characters. Fields nested in the instruction of a field are returned
+// after it; fields nested in a result are part of the result.
+func scanFieldsAcrossRuns(runs []*ctypes.Run) []runField {
+	scanner := &fieldScanner{}
+	for _, run := range runs {
+		scanner.addRun(run)
+	}
 
-	// Find field sequences within this run
-	fieldState := "none" // none, begin, instrText, separate, result
-	var currentFieldCode strings.Builder
-	var resultIndices []int // indices of text elements that contain field results
-
-	for i, child := range run.Children {
-		if child.FldChar != nil {
-			switch child.FldChar.FldCharType.Val {
-			case stypes.FldCharTypeBegin:
-				fieldState = "begin"
-				currentFieldCode.Reset()
-				resultIndices = []int{}
-			case stypes.FldCharTypeSeparate:
-				fieldState = "separate"
-				resultIndices = []int{}
-			case stypes.FldCharTypeEnd:
-				if fieldState == "separate" {
-					// We have a complete field, try to replace it
-					fieldCode := strings.TrimSpace(currentFieldCode.String())
-					if replacement, exists := fieldMap[fieldCode]; exists {
-						// Replace all result text elements with the replacement
-						rd.replaceFieldResult(run, resultIndices, replacement)
-						replacements++
-					}
-				}
-				fieldState = "none"
-				currentFieldCode.Reset()
-				resultIndices = []int{}
+	var fields []runField
+	var add func(node *fieldNode)
+	add = func(node *fieldNode) {
+		if node.separate != nil {
+			fields = append(fields, runField{fieldNode: node, code: node.instruction()})
+		}
+		for _, part := range node.parts {
+			if part.field != nil && part.field.complete {
+				add(part.field)
 			}
-		} else if child.InstrText != nil && (fieldState == "begin" || fieldState == "instrText") {
-			fieldState = "instrText"
-			currentFieldCode.WriteString(child.InstrText.Text)
-		} else if child.Text != nil && fieldState == "separate" {
-			resultIndices = append(resultIndices, i)
 		}
 	}
+	for _, node := range scanner.completed() {
+		add(node)
+	}
 
-	return replacements
+	return fields
+}
+
+// replaceFieldsInRun replaces fields within a single run
+func (rd *RootDoc) replaceFieldsInRun(run *ctypes.Run, fieldMap map[string]string) int {
+	return rd.replaceFieldsAcrossRuns([]*ctypes.Run{run}, fieldMap)
 }
 
 // replaceFieldResult replaces the text elements at the given indices with the replacement text
@@ -0,0 +1,486 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// Field is a field inserted in a paragraph, either a complex field delimited by field
+// characters or a simple field (w:fldSimple).
+type Field struct {
+	root   *RootDoc
+	begin  *ctypes.FieldChar   // begin is the field character starting a complex field
+	simple *ctypes.SimpleField // simple is the simple field element
+}
+
+// Dirty marks the result of the field as out of date, so that Word updates it when the
+// document is opened.
+func (f *Field) Dirty(value bool) *Field {
+	if f.simple != nil {
+		f.simple.Dirty = onOffAttr(value)
+	} else {
+		f.begin.Dirty = onOffAttr(value)
+	}
+	return f
+}
+
+// Lock locks the field so that its result is not recalculated.
+func (f *Field) Lock(value bool) *Field {
+	if f.simple != nil {
+		f.simple.Lock = onOffAttr(value)
+	} else {
+		f.begin.Lock = onOffAttr(value)
+	}
+	return f
+}
+
+// onOffAttr returns an on/off attribute value that is set only when value is true.
+func onOffAttr(value bool) *ctypes.OnOff {
+	if !value {
+		return nil
+	}
+	return ctypes.OnOffFromBool(true)
+}
+
+// GetDirty reports whether the result of the field is marked as out of date.
+func (f *Field) GetDirty() bool {
+	if f.simple != nil {
+		return f.simple.Dirty.Bool()
+	}
+	return f.begin.Dirty.Bool()
+}
+
+// AddField appends a complex field to the run: the begin character, the instruction, the
+// separate character, the cached result and the end character.
+//
+// Parameters:
+//   - instr: The field instruction, such as `PAGE` or `DATE \@ "d MMMM yyyy"`.
+//   - cachedResult: The result shown until the field is updated.
+func (r *Run) AddField(instr, cachedResult string) *Field {
+	begin := newFieldChar(stypes.FldCharTypeBegin)
+
+	r.ct.Children = append(r.ct.Children,
+		ctypes.RunChild{FldChar: begin},
+		ctypes.RunChild{InstrText: ctypes.TextFromString(" " + strings.TrimSpace(instr) + " ")},
+		ctypes.RunChild{FldChar: newFieldChar(stypes.FldCharTypeSeparate)},
+	)
+	if cachedResult != "" {
+		r.ct.Children = append(r.ct.Children, ctypes.RunChild{Text: ctypes.TextFromString(cachedResult)})
+	}
+	r.ct.Children = append(r.ct.Children, ctypes.RunChild{FldChar: newFieldChar(stypes.FldCharTypeEnd)})
+
+	return &Field{root: r.root, begin: begin}
+}
+
+// newFieldChar returns a field character of the given type.
+func newFieldChar(fldCharType stypes.FldCharType) *ctypes.FieldChar {
+	return &ctypes.FieldChar{FldCharType: ctypes.NewGenSingleStrVal(fldCharType)}
+}
+
+// AddField appends a run holding a complex field to the paragraph, see Run.AddField.
+func (p *Paragraph) AddField(instr, cachedResult string) *Field {
+	return p.AddRun().AddField(instr, cachedResult)
+}
+
+// AddSimpleField appends a simple field (w:fldSimple) to the paragraph. The instruction is
+// held in an attribute and the cached result in a run of the field.
+func (p *Paragraph) AddSimpleField(instr, cachedResult string) *Field {
+	simple := &ctypes.SimpleField{Instr: " " + strings.TrimSpace(instr) + " "}
+	if cachedResult != "" {
+		simple.Children = append(simple.Children, ctypes.ParagraphChild{
+			Run: &ctypes.Run{Children: []ctypes.RunChild{{Text: ctypes.TextFromString(cachedResult)}}},
+		})
+	}
+
+	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{SimpleField: simple})
+
+	return &Field{root: p.root, simple: simple}
+}
+
+// AddPageField appends a PAGE field showing the current page number, such as the "X" of a
+// "Page X of Y" footer.
+func (p *Paragraph) AddPageField() *Field {
+	return p.AddField("PAGE", "1")
+}
+
+// AddNumPagesField appends a NUMPAGES field showing the number of pages of the document.
+func (p *Paragraph) AddNumPagesField() *Field {
+	return p.AddField("NUMPAGES", "1")
+}
+
+// AddSectionPagesField appends a SECTIONPAGES field showing the number of pages of the
+// current section.
+func (p *Paragraph) AddSectionPagesField() *Field {
+	return p.AddField("SECTIONPAGES", "1")
+}
+
+// AddDateField appends a DATE field showing the current date with a date picture such as
+// "d MMMM yyyy", see the \@ switch. The cached result is today's date. An empty picture
+// uses the default date format of Word.
+func (p *Paragraph) AddDateField(picture string) *Field {
+	return p.addDateTimeField("DATE", picture, defaultDatePicture)
+}
+
+// AddTimeField appends a TIME field showing the current time with a time picture such as
+// "HH:mm", see the \@ switch. The cached result is the current time. An empty picture uses
+// the default time format of Word.
+func (p *Paragraph) AddTimeField(picture string) *Field {
+	return p.addDateTimeField("TIME", picture, defaultTimePicture)
+}
+
+const (
+	defaultDatePicture = "M/d/yyyy"
+	defaultTimePicture = "h:mm AM/PM"
+)
+
+// addDateTimeField appends a DATE or TIME field cached with the current time.
+func (p *Paragraph) addDateTimeField(name, picture, defaultPicture string) *Field {
+	instr := name
+	if picture != "" {
+		instr += ` \@ "` + picture + `"`
+	} else {
+		picture = defaultPicture
+	}
+	return p.AddField(instr, FormatDate(time.Now(), picture))
+}
+
+// AddRefField appends a REF field showing the text of a bookmark. With hyperlink, the
+// result links to the bookmark. The field is marked dirty since its result is only known
+// once the field is updated.
+func (p *Paragraph) AddRefField(bookmark string, hyperlink bool) *Field {
+	instr := "REF " + bookmark
+	if hyperlink {
+		instr += ` \h`
+	}
+	return p.AddField(instr, "").Dirty(true)
+}
+
+// AddSeqField appends a SEQ field numbering items of a sequence such as "Figure" or
+// "Table". The cached result numbers the field after the SEQ fields of the same sequence
+// already in the document.
+func (p *Paragraph) AddSeqField(label string) *Field {
+	number := 1
+	if p.root != nil {
+		number += p.root.countSeqFields(label)
+	}
+	return p.AddField("SEQ "+quoteFieldArg(label)+` \* ARABIC`, strconv.Itoa(number))
+}
+
+// AddDocPropertyField appends a DOCPROPERTY field showing a document property such as
+// "Title" or "Author". The cached result is the value of the property in the core
+// properties of the document, when known.
+func (p *Paragraph) AddDocPropertyField(name string) *Field {
+	value := ""
+	if p.root != nil {
+		value, _ = p.root.docProperty(name)
+	}
+	return p.AddField("DOCPROPERTY "+quoteFieldArg(name), value)
+}
+
+// AddStyleRefField appends a STYLEREF field showing the text of the nearest paragraph with
+// the given style, such as the current chapter title in a header. The field is marked dirty
+// since its result depends on the page it is shown on.
+func (p *Paragraph) AddStyleRefField(style string) *Field {
+	return p.AddField("STYLEREF "+quoteFieldArg(style), "").Dirty(true)
+}
+
+// quoteFieldArg quotes a field argument containing spaces.
+func quoteFieldArg(arg string) string {
+	if strings.ContainsAny(arg, " \t") {
+		return `"` + arg + `"`
+	}
+	return arg
+}
+
+// splitFieldInstruction splits a field instruction into its arguments and switches. Quoted
+// arguments are unquoted.
+func splitFieldInstruction(instr string) []string {
+	var (
+		args    []string
+		current strings.Builder
+		quoted  bool
+		inArg   bool
+	)
+
+	for _, r := range instr {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inArg = true
+		case !quoted && (r == ' ' || r == '\t'):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args
+}
+
+// countSeqFields returns the number of SEQ fields of a sequence in the document body.
+func (rd *RootDoc) countSeqFields(label string) int {
+	count := 0
+	for _, para := range rd.bodyParagraphs() {
+		count += seqFieldCount(para, label)
+	}
+	return count
+}
+
+// seqFieldCount returns the number of SEQ fields of a sequence in a paragraph.
+func seqFieldCount(para *ctypes.Paragraph, label string) int {
+	count := 0
+	for _, instr := range paragraphFieldInstructions(para) {
+		args := splitFieldInstruction(instr)
+		if len(args) > 1 && strings.EqualFold(args[0], "SEQ") && strings.EqualFold(args[1], label) {
+			count++
+		}
+	}
+	return count
+}
+
+// paragraphFieldInstructions returns the instructions of the complex and simple fields of a
+// paragraph, in order.
+func paragraphFieldInstructions(para *ctypes.Paragraph) []string {
+	var instrs []string
+	for _, field := range scanFieldsAcrossRuns(paragraphRuns(para)) {
+		instrs = append(instrs, field.code)
+	}
+	for _, child := range para.Children {
+		if child.SimpleField != nil {
+			instrs = append(instrs, child.SimpleField.Instr)
+		}
+	}
+	return instrs
+}
+
+// bodyParagraphs returns the paragraphs of the document body, including the paragraphs of
+// tables and nested tables, in document order.
+func (rd *RootDoc) bodyParagraphs() []*ctypes.Paragraph {
+	if rd.Document == nil || rd.Document.Body == nil {
+		return nil
+	}
+
+	var paras []*ctypes.Paragraph
+	for _, child := range rd.Document.Body.Children {
+		if child.Para != nil {
+			paras = append(paras, &child.Para.ct)
+		}
+		if child.Table != nil {
+			paras = appendTableParagraphs(paras, &child.Table.ct)
+		}
+		if child.Sdt != nil {
+			paras = appendSdtParagraphs(paras, child.Sdt)
+		}
+	}
+	return paras
+}
+
+// appendSdtParagraphs appends the paragraphs of a block-level content control, including those
+// of its tables, to paras.
+func appendSdtParagraphs(paras []*ctypes.Paragraph, sdt *ctypes.StructuredDocumentTag) []*ctypes.Paragraph {
+	if sdt.Content == nil {
+		return paras
+	}
+	for _, content := range sdt.Content.Children {
+		if content.Paragraph != nil {
+			paras = append(paras, content.Paragraph)
+		}
+		if content.Table != nil {
+			paras = appendTableParagraphs(paras, content.Table)
+		}
+	}
+	return paras
+}
+
+// appendTableParagraphs appends the paragraphs of the cells of a table to paras.
+func appendTableParagraphs(paras []*ctypes.Paragraph, tbl *ctypes.Table) []*ctypes.Paragraph {
+	for _, rowContent := range tbl.RowContents {
+		if rowContent.Row == nil {
+			continue
+		}
+		for _, cellContent := range rowContent.Row.Contents {
+			if cellContent.Cell == nil {
+				continue
+			}
+			for _, block := range cellContent.Cell.Contents {
+				if block.Paragraph != nil {
+					paras = append(paras, block.Paragraph)
+				}
+				if block.Table != nil {
+					paras = appendTableParagraphs(paras, block.Table)
+				}
+			}
+		}
+	}
+	return paras
+}
+
+// CoreProperties returns the core properties of the document, such as its title and author.
+func (rd *RootDoc) CoreProperties() (*CoreProperties, error) {
+	for _, rel := range rd.RootRels.Relationships {
+		if rel.Type != constants.CORE_PROP_TYPE {
+			continue
+		}
+		content, ok := rd.FileMap.Load(strings.TrimPrefix(rel.Target, "/"))
+		if !ok {
+			break
+		}
+		return LoadDocProps(content.([]byte))
+	}
+
+	return nil, errors.New("document has no core properties part")
+}
+
+// docProperty returns the value of a built-in document property, by the name DOCPROPERTY
+// fields use.
+func (rd *RootDoc) docProperty(name string) (string, bool) {
+	props, err := rd.CoreProperties()
+	if err != nil {
+		return "", false
+	}
+	return corePropertyValue(props, name)
+}
+
+// corePropertyValue returns the value of a core property by the name DOCPROPERTY fields use.
+func corePropertyValue(props *CoreProperties, name string) (string, bool) {
+	switch strings.ToLower(name) {
+	case "title":
+		return props.Title, true
+	case "subject":
+		return props.Subject, true
+	case "author":
+		return props.Creator, true
+	case "keywords":
+		return props.Keywords, true
+	case "comments":
+		return props.Description, true
+	case "category":
+		return props.Category, true
+	case "lastsavedby":
+		return props.LastModifiedBy, true
+	case "revisionnumber":
+		return props.Revision, true
+	case "createtime":
+		return props.Created, true
+	case "lastsavedtime":
+		return props.Modified, true
+	}
+	return "", false
+}
+
+// FormatDate formats a time with a date-time picture as used by the \@ field switch, such as
+// "dddd, MMMM d, yyyy" or "HH:mm".
+//
+// The picture supports d, dd, ddd and dddd for the day, M, MM, MMM and MMMM for the month, yy
+// and yyyy for the year, h and hh for the 12-hour clock, H and HH for the 24-hour clock, m
+// and mm for minutes, s and ss for seconds, and AM/PM or am/pm. Text in single quotes and
+// any other character are copied as is.
+func FormatDate(t time.Time, picture string) string {
+	var sb strings.Builder
+
+	runes := []rune(picture)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		if r == '\'' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			sb.WriteString(string(runes[i+1 : end]))
+			i = end + 1
+			continue
+		}
+
+		if rest := string(runes[i:]); strings.HasPrefix(rest, "AM/PM") || strings.HasPrefix(rest, "am/pm") {
+			ampm := "AM"
+			if t.Hour() >= 12 {
+				ampm = "PM"
+			}
+			if r == 'a' {
+				ampm = strings.ToLower(ampm)
+			}
+			sb.WriteString(ampm)
+			i += len("AM/PM")
+			continue
+		}
+
+		n := 1
+		for i+n < len(runes) && runes[i+n] == r {
+			n++
+		}
+
+		switch r {
+		case 'd':
+			switch n {
+			case 1:
+				sb.WriteString(strconv.Itoa(t.Day()))
+			case 2:
+				fmt.Fprintf(&sb, "%02d", t.Day())
+			case 3:
+				sb.WriteString(t.Weekday().String()[:3])
+			default:
+				sb.WriteString(t.Weekday().String())
+			}
+		case 'M':
+			switch n {
+			case 1:
+				sb.WriteString(strconv.Itoa(int(t.Month())))
+			case 2:
+				fmt.Fprintf(&sb, "%02d", int(t.Month()))
+			case 3:
+				sb.WriteString(t.Month().String()[:3])
+			default:
+				sb.WriteString(t.Month().String())
+			}
+		case 'y':
+			if n <= 2 {
+				fmt.Fprintf(&sb, "%02d", t.Year()%100)
+			} else {
+				fmt.Fprintf(&sb, "%04d", t.Year())
+			}
+		case 'h':
+			hour := t.Hour() % 12
+			if hour == 0 {
+				hour = 12
+			}
+			writeDatePart(&sb, hour, n)
+		case 'H':
+			writeDatePart(&sb, t.Hour(), n)
+		case 'm':
+			writeDatePart(&sb, t.Minute(), n)
+		case 's':
+			writeDatePart(&sb, t.Second(), n)
+		default:
+			sb.WriteString(string(runes[i : i+n]))
+		}
+		i += n
+	}
+
+	return sb.String()
+}
+
+// writeDatePart writes a time component, padded to two digits when its placeholder is
+// repeated.
+func writeDatePart(sb *strings.Builder, value, n int) {
+	if n > 1 {
+		fmt.Fprintf(sb, "%02d", value)
+		return
+	}
+	sb.WriteString(strconv.Itoa(value))
+}
@@ -0,0 +1,165 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParagraph_AddField(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddParagraph("Page ")
+	p.AddPageField()
+	p.AddText(" of ")
+	p.AddNumPagesField()
+
+	output, err := xml.Marshal(p.ct)
+	require.NoError(t, err)
+	assert.Contains(t, string(output),
+		`<w:r><w:fldChar w:fldCharType="begin"></w:fldChar><w:instrText xml:space="preserve"> PAGE </w:instrText>`+
+			`<w:fldChar w:fldCharType="separate"></w:fldChar><w:t>1</w:t><w:fldChar w:fldCharType="end"></w:fldChar></w:r>`)
+	assert.Contains(t, string(output), `<w:instrText xml:space="preserve"> NUMPAGES </w:instrText>`)
+	assert.Equal(t, "Page 1 of 1", paragraphPlainText(&p.ct))
+
+	fields := scanFieldsAcrossRuns(paragraphRuns(&p.ct))
+	require.Len(t, fields, 2)
+	assert.Equal(t, " PAGE ", fields[0].code)
+	assert.Equal(t, " NUMPAGES ", fields[1].code)
+}
+
+func TestParagraph_AddSimpleField(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddEmptyParagraph()
+	field := p.AddSimpleField("SECTIONPAGES", "4").Dirty(true)
+
+	assert.True(t, field.GetDirty())
+	output, err := xml.Marshal(p.ct)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), `<w:fldSimple w:instr=" SECTIONPAGES " w:dirty="true"><w:r><w:t>4</w:t></w:r></w:fldSimple>`)
+	assert.Equal(t, "4", paragraphPlainText(&p.ct))
+
+	field.Dirty(false).Lock(true)
+	assert.False(t, field.GetDirty())
+	assert.Nil(t, p.ct.Children[0].SimpleField.Dirty)
+	assert.True(t, p.ct.Children[0].SimpleField.Lock.Bool())
+}
+
+func TestParagraph_TypedFields(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	tests := []struct {
+		name   string
+		add    func(p *Paragraph) *Field
+		instr  string
+		result string
+		dirty  bool
+	}{
+		{"Section pages", (*Paragraph).AddSectionPagesField, " SECTIONPAGES ", "1", false},
+		{"Reference", func(p *Paragraph) *Field { return p.AddRefField("Intro", true) }, ` REF Intro \h `, "", true},
+		{"Style reference", func(p *Paragraph) *Field { return p.AddStyleRefField("Heading 1") }, ` STYLEREF "Heading 1" `, "", true},
+		{"Document property", func(p *Paragraph) *Field { return p.AddDocPropertyField("Title") }, ` DOCPROPERTY Title `, "", false},
+		{"Time", func(p *Paragraph) *Field { return p.AddTimeField("HH:mm") }, ` TIME \@ "HH:mm" `, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := rd.AddEmptyParagraph()
+			field := tt.add(p)
+			assert.Equal(t, tt.dirty, field.GetDirty())
+
+			fields := scanFieldsAcrossRuns(paragraphRuns(&p.ct))
+			require.Len(t, fields, 1)
+			assert.Equal(t, tt.instr, fields[0].code)
+			if tt.result != "" {
+				assert.Equal(t, tt.result, paragraphPlainText(&p.ct))
+			}
+		})
+	}
+}
+
+func TestParagraph_AddDateField(t *testing.T) {
+	rd := setupRootDoc(t)
+	before := time.Now()
+	p := rd.AddEmptyParagraph()
+	p.AddDateField("yyyy-MM-dd")
+
+	fields := scanFieldsAcrossRuns(paragraphRuns(&p.ct))
+	require.Len(t, fields, 1)
+	assert.Equal(t, ` DATE \@ "yyyy-MM-dd" `, fields[0].code)
+
+	text := paragraphPlainText(&p.ct)
+	assert.Contains(t, []string{before.Format("2006-01-02"), time.Now().Format("2006-01-02")}, text)
+}
+
+func TestParagraph_AddSeqField(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	rd.AddParagraph("Figure ").AddSeqField("Figure")
+	rd.AddParagraph("Table ").AddSeqField("Table")
+	tbl := rd.AddTable()
+	tbl.AddRow().AddCell().AddParagraph("Figure ").AddSeqField("Figure")
+	p := rd.AddParagraph("Figure ")
+	p.AddSeqField("Figure")
+
+	assert.Equal(t, "Figure 3", paragraphPlainText(&p.ct))
+	fields := scanFieldsAcrossRuns(paragraphRuns(&p.ct))
+	require.Len(t, fields, 1)
+	assert.Equal(t, ` SEQ Figure \* ARABIC `, fields[0].code)
+}
+
+func TestParagraph_AddDocPropertyField(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.RootRels.Relationships = append(rd.RootRels.Relationships, &Relationship{
+		ID:     "rId2",
+		Type:   constants.CORE_PROP_TYPE,
+		Target: "docProps/core.xml",
+	})
+	rd.FileMap.Store("docProps/core.xml", []byte(`<?xml version="1.0" encoding="UTF-8"?>`+
+		`<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" `+
+		`xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>Annual Report</dc:title>`+
+		`<dc:creator>Jane Doe</dc:creator></cp:coreProperties>`))
+
+	p := rd.AddEmptyParagraph()
+	p.AddDocPropertyField("Title")
+	p.AddText(" by ")
+	p.AddDocPropertyField("Author")
+
+	assert.Equal(t, "Annual Report by Jane Doe", paragraphPlainText(&p.ct))
+}
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+
+	tests := []struct {
+		picture  string
+		expected string
+	}{
+		{"M/d/yyyy", "3/5/2024"},
+		{"dd.MM.yy", "05.03.24"},
+		{"dddd, MMMM d, yyyy", "Tuesday, March 5, 2024"},
+		{"ddd d MMM", "Tue 5 Mar"},
+		{"h:mm AM/PM", "2:07 PM"},
+		{"hh:mm am/pm", "02:07 pm"},
+		{"HH:mm:ss", "14:07:09"},
+		{"'Week of' d MMMM", "Week of 5 March"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.picture, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatDate(date, tt.picture))
+		})
+	}
+}
+
+func TestSplitFieldInstruction(t *testing.T) {
+	assert.Equal(t, []string{"DATE", `\@`, "d MMMM yyyy"}, splitFieldInstruction(` DATE \@ "d MMMM yyyy" `))
+	assert.Equal(t, []string{"SEQ", "Figure", `\*`, "ARABIC"}, splitFieldInstruction("SEQ Figure \\* ARABIC"))
+	assert.Equal(t, []string{"STYLEREF", "Heading 1"}, splitFieldInstruction(`STYLEREF "Heading 1"`))
+
+	assert.Equal(t, stypes.FldCharTypeBegin, newFieldChar(stypes.FldCharTypeBegin).FldCharType.Val)
+}
@@ -0,0 +1,1037 @@
+package docx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// FieldContext provides the values fields take from outside the document.
+type FieldContext struct {
+	// Now is the time shown by DATE and TIME fields, the current time when zero
+	Now time.Time
+
+	// Variables are the values of DOCVARIABLE fields. Variables missing from the map are
+	// taken from the document variables of the settings part.
+	Variables map[string]string
+
+	// Properties are the values of DOCPROPERTY fields and of fields such as AUTHOR and
+	// TITLE. They take precedence over the core properties of the document.
+	Properties map[string]string
+}
+
+var (
+	errRefNotFound      = errors.New("reference source not found")
+	errBookmarkNotFound = errors.New("bookmark not defined")
+	errUnknownProperty  = errors.New("unknown document property name")
+	errNoVariable       = errors.New("no document variable supplied")
+	errFieldSyntax      = errors.New("syntax error")
+	errNoStyleText      = errors.New("no text of specified style in document")
+)
+
+// fieldErrorText is the result Word shows for a field that cannot be evaluated.
+var fieldErrorText = map[error]string{
+	errRefNotFound:      "Error! Reference source not found.",
+	errBookmarkNotFound: "Error! Bookmark not defined.",
+	errUnknownProperty:  "Error! Unknown document property name.",
+	errNoVariable:       "Error! No document variable supplied.",
+	errFieldSyntax:      "!Syntax Error",
+	errNoStyleText:      "Error! No text of specified style in document.",
+}
+
+// UpdateFields recomputes the results of the fields of the document body that can be
+// evaluated without laying out pages, so that the document shows current values without
+// being updated in Word.
+//
+// Supported fields are DATE, TIME, CREATEDATE, SAVEDATE, DOCPROPERTY, AUTHOR, TITLE,
+// SUBJECT, KEYWORDS, COMMENTS, LASTSAVEDBY, DOCVARIABLE, REF and bookmark references,
+// PAGEREF, SEQ, STYLEREF and IF. Fields nested in the instruction of another field are evaluated first
+// and their results take part in the instruction. The \* format switches Upper, Lower,
+// FirstCap, Caps, Arabic, Roman, Alphabetic, Ordinal and Hex, the \# numeric picture and the
+// \@ date picture are applied to the results. Other fields keep their results, and locked
+// fields are left unchanged. Formula fields are updated by UpdateFormulas.
+//
+// PAGEREF results count the explicit page and section breaks before the bookmark, which
+// matches the page number only in documents paginated by explicit breaks.
+//
+// Fields that cannot be evaluated get the error text Word shows, such as "Error! Reference
+// source not found.", and the first error is returned after every field has been updated.
+// It returns the number of fields updated.
+func (rd *RootDoc) UpdateFields(ctx FieldContext) (int, error) {
+	if rd.Document == nil || rd.Document.Body == nil {
+		return 0, nil
+	}
+
+	if ctx.Now.IsZero() {
+		ctx.Now = time.Now()
+	}
+	e := &fieldEngine{rd: rd, ctx: ctx}
+	if props, err := rd.CoreProperties(); err == nil {
+		e.props = props
+	}
+	e.variables = rd.documentVariables()
+
+	// The second pass sees the bookmark text updated by the first, so that references show
+	// the updated results of the fields they refer to
+	e.run()
+	e.run()
+
+	return e.updated, e.firstErr
+}
+
+// fieldEngine evaluates the fields of a document in document order.
+type fieldEngine struct {
+	rd        *RootDoc
+	ctx       FieldContext
+	props     *CoreProperties
+	variables map[string]string
+
+	bookmarks map[string]bookmarkInfo
+
+	// seq holds the current number of each sequence and seqChapter the heading count the
+	// number was given under, for sequences restarted at headings
+	seq        map[string]int
+	seqChapter map[string]int
+
+	// headings counts the headings at each outline level or above
+	headings [9]int
+
+	// outline holds the number of the current heading at each outline level, counted from
+	// the previous heading of a higher level, and outlineText its text
+	outline     [9]int
+	outlineText [9]string
+
+	// styleText holds the text of the last paragraph of each paragraph style
+	styleText map[string]string
+
+	// only limits the fields evaluated to the given field names when it is set
+	only map[string]bool
+
+	updated  int
+	firstErr error
+}
+
+// bookmarkInfo is the content of a bookmark and the page it starts on.
+type bookmarkInfo struct {
+	text string
+	page int
+
+	// number is the heading number of the paragraph the bookmark starts in, counted from the
+	// headings of the document as in "3.2", or empty when it is not a heading.
+	number string
+}
+
+// run evaluates every field of the document body once.
+func (e *fieldEngine) run() {
+	e.bookmarks = e.rd.collectBookmarks()
+	e.seq = make(map[string]int)
+	e.seqChapter = make(map[string]int)
+	e.headings = [9]int{}
+	e.outline = [9]int{}
+	e.outlineText = [9]string{}
+	e.styleText = make(map[string]string)
+	e.updated = 0
+	e.firstErr = nil
+
+	e.rd.walkBodyParagraphs(func(para *ctypes.Paragraph, _ int) {
+		text := paragraphPlainText(para)
+		if level := e.rd.OutlineLevel(para); level >= 0 {
+			for l := level; l < len(e.headings); l++ {
+				e.headings[l]++
+			}
+			e.outline[level]++
+			e.outlineText[level] = text
+			for l := level + 1; l < len(e.outline); l++ {
+				e.outline[l] = 0
+				e.outlineText[l] = ""
+			}
+		}
+		if styleID := e.rd.paragraphStyleID(para); styleID != "" {
+			e.styleText[styleID] = text
+		}
+		e.updateParagraph(para)
+	})
+}
+
+// updateParagraph evaluates the fields of a paragraph in order.
+func (e *fieldEngine) updateParagraph(para *ctypes.Paragraph) {
+	scanner := &fieldScanner{}
+
+	flush := func() {
+		for _, node := range scanner.completed() {
+			e.evaluate(node)
+		}
+	}
+
+	for _, child := range para.Children {
+		switch {
+		case child.Run != nil:
+			scanner.addRun(child.Run)
+		case child.Link != nil:
+			for _, run := range linkRuns(child.Link) {
+				scanner.addRun(run)
+			}
+		case child.Sdt != nil && child.Sdt.Content != nil:
+			for _, content := range child.Sdt.Content.Children {
+				if content.Run != nil {
+					scanner.addRun(content.Run)
+				}
+			}
+		}
+		flush()
+
+		if child.SimpleField != nil {
+			e.evaluateSimple(child.SimpleField)
+		}
+	}
+}
+
+// evaluate evaluates a complex field and its nested fields, and sets its result. It returns
+// the result of the field, which is its previous result when the field is not evaluated.
+func (e *fieldEngine) evaluate(node *fieldNode) string {
+	var code strings.Builder
+	for _, part := range node.parts {
+		if part.field != nil {
+			code.WriteString(e.evaluate(part.field))
+		} else {
+			code.WriteString(part.text)
+		}
+	}
+
+	if node.separate == nil || node.begin.Lock.Bool() {
+		return node.resultText()
+	}
+
+	result, ok := e.result(code.String())
+	if !ok {
+		return node.resultText()
+	}
+
+	node.setResult(result)
+	e.updated++
+	return result
+}
+
+// evaluateSimple evaluates a simple field and sets its result.
+func (e *fieldEngine) evaluateSimple(field *ctypes.SimpleField) {
+	if field.Lock.Bool() {
+		return
+	}
+
+	result, ok := e.result(field.Instr)
+	if !ok {
+		return
+	}
+
+	var texts []*ctypes.Text
+	for _, child := range field.Children {
+		if child.Run == nil {
+			continue
+		}
+		for _, rc := range child.Run.Children {
+			if rc.Text != nil {
+				texts = append(texts, rc.Text)
+			}
+		}
+	}
+
+	if len(texts) == 0 {
+		field.Children = append(field.Children, ctypes.ParagraphChild{
+			Run: &ctypes.Run{Children: []ctypes.RunChild{{Text: ctypes.TextFromString(result)}}},
+		})
+	} else {
+		setResultTexts(texts, result)
+	}
+	field.Dirty = nil
+	e.updated++
+}
+
+// result computes the result of a field instruction. It reports false for fields it does
+// not evaluate.
+func (e *fieldEngine) result(code string) (string, bool) {
+	args := splitFieldInstruction(code)
+	if len(args) == 0 {
+		return "", false
+	}
+
+	name := strings.ToUpper(args[0])
+	sw := parseFieldSwitches(args[1:], name)
+
+	result, err := e.fieldValue(name, args[0], sw)
+	if errors.Is(err, errFieldNotEvaluated) {
+		return "", false
+	}
+	if err != nil {
+		if e.firstErr == nil {
+			e.firstErr = fmt.Errorf("field %q: %w", strings.TrimSpace(code), err)
+		}
+		return fieldErrorText[err], true
+	}
+
+	return sw.format(result), true
+}
+
+// errFieldNotEvaluated reports a field that is left unchanged.
+var errFieldNotEvaluated = errors.New("field not evaluated")
+
+// fieldValue returns the unformatted result of a field.
+func (e *fieldEngine) fieldValue(name, rawName string, sw fieldSwitches) (string, error) {
+	param := func(i int) string {
+		if i < len(sw.params) {
+			return sw.params[i]
+		}
+		return ""
+	}
+
+	if e.only != nil && !e.only[name] {
+		return "", errFieldNotEvaluated
+	}
+
+	switch name {
+	case "DATE":
+		return FormatDate(e.ctx.Now, sw.datePicture(defaultDatePicture)), nil
+	case "TIME":
+		return FormatDate(e.ctx.Now, sw.datePicture(defaultTimePicture)), nil
+	case "CREATEDATE", "SAVEDATE":
+		if e.props == nil {
+			return "", errFieldNotEvaluated
+		}
+		value := e.props.Created
+		if name == "SAVEDATE" {
+			value = e.props.Modified
+		}
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+		if err != nil {
+			return "", errFieldNotEvaluated
+		}
+		return FormatDate(t.In(e.ctx.Now.Location()), sw.datePicture(defaultDateTimePicture)), nil
+	case "DOCPROPERTY":
+		if value, ok := e.property(param(0)); ok {
+			return value, nil
+		}
+		return "", errUnknownProperty
+	case "AUTHOR", "TITLE", "SUBJECT", "KEYWORDS", "COMMENTS", "LASTSAVEDBY":
+		value, _ := e.property(name)
+		return value, nil
+	case "DOCVARIABLE":
+		if value, ok := e.ctx.Variables[param(0)]; ok {
+			return value, nil
+		}
+		if value, ok := e.variables[param(0)]; ok {
+			return value, nil
+		}
+		return "", errNoVariable
+	case "REF":
+		bookmark, ok := e.bookmarks[param(0)]
+		if !ok {
+			return "", errRefNotFound
+		}
+		for _, s := range []string{`\n`, `\r`, `\w`} {
+			if _, ok := sw.values[s]; ok {
+				return bookmark.number, nil
+			}
+		}
+		return bookmark.text, nil
+	case "PAGEREF":
+		bookmark, ok := e.bookmarks[param(0)]
+		if !ok {
+			return "", errBookmarkNotFound
+		}
+		return strconv.Itoa(bookmark.page), nil
+	case "SEQ":
+		return e.sequence(param(0), sw), nil
+	case "STYLEREF":
+		return e.styleRef(param(0), sw)
+	case "IF":
+		return evaluateIf(sw.params)
+	}
+
+	// A bookmark name alone is a reference to the bookmark
+	if bookmark, ok := e.bookmarks[rawName]; ok {
+		return bookmark.text, nil
+	}
+
+	return "", errFieldNotEvaluated
+}
+
+const defaultDateTimePicture = "M/d/yyyy h:mm:ss AM/PM"
+
+// property returns a document property from the context or the core properties.
+func (e *fieldEngine) property(name string) (string, bool) {
+	for key, value := range e.ctx.Properties {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	if e.props == nil {
+		return "", false
+	}
+	return corePropertyValue(e.props, name)
+}
+
+// sequence advances a SEQ sequence and returns its number.
+func (e *fieldEngine) sequence(label string, sw fieldSwitches) string {
+	if level, err := strconv.Atoi(sw.values[`\s`]); err == nil && level >= 1 && level <= len(e.headings) {
+		if chapter := e.headings[level-1]; e.seqChapter[label] != chapter {
+			e.seqChapter[label] = chapter
+			e.seq[label] = 0
+		}
+	}
+
+	_, current := sw.values[`\c`]
+	reset, err := strconv.Atoi(sw.values[`\r`])
+	switch {
+	case err == nil:
+		e.seq[label] = reset
+	case !current:
+		e.seq[label]++
+	}
+
+	if _, hidden := sw.values[`\h`]; hidden {
+		return ""
+	}
+	return strconv.Itoa(e.seq[label])
+}
+
+// styleRef returns the result of a STYLEREF field: the text of the last paragraph with the
+// style, given by name, ID or heading level, before the field. The number switches give the
+// number of the heading instead, counted from the headings of the document, as in "2.3".
+func (e *fieldEngine) styleRef(style string, sw fieldSwitches) (string, error) {
+	level := -1
+	if n, err := strconv.Atoi(style); err == nil && n >= 1 && n <= len(e.outline) {
+		level = n - 1
+	} else {
+		styleID := style
+		if s := e.rd.getStyleByName(style, stypes.StyleTypeParagraph); s != nil && s.ID != nil {
+			styleID = *s.ID
+		}
+		level = e.rd.OutlineLevel(&ctypes.Paragraph{Property: &ctypes.ParagraphProp{Style: ctypes.NewParagraphStyle(styleID)}})
+		if level < 0 {
+			if text, ok := e.styleText[styleID]; ok {
+				return text, nil
+			}
+			return "", errNoStyleText
+		}
+	}
+
+	if e.outline[level] == 0 {
+		return "", errNoStyleText
+	}
+
+	for _, s := range []string{`\s`, `\n`, `\r`, `\w`} {
+		if _, ok := sw.values[s]; ok {
+			numbers := make([]string, 0, level+1)
+			for l := 0; l <= level; l++ {
+				numbers = append(numbers, strconv.Itoa(e.outline[l]))
+			}
+			return strings.Join(numbers, "."), nil
+		}
+	}
+	return e.outlineText[level], nil
+}
+
+// evaluateIf returns the result of an IF field: the first text when the comparison holds and
+// the second otherwise.
+func evaluateIf(params []string) (string, error) {
+	if len(params) < 3 {
+		return "", errFieldSyntax
+	}
+
+	left, op, right := params[0], params[1], params[2]
+	if !isCompareOp(op) {
+		return "", errFieldSyntax
+	}
+
+	var holds bool
+
+	a, aNum := parseFormulaNumber(left)
+	b, bNum := parseFormulaNumber(right)
+	switch {
+	case aNum && bNum:
+		holds = compareOp(op, compareNumbers(a, b))
+	case op == "=" || op == "<>":
+		holds = matchWildcard(right, left) == (op == "=")
+	default:
+		holds = compareOp(op, strings.Compare(left, right))
+	}
+
+	if holds {
+		if len(params) > 3 {
+			return params[3], nil
+		}
+		return "", nil
+	}
+	if len(params) > 4 {
+		return params[4], nil
+	}
+	return "", nil
+}
+
+// isCompareOp reports whether op is a comparison operator of IF fields.
+func isCompareOp(op string) bool {
+	switch op {
+	case "=", "<>", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+// compareOp reports whether a comparison result satisfies an operator.
+func compareOp(op string, cmp int) bool {
+	switch op {
+	case "=":
+		return cmp == 0
+	case "<>":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// compareNumbers returns -1, 0 or 1 as a is less than, equal to or greater than b.
+func compareNumbers(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// matchWildcard reports whether text matches a pattern where ? matches any character and *
+// any sequence of characters.
+func matchWildcard(pattern, text string) bool {
+	p, t := []rune(pattern), []rune(text)
+	star, match := -1, 0
+	i, j := 0, 0
+	for j < len(t) {
+		switch {
+		case i < len(p) && (p[i] == '?' || p[i] == t[j]):
+			i++
+			j++
+		case i < len(p) && p[i] == '*':
+			star, match = i, j
+			i++
+		case star >= 0:
+			i = star + 1
+			match++
+			j = match
+		default:
+			return false
+		}
+	}
+	for i < len(p) && p[i] == '*' {
+		i++
+	}
+	return i == len(p)
+}
+
+// fieldSwitches holds the parameters and switches of a field instruction.
+type fieldSwitches struct {
+	params []string
+
+	// values holds the field-specific switches and their arguments
+	values map[string]string
+
+	// formats holds the \* switches in order, numeric the \# picture and date the \@ picture
+	formats []string
+	numeric string
+	date    string
+}
+
+// parseFieldSwitches separates the parameters of a field from its switches.
+func parseFieldSwitches(args []string, name string) fieldSwitches {
+	sw := fieldSwitches{values: make(map[string]string)}
+
+	takesValue := func(s string) bool {
+		switch s {
+		case `\*`, `\#`, `\@`:
+			return true
+		case `\r`, `\s`:
+			return name == "SEQ"
+		case `\d`:
+			return name == "REF" || name == "NOTEREF"
+		}
+		return false
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if len(arg) < 2 || arg[0] != '\\' {
+			sw.params = append(sw.params, arg)
+			continue
+		}
+
+		s, value := arg[:2], arg[2:]
+		if value == "" && takesValue(s) && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+
+		switch s {
+		case `\*`:
+			sw.formats = append(sw.formats, value)
+		case `\#`:
+			sw.numeric = value
+		case `\@`:
+			sw.date = value
+		default:
+			sw.values[s] = value
+		}
+	}
+
+	return sw
+}
+
+// datePicture returns the \@ picture of a field, or def when the field has none.
+func (sw fieldSwitches) datePicture(def string) string {
+	if sw.date != "" {
+		return sw.date
+	}
+	return def
+}
+
+// format applies the numeric picture and the format switches to a field result.
+func (sw fieldSwitches) format(result string) string {
+	if sw.numeric != "" {
+		if n, ok := parseFormulaNumber(result); ok {
+			result = FormatNumber(n, sw.numeric)
+		}
+	}
+
+	for _, f := range sw.formats {
+		result = formatFieldResult(result, f)
+	}
+	return result
+}
+
+// formatFieldResult applies a \* format switch to a field result.
+func formatFieldResult(result, format string) string {
+	switch strings.ToUpper(format) {
+	case "UPPER":
+		return strings.ToUpper(result)
+	case "LOWER":
+		return strings.ToLower(result)
+	case "FIRSTCAP":
+		return capitalize(result)
+	case "CAPS":
+		// Only the first letter of each word changes, the separators and the rest of the
+		// word are kept as they are
+		var sb strings.Builder
+		start := true
+		for _, r := range result {
+			if start {
+				r = unicode.ToUpper(r)
+			}
+			start = unicode.IsSpace(r)
+			sb.WriteRune(r)
+		}
+		return sb.String()
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(result))
+	if err != nil {
+		return result
+	}
+
+	lower := format == strings.ToLower(format)
+	switch strings.ToUpper(format) {
+	case "ARABIC":
+		return strconv.Itoa(n)
+	case "ROMAN":
+		if lower {
+			return strings.ToLower(romanNumeral(n))
+		}
+		return romanNumeral(n)
+	case "ALPHABETIC":
+		if lower {
+			return strings.ToLower(alphabeticNumeral(n))
+		}
+		return alphabeticNumeral(n)
+	case "ORDINAL":
+		return strconv.Itoa(n) + ordinalSuffix(n)
+	case "HEX":
+		return strings.ToUpper(strconv.FormatInt(int64(n), 16))
+	}
+	return result
+}
+
+// capitalize returns text with its first letter in upper case.
+func capitalize(text string) string {
+	for i, r := range text {
+		return text[:i] + string(unicode.ToUpper(r)) + text[i+len(string(r)):]
+	}
+	return text
+}
+
+// romanNumeral returns a positive number in upper case roman numerals.
+func romanNumeral(n int) string {
+	if n <= 0 {
+		return strconv.Itoa(n)
+	}
+
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	symbols := []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+
+	var sb strings.Builder
+	for i, value := range values {
+		for n >= value {
+			sb.WriteString(symbols[i])
+			n -= value
+		}
+	}
+	return sb.String()
+}
+
+// alphabeticNumeral returns a positive number as letters the way Word numbers them: A to Z,
+// then AA to ZZ and so on.
+func alphabeticNumeral(n int) string {
+	if n <= 0 {
+		return strconv.Itoa(n)
+	}
+	letter := string(rune('A' + (n-1)%26))
+	return strings.Repeat(letter, (n-1)/26+1)
+}
+
+// ordinalSuffix returns the English ordinal suffix of a number.
+func ordinalSuffix(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// fieldNode is a complex field of a paragraph. Fields nested in its instruction are parts
+// of the instruction.
+type fieldNode struct {
+	begin *ctypes.FieldChar
+
+	// parts holds the instruction text and the nested fields, in order
+	parts []fieldPart
+
+	// separate is the field character separating the instruction from the result, and
+	// sepRun the run holding it
+	separate *ctypes.FieldChar
+	sepRun   *ctypes.Run
+
+	// results are the text elements of the result, including those of fields nested in it
+	results []*ctypes.Text
+
+	complete bool
+}
+
+// fieldPart is a piece of a field instruction: either text or a nested field.
+type fieldPart struct {
+	text  string
+	field *fieldNode
+}
+
+// instruction returns the instruction of the field, with the fields nested in it replaced by
+// their current results.
+func (n *fieldNode) instruction() string {
+	var sb strings.Builder
+	for _, part := range n.parts {
+		if part.field != nil {
+			sb.WriteString(part.field.resultText())
+		} else {
+			sb.WriteString(part.text)
+		}
+	}
+	return sb.String()
+}
+
+// resultText returns the current result of the field.
+func (n *fieldNode) resultText() string {
+	var sb strings.Builder
+	for _, text := range n.results {
+		sb.WriteString(text.Text)
+	}
+	return sb.String()
+}
+
+// setResult replaces the result of the field with text. A field with an empty result gets a
+// text element after its separator.
+func (n *fieldNode) setResult(text string) {
+	n.begin.Dirty = nil
+	if len(n.results) > 0 {
+		setResultTexts(n.results, text)
+		return
+	}
+
+	for i, child := range n.sepRun.Children {
+		if child.FldChar != n.separate {
+			continue
+		}
+		added := ctypes.TextFromString(text)
+		children := make([]ctypes.RunChild, 0, len(n.sepRun.Children)+1)
+		children = append(children, n.sepRun.Children[:i+1]...)
+		children = append(children, ctypes.RunChild{Text: added})
+		n.sepRun.Children = append(children, n.sepRun.Children[i+1:]...)
+		n.results = []*ctypes.Text{added}
+		return
+	}
+}
+
+// setResultTexts puts text in the first text element and empties the others.
+func setResultTexts(texts []*ctypes.Text, text string) {
+	*texts[0] = *ctypes.TextFromString(text)
+	for _, t := range texts[1:] {
+		t.Text = ""
+	}
+}
+
+// fieldScanner finds the complex fields of a sequence of runs, including nested fields.
+type fieldScanner struct {
+	stack   []*fieldNode
+	pending []*fieldNode
+}
+
+// addRun scans the content of a run.
+func (s *fieldScanner) addRun(run *ctypes.Run) {
+	for _, child := range run.Children {
+		switch {
+		case child.FldChar != nil && child.FldChar.FldCharType != nil:
+			switch child.FldChar.FldCharType.Val {
+			case stypes.FldCharTypeBegin:
+				node := &fieldNode{begin: child.FldChar}
+				if len(s.stack) == 0 {
+					s.pending = append(s.pending, node)
+				} else if parent := s.stack[len(s.stack)-1]; parent.separate == nil {
+					parent.parts = append(parent.parts, fieldPart{field: node})
+				}
+				// A field nested in the result of another field is replaced with the result
+				// of the outer field and is not evaluated
+				s.stack = append(s.stack, node)
+			case stypes.FldCharTypeSeparate:
+				if len(s.stack) > 0 {
+					node := s.stack[len(s.stack)-1]
+					node.separate, node.sepRun = child.FldChar, run
+				}
+			case stypes.FldCharTypeEnd:
+				if len(s.stack) > 0 {
+					s.stack[len(s.stack)-1].complete = true
+					s.stack = s.stack[:len(s.stack)-1]
+				}
+			}
+		case child.InstrText != nil:
+			if len(s.stack) > 0 {
+				if node := s.stack[len(s.stack)-1]; node.separate == nil {
+					node.parts = append(node.parts, fieldPart{text: child.InstrText.Text})
+				}
+			}
+		case child.Text != nil:
+			for _, node := range s.stack {
+				if node.separate != nil {
+					node.results = append(node.results, child.Text)
+				}
+			}
+		}
+	}
+}
+
+// completed returns the outermost fields completed since the last call, in order.
+func (s *fieldScanner) completed() []*fieldNode {
+	var done []*fieldNode
+	for len(s.pending) > 0 && s.pending[0].complete {
+		done = append(done, s.pending[0])
+		s.pending = s.pending[1:]
+	}
+	return done
+}
+
+// walkBodyParagraphs calls fn for the paragraphs of the document body and its tables in
+// document order, with the number of the page each paragraph starts on counted from the
+// explicit page breaks and the section breaks before it.
+func (rd *RootDoc) walkBodyParagraphs(fn func(para *ctypes.Paragraph, page int)) {
+	if rd.Document == nil || rd.Document.Body == nil {
+		return
+	}
+
+	page := 1
+	visit := func(para *ctypes.Paragraph, first bool) {
+		if !first && para.Property != nil && para.Property.PageBreakBefore.Bool() {
+			page++
+		}
+		fn(para, page)
+		page += paragraphPageBreaks(para)
+	}
+
+	body := rd.Document.Body
+	for i, child := range body.Children {
+		if child.Para != nil {
+			visit(&child.Para.ct, i == 0)
+			if hasSectionBreak(child.Para) {
+				next := rd.sectionAt(i + 1)
+				if next == nil || next.Type == nil || next.Type.Val != stypes.SectionMarkNextContinuous {
+					page++
+				}
+			}
+		}
+		if child.Table != nil {
+			for _, para := range appendTableParagraphs(nil, &child.Table.ct) {
+				visit(para, false)
+			}
+		}
+		if child.Sdt != nil {
+			for j, para := range appendSdtParagraphs(nil, child.Sdt) {
+				visit(para, i == 0 && j == 0)
+			}
+		}
+	}
+}
+
+// paragraphPageBreaks returns the number of page breaks in the runs of a paragraph.
+func paragraphPageBreaks(para *ctypes.Paragraph) int {
+	breaks := 0
+	for _, run := range paragraphRuns(para) {
+		breaks += runPageBreaks(run)
+	}
+	return breaks
+}
+
+// runPageBreaks returns the number of page breaks in a run.
+func runPageBreaks(run *ctypes.Run) int {
+	breaks := 0
+	for _, child := range run.Children {
+		if child.Break != nil && child.Break.BreakType != nil && *child.Break.BreakType == stypes.BreakTypePage {
+			breaks++
+		}
+	}
+	return breaks
+}
+
+// collectBookmarks returns the text, page and heading number of the bookmarks of the
+// document body. The paragraphs of a bookmark spanning paragraphs are joined with spaces.
+func (rd *RootDoc) collectBookmarks() map[string]bookmarkInfo {
+	bookmarks := make(map[string]bookmarkInfo)
+	open := make(map[int]string)
+	texts := make(map[string]*strings.Builder)
+	var outline [9]int
+
+	rd.walkBodyParagraphs(func(para *ctypes.Paragraph, page int) {
+		for _, sb := range texts {
+			if sb.Len() > 0 {
+				sb.WriteString(" ")
+			}
+		}
+
+		number := ""
+		if level := rd.OutlineLevel(para); level >= 0 && level < len(outline) {
+			outline[level]++
+			numbers := make([]string, 0, level+1)
+			for l := range outline {
+				if l > level {
+					outline[l] = 0
+					continue
+				}
+				numbers = append(numbers, strconv.Itoa(outline[l]))
+			}
+			number = strings.Join(numbers, ".")
+		}
+
+		for _, child := range para.Children {
+			switch {
+			case child.BookmarkStart != nil:
+				start := child.BookmarkStart
+				if start.Name == nil || start.ID == nil {
+					continue
+				}
+				if _, exists := bookmarks[start.Name.Val]; exists {
+					continue
+				}
+				open[start.ID.Val] = start.Name.Val
+				texts[start.Name.Val] = &strings.Builder{}
+				bookmarks[start.Name.Val] = bookmarkInfo{page: page, number: number}
+			case child.BookmarkEnd != nil:
+				if child.BookmarkEnd.ID == nil {
+					continue
+				}
+				if name, ok := open[child.BookmarkEnd.ID.Val]; ok {
+					info := bookmarks[name]
+					info.text = strings.TrimSpace(texts[name].String())
+					bookmarks[name] = info
+					delete(open, child.BookmarkEnd.ID.Val)
+					delete(texts, name)
+				}
+			default:
+				text := paragraphPlainText(&ctypes.Paragraph{Children: []ctypes.ParagraphChild{child}})
+				for _, name := range open {
+					texts[name].WriteString(text)
+				}
+				if child.Run != nil {
+					page += runPageBreaks(child.Run)
+				}
+			}
+		}
+	})
+
+	// Bookmarks that are never closed hold the text up to the end of the document
+	for name, sb := range texts {
+		info := bookmarks[name]
+		info.text = strings.TrimSpace(sb.String())
+		bookmarks[name] = info
+	}
+
+	return bookmarks
+}
+
+// documentVariables returns the document variables of the settings part.
+func (rd *RootDoc) documentVariables() map[string]string {
+	content, ok := rd.settingsPart()
+	if !ok {
+		return nil
+	}
+
+	var settings struct {
+		DocVars []struct {
+			Name string `xml:"name,attr"`
+			Val  string `xml:"val,attr"`
+		} `xml:"docVars>docVar"`
+	}
+	if err := xml.Unmarshal(content, &settings); err != nil {
+		return nil
+	}
+
+	variables := make(map[string]string, len(settings.DocVars))
+	for _, v := range settings.DocVars {
+		variables[v.Name] = v.Val
+	}
+	return variables
+}
+
+// settingsPart returns the content of the settings part of the document.
+func (rd *RootDoc) settingsPart() ([]byte, bool) {
+	if rd.Document == nil {
+		return nil, false
+	}
+	for _, rel := range rd.Document.DocRels.Relationships {
+		if rel.Type == constants.SettingsType {
+			return rd.ReadPart(rel.Target)
+		}
+	}
+	return nil, false
+}
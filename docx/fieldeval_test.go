@@ -0,0 +1,255 @@
+package docx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addFieldRun appends a run built from tokens to the paragraph: "{" begins a field, "|"
+// separates the instruction from the result, "}" ends the field, "i:" prefixes instruction
+// text and any other token is result text.
+func addFieldRun(p *Paragraph, tokens ...string) {
+	run := p.AddRun()
+	for _, token := range tokens {
+		var child ctypes.RunChild
+		switch {
+		case token == "{":
+			child.FldChar = newFieldChar(stypes.FldCharTypeBegin)
+		case token == "|":
+			child.FldChar = newFieldChar(stypes.FldCharTypeSeparate)
+		case token == "}":
+			child.FldChar = newFieldChar(stypes.FldCharTypeEnd)
+		case len(token) > 2 && token[:2] == "i:":
+			child.InstrText = ctypes.TextFromString(token[2:])
+		default:
+			child.Text = ctypes.TextFromString(token)
+		}
+		run.ct.Children = append(run.ct.Children, child)
+	}
+}
+
+func addCoreProperties(rd *RootDoc, props string) {
+	rd.RootRels.Relationships = append(rd.RootRels.Relationships, &Relationship{
+		ID:     "rId1",
+		Type:   constants.CORE_PROP_TYPE,
+		Target: "docProps/core.xml",
+	})
+	rd.FileMap.Store("docProps/core.xml", []byte(`<cp:coreProperties `+
+		`xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" `+
+		`xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/">`+
+		props+`</cp:coreProperties>`))
+}
+
+func TestUpdateFields_DocumentValues(t *testing.T) {
+	rd := setupRootDoc(t)
+	addCoreProperties(rd, `<dc:title>Annual Report</dc:title><dc:creator>Jane Doe</dc:creator>`+
+		`<dcterms:created>2023-11-02T09:30:00Z</dcterms:created>`)
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &Relationship{
+		ID:     "rId9",
+		Type:   constants.SettingsType,
+		Target: "settings.xml",
+	})
+	rd.FileMap.Store("word/settings.xml", []byte(`<w:settings xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">`+
+		`<w:docVars><w:docVar w:name="Client" w:val="Contoso"/></w:docVars></w:settings>`))
+
+	now := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+	ctx := FieldContext{
+		Now:        now,
+		Variables:  map[string]string{"Amount": "1234.5", "Count": "4"},
+		Properties: map[string]string{"Department": "Finance"},
+	}
+
+	tests := []struct {
+		name     string
+		instr    string
+		expected string
+	}{
+		{"Date picture", `DATE \@ "d MMMM yyyy"`, "5 March 2024"},
+		{"Date default", `DATE`, "3/5/2024"},
+		{"Time upper case", `TIME \@ "h:mm am/pm" \* Upper`, "2:07 PM"},
+		{"Create date", `CREATEDATE \@ "yyyy-MM-dd HH:mm"`, "2023-11-02 09:30"},
+		{"Core property", `DOCPROPERTY Title \* MERGEFORMAT`, "Annual Report"},
+		{"Context property", `DOCPROPERTY Department`, "Finance"},
+		{"Author", `AUTHOR \* Upper`, "JANE DOE"},
+		{"Context variable", `DOCVARIABLE Amount \# "$#,##0.00"`, "$1,234.50"},
+		{"Settings variable", `DOCVARIABLE Client \* Lower`, "contoso"},
+		{"Roman", `DOCVARIABLE Count \* ROMAN`, "IV"},
+		{"Lower roman", `DOCVARIABLE Count \* roman`, "iv"},
+		{"Ordinal", `DOCVARIABLE Count \* Ordinal`, "4th"},
+		{"Alphabetic", `DOCVARIABLE Count \* ALPHABETIC`, "D"},
+		{"If", `IF 5 > 3 "bigger" "smaller"`, "bigger"},
+		{"If wildcard", `IF "Contoso Ltd" = "Cont*" "match" "no match"`, "match"},
+		{"If false without text", `IF 1 = 2 "same"`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := rd.AddEmptyParagraph()
+			p.AddField(tt.instr, "old")
+
+			_, err := rd.UpdateFields(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, paragraphPlainText(&p.ct))
+		})
+	}
+}
+
+func TestUpdateFields_NestedFields(t *testing.T) {
+	rd := setupRootDoc(t)
+	ctx := FieldContext{Variables: map[string]string{"Status": "yes"}}
+
+	p := rd.AddEmptyParagraph()
+	addFieldRun(p, "{", `i: IF "`, "{", "i: DOCVARIABLE Status ", "|", "no", "}", `i:" = "yes" "On" "Off" `, "|", "Off", "}")
+
+	updated, err := rd.UpdateFields(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+	assert.Equal(t, "yesOn", paragraphPlainText(&p.ct))
+}
+
+func TestScanFieldsAcrossRuns_Nesting(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddEmptyParagraph()
+	addFieldRun(p, "{", "i: IF ", "{", "i: MERGEFIELD name ", "|", "Ann", "}", `i: = "Ann" "yes" "no" `, "|", "yes", "}")
+	addFieldRun(p, "|", "stray", "}")
+	addFieldRun(p, "{", "i: PAGE ", "|", "1", "}")
+
+	fields := scanFieldsAcrossRuns(paragraphRuns(&p.ct))
+	codes := make([]string, len(fields))
+	for i, field := range fields {
+		codes[i] = field.code
+	}
+	assert.Equal(t, []string{` IF Ann = "Ann" "yes" "no" `, " MERGEFIELD name ", " PAGE "}, codes)
+
+	assert.Equal(t, 1, rd.ReplaceFields(map[string]string{"PAGE": "7"}))
+	assert.Equal(t, "Annyesstray7", paragraphPlainText(&p.ct))
+}
+
+func TestUpdateFields_Sequences(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	heading := func(text string) {
+		p := rd.AddParagraph(text)
+		p.Style("Heading1")
+	}
+	caption := func() *Paragraph {
+		p := rd.AddParagraph("Figure ")
+		p.AddField(`SEQ Figure \s 1`, "0")
+		return p
+	}
+
+	heading("One")
+	f1 := caption()
+	f2 := caption()
+	heading("Two")
+	f3 := caption()
+
+	tbl := rd.AddTable()
+	t1 := tbl.AddRow().AddCell().AddParagraph("Table ")
+	t1.AddField(`SEQ Table \r 5`, "1")
+	t2 := rd.AddParagraph("Table ")
+	t2.AddField(`SEQ Table`, "1")
+	t3 := rd.AddParagraph("Same ")
+	t3.AddField(`SEQ Table \c`, "1")
+	hidden := rd.AddParagraph("Hidden")
+	hidden.AddField(`SEQ Table \h`, "1")
+	t4 := rd.AddParagraph("Table ")
+	t4.AddSimpleField(`SEQ Table \* alphabetic`, "1")
+
+	_, err := rd.UpdateFields(FieldContext{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Figure 1", paragraphPlainText(&f1.ct))
+	assert.Equal(t, "Figure 2", paragraphPlainText(&f2.ct))
+	assert.Equal(t, "Figure 1", paragraphPlainText(&f3.ct))
+	assert.Equal(t, "Table 5", paragraphPlainText(&t1.ct))
+	assert.Equal(t, "Table 6", paragraphPlainText(&t2.ct))
+	assert.Equal(t, "Same 6", paragraphPlainText(&t3.ct))
+	assert.Equal(t, "Hidden", paragraphPlainText(&hidden.ct))
+	assert.Equal(t, "Table h", paragraphPlainText(&t4.ct))
+}
+
+func TestUpdateFields_References(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	// The reference comes before the caption it refers to
+	ref := rd.AddParagraph("See ")
+	ref.AddRefField("_RefFigure", true)
+	ref.AddText(" on page ")
+	ref.AddField("PAGEREF _RefFigure", "1")
+
+	rd.AddParagraph("Figure ").AddSeqField("Figure")
+	rd.AddPageBreak()
+	caption := rd.AddParagraph("Figure ")
+	caption.AddField("SEQ Figure", "1")
+	caption.AddBookmark("_RefFigure")
+
+	bare := rd.AddEmptyParagraph()
+	bare.AddField(`_RefFigure \* Upper`, "")
+	missing := rd.AddEmptyParagraph()
+	missing.AddField("REF Missing", "old")
+
+	updated, err := rd.UpdateFields(FieldContext{})
+	require.ErrorIs(t, err, errRefNotFound)
+	assert.Equal(t, 6, updated)
+
+	assert.Equal(t, "See Figure 2 on page 2", paragraphPlainText(&ref.ct))
+	assert.Equal(t, "FIGURE 2", paragraphPlainText(&bare.ct))
+	assert.Equal(t, "Error! Reference source not found.", paragraphPlainText(&missing.ct))
+	assert.Nil(t, ref.ct.Children[1].Run.Children[0].FldChar.Dirty)
+	assert.Equal(t, []string{"_RefFigure"}, rd.Bookmarks())
+}
+
+func TestUpdateFields_UnchangedFields(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	p := rd.AddEmptyParagraph()
+	p.AddPageField()
+	p.AddField("DATE", "locked").Lock(true)
+	p.AddField("MERGEFIELD Name", "«Name»")
+	p.AddField("= 1 + 2", "3")
+
+	updated, err := rd.UpdateFields(FieldContext{Now: time.Now()})
+	require.NoError(t, err)
+	assert.Equal(t, 0, updated)
+	assert.Equal(t, "1locked«Name»3", paragraphPlainText(&p.ct))
+}
+
+func TestFormatFieldResult(t *testing.T) {
+	tests := []struct {
+		result   string
+		format   string
+		expected string
+	}{
+		{"hello world", "FirstCap", "Hello world"},
+		{"hello WORLD", "Caps", "Hello WORLD"},
+		{"old  mcDonald\tiPhone x", "Caps", "Old  McDonald\tIPhone X"},
+		{"28", "ALPHABETIC", "BB"},
+		{"1999", "Roman", "MCMXCIX"},
+		{"12", "Ordinal", "12th"},
+		{"22", "Ordinal", "22nd"},
+		{"255", "Hex", "FF"},
+		{"text", "Roman", "text"},
+		{"7", "MERGEFORMAT", "7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.result, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatFieldResult(tt.result, tt.format))
+		})
+	}
+}
+
+func TestMatchWildcard(t *testing.T) {
+	assert.True(t, matchWildcard("Cont*", "Contoso"))
+	assert.True(t, matchWildcard("C?ntoso", "Contoso"))
+	assert.True(t, matchWildcard("*so", "Contoso"))
+	assert.False(t, matchWildcard("Cont?", "Contoso"))
+	assert.False(t, matchWildcard("Fabrikam", "Contoso"))
+}
@@ -0,0 +1,317 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/bfoley13/godocx/common/units"
+	"github.com/bfoley13/godocx/dml"
+	"github.com/bfoley13/godocx/dml/dmlct"
+	"github.com/bfoley13/godocx/dml/dmlst"
+	"github.com/bfoley13/godocx/internal"
+)
+
+// PictureWrap is the way text flows around a floating picture.
+type PictureWrap int
+
+const (
+	// PictureWrapSquare wraps text around the bounding box of the picture.
+	PictureWrapSquare PictureWrap = iota
+	// PictureWrapTight wraps text around the outline of the picture.
+	PictureWrapTight
+	// PictureWrapThrough wraps text around the outline of the picture and into its open areas.
+	PictureWrapThrough
+	// PictureWrapTopAndBottom places the picture on its own lines, with text above and below.
+	PictureWrapTopAndBottom
+	// PictureWrapNone does not wrap text, which flows in front of or behind the picture.
+	PictureWrapNone
+)
+
+// wrapPolygonSize is the size of the square the points of a wrap polygon are expressed in.
+const wrapPolygonSize = 21600
+
+// FloatingOptions sets the position and text wrapping of a floating picture.
+type FloatingOptions struct {
+	// RelativeH is the base of the horizontal position. It defaults to the column.
+	RelativeH dmlst.RelFromH
+	// AlignH aligns the picture horizontally relative to its base. When it is empty, the
+	// picture is placed at OffsetX.
+	AlignH dmlst.AlignH
+	// OffsetX is the distance from the left edge of the base to the left edge of the picture.
+	OffsetX units.Inch
+
+	// RelativeV is the base of the vertical position. It defaults to the paragraph.
+	RelativeV dmlst.RelFromV
+	// AlignV aligns the picture vertically relative to its base. When it is empty, the picture
+	// is placed at OffsetY.
+	AlignV dmlst.AlignV
+	// OffsetY is the distance from the top edge of the base to the top edge of the picture.
+	OffsetY units.Inch
+
+	// Wrap is the way text flows around the picture. It defaults to PictureWrapSquare.
+	Wrap PictureWrap
+	// WrapSide is the side text flows on for square, tight and through wrapping. It
+	// defaults to both sides.
+	WrapSide dmlst.WrapText
+
+	// The minimum distances kept between the picture and the text around it.
+	DistanceTop    units.Inch
+	DistanceBottom units.Inch
+	DistanceLeft   units.Inch
+	DistanceRight  units.Inch
+
+	// BehindText places the picture behind the text instead of in front of it.
+	BehindText bool
+	// AllowOverlap lets other floating objects overlap the picture.
+	AllowOverlap bool
+	// Locked keeps the picture anchored to its paragraph when the picture is moved.
+	Locked bool
+	// LayoutInCell positions the picture relative to the table cell holding its paragraph.
+	LayoutInCell bool
+}
+
+// validate returns an error if an option has a value that is not defined.
+func (opts FloatingOptions) validate() error {
+	if opts.RelativeH != "" {
+		if _, err := dmlst.RelFromHFromStr(string(opts.RelativeH)); err != nil {
+			return err
+		}
+	}
+	if opts.RelativeV != "" {
+		if _, err := dmlst.RelFromVFromStr(string(opts.RelativeV)); err != nil {
+			return err
+		}
+	}
+	if opts.AlignH != "" {
+		if _, err := dmlst.AlignHFromStr(string(opts.AlignH)); err != nil {
+			return err
+		}
+	}
+	if opts.AlignV != "" {
+		if _, err := dmlst.AlignVFromStr(string(opts.AlignV)); err != nil {
+			return err
+		}
+	}
+	if opts.WrapSide != "" {
+		if _, err := dmlst.WrapTextFromStr(string(opts.WrapSide)); err != nil {
+			return err
+		}
+	}
+	if opts.Wrap < PictureWrapSquare || opts.Wrap > PictureWrapNone {
+		return fmt.Errorf("invalid picture wrap %d", opts.Wrap)
+	}
+	return nil
+}
+
+// apply sets the position, wrapping and flags of the anchor.
+func (opts FloatingOptions) apply(anchor *dml.Anchor) {
+	relH, relV, side := opts.RelativeH, opts.RelativeV, opts.WrapSide
+	if relH == "" {
+		relH = dmlst.RelFromHColumn
+	}
+	if relV == "" {
+		relV = dmlst.RelFromVParagraph
+	}
+	if side == "" {
+		side = dmlst.WrapTextBothSides
+	}
+
+	anchor.SimplePosAttr = internal.ToPtr(0)
+	anchor.PositionH = dml.PoistionH{RelativeFrom: relH, Align: opts.AlignH, PosOffset: int(opts.OffsetX.ToEmu())}
+	anchor.PositionV = dml.PoistionV{RelativeFrom: relV, Align: opts.AlignV, PosOffset: int(opts.OffsetY.ToEmu())}
+
+	anchor.DistT = uint(opts.DistanceTop.ToEmu())
+	anchor.DistB = uint(opts.DistanceBottom.ToEmu())
+	anchor.DistL = uint(opts.DistanceLeft.ToEmu())
+	anchor.DistR = uint(opts.DistanceRight.ToEmu())
+
+	anchor.WrapNone, anchor.WrapSquare, anchor.WrapTight, anchor.WrapThrough, anchor.WrapTopBtm = nil, nil, nil, nil, nil
+	switch opts.Wrap {
+	case PictureWrapSquare:
+		anchor.WrapSquare = &dml.WrapSquare{WrapText: side}
+	case PictureWrapTight:
+		anchor.WrapTight = &dml.WrapTight{WrapText: side, WrapPolygon: rectWrapPolygon()}
+	case PictureWrapThrough:
+		anchor.WrapThrough = &dml.WrapThrough{WrapText: side, WrapPolygon: rectWrapPolygon()}
+	case PictureWrapTopAndBottom:
+		anchor.WrapTopBtm = &dml.WrapTopBtm{}
+	case PictureWrapNone:
+		anchor.WrapNone = &dml.WrapNone{}
+	}
+
+	anchor.BehindDoc = boolInt(opts.BehindText)
+	anchor.AllowOverlap = boolInt(opts.AllowOverlap)
+	anchor.Locked = boolInt(opts.Locked)
+	anchor.LayoutInCell = boolInt(opts.LayoutInCell)
+}
+
+// rectWrapPolygon returns a wrap polygon following the bounding box of a picture.
+func rectWrapPolygon() dml.WrapPolygon {
+	return dml.WrapPolygon{
+		Start: dmlct.Point2D{},
+		LineTo: []dmlct.Point2D{
+			{YAxis: wrapPolygonSize},
+			{XAxis: wrapPolygonSize, YAxis: wrapPolygonSize},
+			{XAxis: wrapPolygonSize},
+			{},
+		},
+	}
+}
+
+// boolInt returns 1 for true and 0 for false.
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// AddFloatingPicture adds an image from a file to the paragraph as a floating picture, placed
+// and wrapped by the options instead of flowing with the text.
+//
+// Example usage:
+//
+//	// Add a logo to the top right corner of the page margins
+//	_, err = para.AddFloatingPicture("logo.png", units.Inch(1.5), units.Inch(0.5), docx.FloatingOptions{
+//	    RelativeH: dmlst.RelFromHMargin,
+//	    AlignH:    dmlst.AlignHRight,
+//	    RelativeV: dmlst.RelFromVMargin,
+//	    AlignV:    dmlst.AlignVTop,
+//	    Wrap:      docx.PictureWrapTopAndBottom,
+//	})
+//
+// Parameters:
+//   - path: The path of the image file to be added.
+//   - width: The width of the image in inches.
+//   - height: The height of the image in inches.
+//   - opts: The position and text wrapping of the picture.
+//
+// Returns:
+//   - *PicMeta: Metadata about the added picture, including the Paragraph instance and Anchor element.
+//   - error: An error if the file cannot be read, its extension is not a known image type or
+//     an option is invalid.
+func (p *Paragraph) AddFloatingPicture(path string, width units.Inch, height units.Inch, opts FloatingOptions) (*PicMeta, error) {
+	imgBytes, err := internal.FileToByte(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.AddFloatingPictureBytes(imgBytes, filepath.Ext(path), width, height, opts)
+}
+
+// AddFloatingPictureBytes adds an image held in memory to the paragraph as a floating
+// picture. See AddFloatingPicture and AddPictureBytes.
+func (p *Paragraph) AddFloatingPictureBytes(imgBytes []byte, imgExt string, width units.Inch, height units.Inch, opts FloatingOptions) (*PicMeta, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	pic, err := p.AddPictureBytes(imgBytes, imgExt, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return pic, pic.MakeFloating(opts)
+}
+
+// MakeFloating turns an inline picture into a floating picture placed and wrapped by the
+// options. The options of a floating picture are replaced.
+//
+// Returns:
+//   - error: An error if an option is invalid or the picture is not found in its paragraph.
+func (pm *PicMeta) MakeFloating(opts FloatingOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	if pm.Anchor != nil {
+		opts.apply(pm.Anchor)
+		return nil
+	}
+
+	drawing, idx := pm.drawing()
+	if drawing == nil {
+		return errors.New("picture not found in its paragraph")
+	}
+
+	inline := drawing.Inline[idx]
+	anchor := &dml.Anchor{
+		RelativeHeight:    int(inline.DocProp.ID),
+		Extent:            inline.Extent,
+		EffectExtent:      inline.EffectExtent,
+		DocProp:           inline.DocProp,
+		CNvGraphicFramePr: inline.CNvGraphicFramePr,
+		Graphic:           inline.Graphic,
+	}
+	opts.apply(anchor)
+
+	drawing.Inline = append(drawing.Inline[:idx], drawing.Inline[idx+1:]...)
+	drawing.Anchor = append(drawing.Anchor, anchor)
+	pm.Inline, pm.Anchor = nil, anchor
+
+	return nil
+}
+
+// MakeInline turns a floating picture into an inline picture flowing with the text of its
+// paragraph, keeping its size.
+//
+// Returns:
+//   - error: An error if the picture is not found in its paragraph.
+func (pm *PicMeta) MakeInline() error {
+	if pm.Anchor == nil {
+		return nil
+	}
+
+	drawing, idx := pm.drawing()
+	if drawing == nil {
+		return errors.New("picture not found in its paragraph")
+	}
+
+	anchor := drawing.Anchor[idx]
+	drawing.Inline = append(drawing.Inline, dml.Inline{
+		Extent:            anchor.Extent,
+		EffectExtent:      anchor.EffectExtent,
+		DocProp:           anchor.DocProp,
+		CNvGraphicFramePr: anchor.CNvGraphicFramePr,
+		Graphic:           anchor.Graphic,
+	})
+	drawing.Anchor = append(drawing.Anchor[:idx], drawing.Anchor[idx+1:]...)
+	pm.Inline, pm.Anchor = &drawing.Inline[len(drawing.Inline)-1], nil
+
+	return nil
+}
+
+// IsFloating reports whether the picture is a floating picture.
+func (pm *PicMeta) IsFloating() bool {
+	return pm.Anchor != nil
+}
+
+// drawing returns the drawing of the paragraph holding the picture, and the index of the
+// picture in the inline or anchor elements of the drawing. The drawing is nil when it is not
+// found.
+func (pm *PicMeta) drawing() (*dml.Drawing, int) {
+	if pm.Para == nil {
+		return nil, -1
+	}
+
+	for _, run := range paragraphRuns(&pm.Para.ct) {
+		for _, child := range run.Children {
+			drawing := child.Drawing
+			if drawing == nil {
+				continue
+			}
+			for i := range drawing.Inline {
+				if pm.Anchor == nil && &drawing.Inline[i] == pm.Inline {
+					return drawing, i
+				}
+			}
+			for i, anchor := range drawing.Anchor {
+				if pm.Anchor != nil && anchor == pm.Anchor {
+					return drawing, i
+				}
+			}
+		}
+	}
+	return nil, -1
+}
@@ -0,0 +1,165 @@
+package docx
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/common/units"
+	"github.com/bfoley13/godocx/dml/dmlst"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddFloatingPictureBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     FloatingOptions
+		expected []string
+	}{
+		{
+			name: "Defaults",
+			opts: FloatingOptions{},
+			expected: []string{
+				`<wp:anchor behindDoc="0" distT="0" distB="0" distL="0" distR="0" simplePos="0" locked="0" layoutInCell="0" allowOverlap="0" relativeHeight="2">`,
+				`<wp:positionH relativeFrom="column"><wp:posOffset>0</wp:posOffset></wp:positionH>`,
+				`<wp:positionV relativeFrom="paragraph"><wp:posOffset>0</wp:posOffset></wp:positionV>`,
+				`<wp:extent cx="914400" cy="457200"></wp:extent><wp:wrapSquare wrapText="bothSides"></wp:wrapSquare>`,
+			},
+		},
+		{
+			name: "Letterhead logo",
+			opts: FloatingOptions{
+				RelativeH:    dmlst.RelFromHMargin,
+				AlignH:       dmlst.AlignHRight,
+				RelativeV:    dmlst.RelFromVPage,
+				OffsetY:      units.Inch(0.5),
+				Wrap:         PictureWrapTopAndBottom,
+				DistanceTop:  units.Inch(0.1),
+				AllowOverlap: true,
+				Locked:       true,
+			},
+			expected: []string{
+				`<wp:anchor behindDoc="0" distT="91440" distB="0" distL="0" distR="0" simplePos="0" locked="1" layoutInCell="0" allowOverlap="1" relativeHeight="2">`,
+				`<wp:positionH relativeFrom="margin"><wp:align>right</wp:align></wp:positionH>`,
+				`<wp:positionV relativeFrom="page"><wp:posOffset>457200</wp:posOffset></wp:positionV>`,
+				`<wp:wrapTopAndBottom></wp:wrapTopAndBottom>`,
+			},
+		},
+		{
+			name: "Behind text",
+			opts: FloatingOptions{Wrap: PictureWrapNone, BehindText: true, LayoutInCell: true},
+			expected: []string{
+				`<wp:anchor behindDoc="1" distT="0" distB="0" distL="0" distR="0" simplePos="0" locked="0" layoutInCell="1"`,
+				`<wp:wrapNone></wp:wrapNone>`,
+			},
+		},
+		{
+			name: "Tight on the right",
+			opts: FloatingOptions{Wrap: PictureWrapTight, WrapSide: dmlst.WrapTextRight},
+			expected: []string{
+				`<wp:wrapTight wrapText="right"><wp:wrapPolygon>`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rd := setupRootDoc(t)
+			p := rd.AddParagraph("Letter")
+
+			pic, err := p.AddFloatingPictureBytes([]byte("png"), ".png", units.Inch(1), units.Inch(0.5), tt.opts)
+			require.NoError(t, err)
+			assert.Same(t, p, pic.Para)
+			assert.Nil(t, pic.Inline)
+			require.NotNil(t, pic.Anchor)
+			assert.True(t, pic.IsFloating())
+
+			drawing := p.ct.Children[1].Run.Children[0].Drawing
+			assert.Empty(t, drawing.Inline)
+			require.Len(t, drawing.Anchor, 1)
+			assert.Same(t, pic.Anchor, drawing.Anchor[0])
+
+			output, err := xml.Marshal(p.ct)
+			require.NoError(t, err)
+			for _, expected := range tt.expected {
+				assert.Contains(t, string(output), expected)
+			}
+
+			// The document root declares the drawing namespace
+			var loaded ctypes.Paragraph
+			output = []byte(strings.Replace(string(output), "<w:p>", `<w:p `+mergeNS+` xmlns:wp="`+constants.WMLDrawingNS+`">`, 1))
+			require.NoError(t, xml.Unmarshal(output, &loaded))
+			anchor := loaded.Children[1].Run.Children[0].Drawing.Anchor[0]
+			assert.Equal(t, pic.Anchor.PositionH, anchor.PositionH)
+			assert.Equal(t, pic.Anchor.PositionV, anchor.PositionV)
+			assert.Equal(t, pic.Anchor.BehindDoc, anchor.BehindDoc)
+			assert.Equal(t, "rId1", anchor.Graphic.Data.Pic.BlipFill.Blip.EmbedID)
+		})
+	}
+}
+
+func TestAddFloatingPictureBytes_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts FloatingOptions
+	}{
+		{"Relative horizontal", FloatingOptions{RelativeH: "nowhere"}},
+		{"Relative vertical", FloatingOptions{RelativeV: "nowhere"}},
+		{"Horizontal alignment", FloatingOptions{AlignH: "top"}},
+		{"Vertical alignment", FloatingOptions{AlignV: "left"}},
+		{"Wrap side", FloatingOptions{WrapSide: "above"}},
+		{"Wrap", FloatingOptions{Wrap: PictureWrap(9)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rd := setupRootDoc(t)
+			p := rd.AddEmptyParagraph()
+
+			_, err := p.AddFloatingPictureBytes([]byte("png"), ".png", 1, 1, tt.opts)
+			assert.Error(t, err)
+			assert.Empty(t, p.ct.Children)
+			assert.Empty(t, rd.Document.DocRels.Relationships)
+		})
+	}
+}
+
+func TestPicMeta_MakeFloatingAndInline(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddEmptyParagraph()
+	pic, err := p.AddPictureBytes([]byte("png"), ".png", units.Inch(2), units.Inch(1))
+	require.NoError(t, err)
+	inline := *pic.Inline
+	drawing := p.ct.Children[0].Run.Children[0].Drawing
+
+	require.NoError(t, pic.MakeFloating(FloatingOptions{RelativeH: dmlst.RelFromHPage, AlignH: dmlst.AlignHCenter}))
+	assert.Nil(t, pic.Inline)
+	assert.Empty(t, drawing.Inline)
+	require.Len(t, drawing.Anchor, 1)
+	assert.Equal(t, inline.Extent, pic.Anchor.Extent)
+	assert.Equal(t, inline.DocProp, pic.Anchor.DocProp)
+	assert.Equal(t, inline.Graphic, pic.Anchor.Graphic)
+
+	// The options of a floating picture are replaced
+	require.NoError(t, pic.MakeFloating(FloatingOptions{Wrap: PictureWrapThrough}))
+	require.Len(t, drawing.Anchor, 1)
+	assert.Equal(t, dmlst.RelFromHColumn, pic.Anchor.PositionH.RelativeFrom)
+	assert.Nil(t, pic.Anchor.WrapSquare)
+	assert.NotNil(t, pic.Anchor.WrapThrough)
+	assert.Error(t, pic.MakeFloating(FloatingOptions{AlignV: "middle"}))
+
+	require.NoError(t, pic.MakeInline())
+	assert.Nil(t, pic.Anchor)
+	assert.Empty(t, drawing.Anchor)
+	require.Len(t, drawing.Inline, 1)
+	assert.Same(t, &drawing.Inline[0], pic.Inline)
+	assert.Equal(t, inline, *pic.Inline)
+	require.NoError(t, pic.MakeInline())
+
+	// A picture that is no longer in its paragraph cannot be converted
+	p.ct.Children = nil
+	assert.Error(t, pic.MakeFloating(FloatingOptions{}))
+}
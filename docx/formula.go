@@ -0,0 +1,756 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	errFormulaSyntax = errors.New("syntax error")
+	errZeroDivide    = errors.New("division by zero")
+)
+
+// UpdateFormulas evaluates the formula fields in the cells of the table, such as
+// { = SUM(ABOVE) } or { = B2*C2 \# "0.00" }, and replaces their results. It returns the number
+// of fields updated. Tables nested in the cells are updated too.
+//
+// Formulas support the operators + - * / ^ % and the comparisons = <> < <= > >=, the
+// functions SUM, AVERAGE, PRODUCT, MIN, MAX, COUNT, IF, ABS, INT, MOD, ROUND, SIGN, AND, OR and
+// NOT, A1 style cell references and ranges such as B2 or A1:C3, and the ABOVE, LEFT, RIGHT and
+// BELOW ranges. ABOVE takes the cells above the formula cell up to the first cell that does not
+// hold a number, and the other directions likewise. The result is formatted with the numeric
+// picture of the \# switch, see FormatNumber.
+//
+// Formulas are evaluated in reading order, so a formula sees the updated results of the
+// formulas before it. A formula that cannot be evaluated gets the result Word shows, such as
+// "!Syntax Error", and the first error is returned after every formula has been updated.
+func (t *Table) UpdateFormulas() (int, error) {
+	var (
+		updated  int
+		firstErr error
+	)
+
+	grid := t.LogicalGrid()
+	for r, row := range grid {
+		for c, pos := range row {
+			if pos.Cell == nil || pos.Row != r || pos.Col != c {
+				continue
+			}
+
+			for _, block := range pos.Cell.ct.Contents {
+				if block.Table != nil {
+					// The nested table shares its rows with the cell
+					nested := &Table{root: t.root, ct: *block.Table}
+					n, err := nested.UpdateFormulas()
+					updated += n
+					if firstErr == nil {
+						firstErr = err
+					}
+				}
+				if block.Paragraph == nil {
+					continue
+				}
+
+				fields := scanFieldsAcrossRuns(paragraphRuns(block.Paragraph))
+				results := make(map[int]string)
+				for i, field := range fields {
+					code := strings.TrimSpace(field.code)
+					if !strings.HasPrefix(code, "=") {
+						continue
+					}
+
+					eval := &formulaEval{grid: grid, cell: pos}
+					result, err := eval.evaluate(code[1:])
+					if err != nil {
+						result = "!Syntax Error"
+						if errors.Is(err, errZeroDivide) {
+							result = "!Zero Divide"
+						}
+						if firstErr == nil {
+							firstErr = fmt.Errorf("formula %q at (%d, %d): %w", code, r, c, err)
+						}
+					}
+					results[i] = result
+				}
+
+				for i, field := range fields {
+					if result, ok := results[i]; ok {
+						field.setResult(result)
+						updated++
+					}
+				}
+			}
+		}
+	}
+
+	return updated, firstErr
+}
+
+// UpdateFormulas evaluates the formula fields of every table of the document body and replaces
+// their results, see Table.UpdateFormulas. It returns the number of fields updated and the
+// first error.
+func (rd *RootDoc) UpdateFormulas() (int, error) {
+	var (
+		updated  int
+		firstErr error
+	)
+
+	for _, tbl := range rd.Tables() {
+		n, err := tbl.UpdateFormulas()
+		updated += n
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return updated, firstErr
+}
+
+// formulaEval evaluates a formula of a table cell.
+type formulaEval struct {
+	grid [][]GridCell
+
+	// cell is the position of the cell holding the formula
+	cell GridCell
+
+	tokens []string
+	pos    int
+}
+
+// formulaValue is the value of a formula expression: a single number, or the numbers of a
+// range of cells, which only functions accept.
+type formulaValue struct {
+	nums  []float64
+	isSet bool
+}
+
+// evaluate evaluates the instruction of a formula field, without its leading equal sign, and
+// returns the formatted result.
+func (f *formulaEval) evaluate(instr string) (string, error) {
+	expr, picture, err := splitFormula(instr)
+	if err != nil {
+		return "", err
+	}
+
+	if f.tokens, err = tokenizeFormula(expr); err != nil {
+		return "", err
+	}
+	if len(f.tokens) == 0 {
+		return "", errFormulaSyntax
+	}
+
+	value, err := f.comparison()
+	if err != nil {
+		return "", err
+	}
+	if f.pos < len(f.tokens) {
+		return "", fmt.Errorf("%w: unexpected %q", errFormulaSyntax, f.tokens[f.pos])
+	}
+	number, err := value.scalar()
+	if err != nil {
+		return "", err
+	}
+
+	if picture != "" {
+		return FormatNumber(number, picture), nil
+	}
+	return formatFormulaNumber(number), nil
+}
+
+// splitFormula splits the instruction of a formula field into the expression and the numeric
+// picture of its \# switch. Other switches are ignored.
+func splitFormula(instr string) (string, string, error) {
+	var quoted bool
+	end := len(instr)
+	for i, r := range instr {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == '\\' && !quoted {
+			end = i
+			break
+		}
+	}
+
+	expr, switches := instr[:end], instr[end:]
+	var picture string
+	for switches != "" {
+		if len(switches) < 2 || switches[0] != '\\' {
+			return "", "", fmt.Errorf("%w: invalid switch %q", errFormulaSyntax, switches)
+		}
+		name := switches[1]
+		rest := strings.TrimLeft(switches[2:], " ")
+
+		// The argument of the switch is a quoted string or a word
+		var arg string
+		if strings.HasPrefix(rest, `"`) {
+			closing := strings.IndexByte(rest[1:], '"')
+			if closing < 0 {
+				return "", "", fmt.Errorf("%w: unterminated switch argument", errFormulaSyntax)
+			}
+			arg, rest = rest[1:closing+1], rest[closing+2:]
+		} else if next := strings.IndexAny(rest, ` \`); next >= 0 {
+			arg, rest = rest[:next], rest[next:]
+		} else {
+			arg, rest = rest, ""
+		}
+
+		if name == '#' {
+			picture = arg
+		}
+		switches = strings.TrimLeft(rest, " ")
+	}
+
+	return expr, picture, nil
+}
+
+// tokenizeFormula splits a formula expression into numbers, names, references and operators.
+// Names are upper cased.
+func tokenizeFormula(expr string) ([]string, error) {
+	var tokens []string
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		case unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, strings.ToUpper(string(runes[start:i])))
+		case strings.ContainsRune("<>", r) && i+1 < len(runes) && (runes[i+1] == '=' || (r == '<' && runes[i+1] == '>')):
+			tokens = append(tokens, string(runes[i:i+2]))
+			i += 2
+		case strings.ContainsRune("+-*/^%(),;:=<>", r):
+			tokens = append(tokens, string(r))
+			i++
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q", errFormulaSyntax, r)
+		}
+	}
+	return tokens, nil
+}
+
+func (f *formulaEval) peek() string {
+	if f.pos < len(f.tokens) {
+		return f.tokens[f.pos]
+	}
+	return ""
+}
+
+func (f *formulaEval) next() string {
+	token := f.peek()
+	f.pos++
+	return token
+}
+
+func (f *formulaEval) expect(token string) error {
+	if got := f.next(); got != token {
+		return fmt.Errorf("%w: expected %q, got %q", errFormulaSyntax, token, got)
+	}
+	return nil
+}
+
+// scalar returns the number of a single value.
+func (v formulaValue) scalar() (float64, error) {
+	if v.isSet || len(v.nums) != 1 {
+		return 0, fmt.Errorf("%w: a range is only valid as a function argument", errFormulaSyntax)
+	}
+	return v.nums[0], nil
+}
+
+func numberValue(n float64) formulaValue {
+	return formulaValue{nums: []float64{n}}
+}
+
+func boolNumber(b bool) formulaValue {
+	if b {
+		return numberValue(1)
+	}
+	return numberValue(0)
+}
+
+// comparison := additive [ ( = | <> | < | <= | > | >= ) additive ]
+func (f *formulaEval) comparison() (formulaValue, error) {
+	left, err := f.additive()
+	if err != nil {
+		return left, err
+	}
+
+	op := f.peek()
+	switch op {
+	case "=", "<>", "<", "<=", ">", ">=":
+	default:
+		return left, nil
+	}
+	f.next()
+
+	right, err := f.additive()
+	if err != nil {
+		return right, err
+	}
+	a, err := left.scalar()
+	if err != nil {
+		return left, err
+	}
+	b, err := right.scalar()
+	if err != nil {
+		return right, err
+	}
+
+	switch op {
+	case "=":
+		return boolNumber(a == b), nil
+	case "<>":
+		return boolNumber(a != b), nil
+	case "<":
+		return boolNumber(a < b), nil
+	case "<=":
+		return boolNumber(a <= b), nil
+	case ">":
+		return boolNumber(a > b), nil
+	default:
+		return boolNumber(a >= b), nil
+	}
+}
+
+// binary parses a left associative chain of the operators ops between operands.
+func (f *formulaEval) binary(operand func() (formulaValue, error), ops string, apply func(op string, a, b float64) (float64, error)) (formulaValue, error) {
+	left, err := operand()
+	if err != nil {
+		return left, err
+	}
+
+	for op := f.peek(); op != "" && len(op) == 1 && strings.Contains(ops, op); op = f.peek() {
+		f.next()
+		right, err := operand()
+		if err != nil {
+			return right, err
+		}
+		a, err := left.scalar()
+		if err != nil {
+			return left, err
+		}
+		b, err := right.scalar()
+		if err != nil {
+			return right, err
+		}
+		result, err := apply(op, a, b)
+		if err != nil {
+			return left, err
+		}
+		left = numberValue(result)
+	}
+
+	return left, nil
+}
+
+// additive := term { ( + | - ) term }
+func (f *formulaEval) additive() (formulaValue, error) {
+	return f.binary(f.term, "+-", func(op string, a, b float64) (float64, error) {
+		if op == "+" {
+			return a + b, nil
+		}
+		return a - b, nil
+	})
+}
+
+// term := power { ( * | / ) power }
+func (f *formulaEval) term() (formulaValue, error) {
+	return f.binary(f.power, "*/", func(op string, a, b float64) (float64, error) {
+		if op == "*" {
+			return a * b, nil
+		}
+		if b == 0 {
+			return 0, errZeroDivide
+		}
+		return a / b, nil
+	})
+}
+
+// power := unary { ^ unary }
+func (f *formulaEval) power() (formulaValue, error) {
+	return f.binary(f.unary, "^", func(_ string, a, b float64) (float64, error) {
+		return math.Pow(a, b), nil
+	})
+}
+
+// unary := ( - | + ) unary | primary [ % ]
+func (f *formulaEval) unary() (formulaValue, error) {
+	switch f.peek() {
+	case "-", "+":
+		negate := f.next() == "-"
+		value, err := f.unary()
+		if err != nil {
+			return value, err
+		}
+		n, err := value.scalar()
+		if err != nil {
+			return value, err
+		}
+		if negate {
+			n = -n
+		}
+		return numberValue(n), nil
+	}
+
+	value, err := f.primary()
+	if err != nil {
+		return value, err
+	}
+	if f.peek() == "%" {
+		f.next()
+		n, err := value.scalar()
+		if err != nil {
+			return value, err
+		}
+		value = numberValue(n / 100)
+	}
+	return value, nil
+}
+
+// primary := number | ( comparison ) | function ( arguments ) | direction | reference [ : reference ]
+func (f *formulaEval) primary() (formulaValue, error) {
+	token := f.next()
+	switch {
+	case token == "":
+		return formulaValue{}, fmt.Errorf("%w: unexpected end of formula", errFormulaSyntax)
+	case token == "(":
+		value, err := f.comparison()
+		if err != nil {
+			return value, err
+		}
+		return value, f.expect(")")
+	case token[0] >= '0' && token[0] <= '9' || token[0] == '.':
+		n, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return formulaValue{}, fmt.Errorf("%w: invalid number %q", errFormulaSyntax, token)
+		}
+		return numberValue(n), nil
+	case f.peek() == "(":
+		return f.function(token)
+	case token == "ABOVE" || token == "BELOW" || token == "LEFT" || token == "RIGHT":
+		return formulaValue{nums: f.direction(token), isSet: true}, nil
+	case token == "TRUE":
+		return numberValue(1), nil
+	case token == "FALSE":
+		return numberValue(0), nil
+	}
+
+	row, col, ok := parseCellReference(token)
+	if !ok {
+		return formulaValue{}, fmt.Errorf("%w: unknown name %q", errFormulaSyntax, token)
+	}
+
+	if f.peek() != ":" {
+		if err := f.checkReference(row, col); err != nil {
+			return formulaValue{}, err
+		}
+		// An empty cell counts as zero
+		n, _ := f.cellNumber(row, col)
+		return numberValue(n), nil
+	}
+
+	f.next()
+	row2, col2, ok := parseCellReference(f.next())
+	if !ok {
+		return formulaValue{}, fmt.Errorf("%w: invalid range", errFormulaSyntax)
+	}
+	if row > row2 {
+		row, row2 = row2, row
+	}
+	if col > col2 {
+		col, col2 = col2, col
+	}
+	if err := f.checkReference(row2, col2); err != nil {
+		return formulaValue{}, err
+	}
+
+	var nums []float64
+	seen := make(map[*Cell]bool)
+	for r := row; r <= row2; r++ {
+		for c := col; c <= col2; c++ {
+			cell := f.grid[r][c].Cell
+			if cell == nil || seen[cell] {
+				continue
+			}
+			seen[cell] = true
+			if n, ok := f.cellNumber(r, c); ok {
+				nums = append(nums, n)
+			}
+		}
+	}
+	return formulaValue{nums: nums, isSet: true}, nil
+}
+
+// function evaluates a function call whose name has been read.
+func (f *formulaEval) function(name string) (formulaValue, error) {
+	if err := f.expect("("); err != nil {
+		return formulaValue{}, err
+	}
+
+	var args []formulaValue
+	if f.peek() != ")" {
+		for {
+			arg, err := f.comparison()
+			if err != nil {
+				return arg, err
+			}
+			args = append(args, arg)
+			if sep := f.peek(); sep != "," && sep != ";" {
+				break
+			}
+			f.next()
+		}
+	}
+	if err := f.expect(")"); err != nil {
+		return formulaValue{}, err
+	}
+
+	var nums []float64
+	for _, arg := range args {
+		nums = append(nums, arg.nums...)
+	}
+
+	// scalars returns the arguments of a function taking count numbers
+	scalars := func(count int) ([]float64, error) {
+		if len(args) != count {
+			return nil, fmt.Errorf("%w: %s takes %d arguments", errFormulaSyntax, name, count)
+		}
+		values := make([]float64, count)
+		for i, arg := range args {
+			n, err := arg.scalar()
+			if err != nil {
+				return nil, err
+			}
+			values[i] = n
+		}
+		return values, nil
+	}
+
+	switch name {
+	case "SUM":
+		var sum float64
+		for _, n := range nums {
+			sum += n
+		}
+		return numberValue(sum), nil
+	case "AVERAGE":
+		if len(nums) == 0 {
+			return numberValue(0), nil
+		}
+		var sum float64
+		for _, n := range nums {
+			sum += n
+		}
+		return numberValue(sum / float64(len(nums))), nil
+	case "PRODUCT":
+		if len(nums) == 0 {
+			return numberValue(0), nil
+		}
+		product := 1.0
+		for _, n := range nums {
+			product *= n
+		}
+		return numberValue(product), nil
+	case "MIN", "MAX":
+		if len(nums) == 0 {
+			return numberValue(0), nil
+		}
+		result := nums[0]
+		for _, n := range nums[1:] {
+			if (name == "MIN" && n < result) || (name == "MAX" && n > result) {
+				result = n
+			}
+		}
+		return numberValue(result), nil
+	case "COUNT":
+		return numberValue(float64(len(nums))), nil
+	case "IF":
+		values, err := scalars(3)
+		if err != nil {
+			return formulaValue{}, err
+		}
+		if values[0] != 0 {
+			return numberValue(values[1]), nil
+		}
+		return numberValue(values[2]), nil
+	case "ABS", "INT", "SIGN", "NOT":
+		values, err := scalars(1)
+		if err != nil {
+			return formulaValue{}, err
+		}
+		switch name {
+		case "ABS":
+			return numberValue(math.Abs(values[0])), nil
+		case "INT":
+			return numberValue(math.Trunc(values[0])), nil
+		case "SIGN":
+			return numberValue(float64(sign(values[0]))), nil
+		default:
+			return boolNumber(values[0] == 0), nil
+		}
+	case "MOD", "ROUND", "AND", "OR":
+		values, err := scalars(2)
+		if err != nil {
+			return formulaValue{}, err
+		}
+		a, b := values[0], values[1]
+		switch name {
+		case "MOD":
+			if b == 0 {
+				return formulaValue{}, errZeroDivide
+			}
+			return numberValue(math.Mod(a, b)), nil
+		case "ROUND":
+			scale := math.Pow(10, math.Trunc(b))
+			return numberValue(math.Round(a*scale) / scale), nil
+		case "AND":
+			return boolNumber(a != 0 && b != 0), nil
+		default:
+			return boolNumber(a != 0 || b != 0), nil
+		}
+	}
+
+	return formulaValue{}, fmt.Errorf("%w: unknown function %s", errFormulaSyntax, name)
+}
+
+func sign(n float64) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// direction returns the numbers of the cells in a direction from the formula cell, up to the
+// first cell that does not hold a number.
+func (f *formulaEval) direction(dir string) []float64 {
+	cell := f.cell
+	dr, dc := 0, 0
+	r, c := cell.Row, cell.Col
+	switch dir {
+	case "ABOVE":
+		dr, r = -1, cell.Row-1
+	case "BELOW":
+		dr, r = 1, cell.Row+cell.RowSpan
+	case "LEFT":
+		dc, c = -1, cell.Col-1
+	case "RIGHT":
+		dc, c = 1, cell.Col+cell.ColSpan
+	}
+
+	var nums []float64
+	var last *Cell
+	for ; r >= 0 && r < len(f.grid) && c >= 0 && c < len(f.grid[r]); r, c = r+dr, c+dc {
+		pos := f.grid[r][c]
+		if pos.Cell == nil {
+			break
+		}
+		if pos.Cell == last {
+			continue
+		}
+		last = pos.Cell
+
+		n, ok := f.cellNumber(r, c)
+		if !ok {
+			break
+		}
+		nums = append(nums, n)
+	}
+	return nums
+}
+
+// checkReference reports an error for a reference outside the table.
+func (f *formulaEval) checkReference(row, col int) error {
+	if row >= len(f.grid) || col >= len(f.grid[row]) {
+		return fmt.Errorf("%w: reference outside the table", errFormulaSyntax)
+	}
+	return nil
+}
+
+// cellNumber returns the number held by the cell at row and col of the logical grid.
+func (f *formulaEval) cellNumber(row, col int) (float64, bool) {
+	cell := f.grid[row][col].Cell
+	if cell == nil {
+		return 0, false
+	}
+	return parseFormulaNumber(cellPlainText(cell.ct, " ", false))
+}
+
+// parseCellReference parses an A1 style reference to zero based row and column indices.
+func parseCellReference(ref string) (int, int, bool) {
+	letters := 0
+	for letters < len(ref) && ref[letters] >= 'A' && ref[letters] <= 'Z' {
+		letters++
+	}
+	if letters == 0 || letters > 3 || letters == len(ref) {
+		return 0, 0, false
+	}
+
+	row, err := strconv.Atoi(ref[letters:])
+	if err != nil || row < 1 {
+		return 0, 0, false
+	}
+
+	col := 0
+	for _, l := range ref[:letters] {
+		col = col*26 + int(l-'A'+1)
+	}
+	return row - 1, col - 1, true
+}
+
+// parseFormulaNumber parses the text of a cell as a number, ignoring currency symbols, digit
+// grouping and percent signs. Negative numbers may be written in parentheses.
+func parseFormulaNumber(text string) (float64, bool) {
+	text = strings.TrimSpace(text)
+	negative := false
+	if strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")") {
+		negative = true
+		text = text[1 : len(text)-1]
+	}
+
+	text = strings.Map(func(r rune) rune {
+		if r == ',' || r == '%' || unicode.IsSpace(r) || unicode.Is(unicode.Sc, r) {
+			return -1
+		}
+		return r
+	}, text)
+	if text == "" {
+		return 0, false
+	}
+
+	n, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, false
+	}
+	if negative {
+		n = -n
+	}
+	return n, true
+}
+
+// formatFormulaNumber formats a formula result without a numeric picture, with no more
+// decimals than needed.
+func formatFormulaNumber(n float64) string {
+	n = math.Round(n*1e10) / 1e10
+	if n == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
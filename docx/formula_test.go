@@ -0,0 +1,145 @@
+package docx
+
+import (
+	"testing"
+
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addFormula adds a formula field with a stale result to a cell.
+func addFormula(cell *Cell, code string) {
+	p := cell.AddEmptyPara()
+	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Run: createRunWithField(code, "0")})
+}
+
+func TestTable_UpdateFormulas(t *testing.T) {
+	rd := setupRootDoc(t)
+	tbl := rd.AddTable()
+
+	rows := [][]string{
+		{"Item", "Qty", "Price", "Amount"},
+		{"Pens", "2", "10.50", ""},
+		{"Paper", "3", "$4", ""},
+		{"Total", "", "", ""},
+	}
+	for _, values := range rows {
+		row := tbl.AddRow()
+		for _, value := range values {
+			row.AddCell().AddParagraph(value)
+		}
+	}
+	addFormula(tbl.Cell(1, 3), ` =B2*C2 \# "0.00" `)
+	addFormula(tbl.Cell(2, 3), `=B3*C3 \# "0.00"`)
+	addFormula(tbl.Cell(3, 3), `=SUM(ABOVE) \# "#,##0.00" \* MERGEFORMAT`)
+	addFormula(tbl.Cell(3, 1), `=SUM(B2:B3)`)
+
+	n, err := tbl.UpdateFormulas()
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []string{"21.00", "12.00", "33.00"}, []string{
+		tableCellText(tbl, 1, 3), tableCellText(tbl, 2, 3), tableCellText(tbl, 3, 3),
+	})
+	assert.Equal(t, "5", tableCellText(tbl, 3, 1))
+
+	// Results follow changes to the table
+	tbl.Cell(2, 1).GetCT().Contents[0].Paragraph.Children[0].Run.Children[0].Text.Text = "10"
+	n, err = rd.UpdateFormulas()
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, "40.00", tableCellText(tbl, 2, 3))
+	assert.Equal(t, "61.00", tableCellText(tbl, 3, 3))
+	assert.Equal(t, "12", tableCellText(tbl, 3, 1))
+}
+
+// tableCellText returns the text of the cell at row r and grid column c.
+func tableCellText(tbl *Table, r, c int) string {
+	return cellPlainText(tbl.Cell(r, c).GetCT(), "", false)
+}
+
+func TestTable_UpdateFormulas_Errors(t *testing.T) {
+	rd := setupRootDoc(t)
+	tbl := rd.AddTable()
+	row := tbl.AddRow()
+	addFormula(row.AddCell(), "=1/0")
+	addFormula(row.AddCell(), "=SUM(")
+	addFormula(row.AddCell(), "=2+2")
+
+	n, err := tbl.UpdateFormulas()
+	assert.Error(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "!Zero Divide", tableCellText(tbl, 0, 0))
+	assert.Equal(t, "!Syntax Error", tableCellText(tbl, 0, 1))
+	assert.Equal(t, "4", tableCellText(tbl, 0, 2))
+}
+
+func TestFormulaEval(t *testing.T) {
+	rd := setupRootDoc(t)
+	tbl := rd.AddTable()
+	values := [][]string{
+		{"1", "2", "3", "text"},
+		{"4", "(5)", "6", ""},
+		{"7", "8", "", "9"},
+	}
+	for _, rowValues := range values {
+		row := tbl.AddRow()
+		for _, value := range rowValues {
+			row.AddCell().AddParagraph(value)
+		}
+	}
+	grid := tbl.LogicalGrid()
+
+	tests := []struct {
+		formula  string
+		row, col int
+		expected string
+	}{
+		{"1+2*3", 0, 0, "7"},
+		{"(1+2)*3", 0, 0, "9"},
+		{"-2^2", 0, 0, "4"},
+		{"2^3^2", 0, 0, "64"},
+		{"50%*10", 0, 0, "5"},
+		{"10/4", 0, 0, "2.5"},
+		{"0.1+0.2", 0, 0, "0.3"},
+		{"A1+B2", 0, 0, "-4"},
+		{"a1 + b1", 0, 0, "3"},
+		{"SUM(A1:C2)", 0, 0, "11"},
+		{"SUM(C1:A2)", 0, 0, "11"},
+		{"AVERAGE(A1:A3)", 0, 0, "4"},
+		{"PRODUCT(A1:C1)", 0, 0, "6"},
+		{"MIN(A1:C2; 0)", 0, 0, "-5"},
+		{"MAX(A1:C3, 10)", 0, 0, "10"},
+		{"COUNT(A1:D3)", 0, 0, "9"},
+		{"IF(A1>0, 10, 20)", 0, 0, "10"},
+		{"IF(A1<>1, 10, 20)", 0, 0, "20"},
+		{"ABS(B2)+INT(7.9)+MOD(7,4)+ROUND(2.456,2)", 0, 0, "17.46"},
+		{"AND(1,0)+OR(1,0)+NOT(0)+SIGN(-3)", 0, 0, "1"},
+		{"SUM(ABOVE)", 2, 0, "5"},
+		{"SUM(ABOVE)", 2, 2, "9"},
+		{"SUM(ABOVE)", 2, 3, "0"},
+		{"SUM(LEFT)", 1, 3, "5"},
+		{"SUM(RIGHT)", 0, 0, "5"},
+		{"SUM(BELOW)", 0, 1, "3"},
+		{"SUM(LEFT,ABOVE)", 2, 2, "24"},
+		{`A1*1000 \# "$#,##0.00"`, 0, 0, "$1,000.00"},
+		{`B2 \# 0.0`, 0, 0, "-5.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			eval := &formulaEval{grid: grid, cell: grid[tt.row][tt.col]}
+			result, err := eval.evaluate(tt.formula)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	for _, formula := range []string{"", "1+", "SUM(A1:C2)+A1:B1", "FOO(1)", "E1", "A1:Z9", "IF(1,2)", "1 2", "1 # 2"} {
+		t.Run("Error "+formula, func(t *testing.T) {
+			eval := &formulaEval{grid: grid, cell: grid[0][0]}
+			_, err := eval.evaluate(formula)
+			assert.ErrorIs(t, err, errFormulaSyntax)
+		})
+	}
+}
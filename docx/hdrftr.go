@@ -0,0 +1,38 @@
+package docx
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/bfoley13/godocx/wml/ctypes"
+)
+
+// HeaderFooter decodes the header or footer part referenced by a relationship ID, as found
+// in the header and footer references of a section.
+//
+// Parameters:
+//   - rID: The relationship ID of the header or footer part.
+//
+// Returns:
+//   - *ctypes.HdrFtr: The decoded header or footer content.
+//   - error: An error if the relationship or the part does not exist or cannot be decoded.
+func (rd *RootDoc) HeaderFooter(rID string) (*ctypes.HdrFtr, error) {
+	rel := rd.Document.GetRelationByID(rID)
+	if rel == nil {
+		return nil, fmt.Errorf("relationship %s not found", rID)
+	}
+
+	partName := rd.partPath(rel.Target)
+	content, ok := rd.FileMap.Load(partName)
+	if !ok {
+		return nil, fmt.Errorf("part %s not found", partName)
+	}
+
+	hdrFtr := &ctypes.HdrFtr{}
+	if err := xml.Unmarshal(content.([]byte), hdrFtr); err != nil {
+		return nil, err
+	}
+	hdrFtr.RelativePath = partName
+
+	return hdrFtr, nil
+}
@@ -1,6 +1,11 @@
 package docx
 
 import (
+	"path/filepath"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/common/units"
+	"github.com/bfoley13/godocx/internal"
 	"github.com/bfoley13/godocx/wml/ctypes"
 	"github.com/bfoley13/godocx/wml/stypes"
 )
@@ -8,18 +13,86 @@ import (
 type Hyperlink struct {
 	root *RootDoc          // root is the root document to which this hyperlink belongs.
 	ct   *ctypes.Hyperlink // ct is the underlying hyperlink element from the wml/ctypes package.
+
+	owner *Paragraph        // owner is the paragraph the hyperlink was added to, nil for listed hyperlinks.
+	para  *ctypes.Paragraph // para is the paragraph holding the hyperlink, once located.
+	part  *linkPart         // part is the document part holding the hyperlink, once located.
 }
 
 func newHyperlink(root *RootDoc, ct *ctypes.Hyperlink) *Hyperlink {
 	return &Hyperlink{root: root, ct: ct}
 }
 
-// getProp returns the hyperlink properties. If not initialized, it creates and returns a new instance.
-func (r *Hyperlink) getProp() *ctypes.RunProperty {
-	if r.ct.Run.Property == nil {
-		r.ct.Run.Property = &ctypes.RunProperty{}
+// Tooltip sets the text shown when the pointer rests on the hyperlink.
+func (r *Hyperlink) Tooltip(text string) *Hyperlink {
+	r.ct.Tooltip = text
+	return r
+}
+
+// History sets whether the target of the hyperlink is added to the list of viewed
+// hyperlinks when it is followed.
+func (r *Hyperlink) History(value bool) *Hyperlink {
+	r.ct.History = ctypes.OnOffFromBool(value)
+	return r
+}
+
+// AddText appends a run of text in the hyperlink character style to the content of the
+// hyperlink. The run can be formatted on its own, unlike the formatting methods of Hyperlink,
+// which apply to every run of the hyperlink.
+//
+// Example:
+//
+//	link := para.AddLink("Read ", "https://example.com")
+//	link.AddText("the docs").Bold(true)
+func (r *Hyperlink) AddText(text string) *Run {
+	run := &ctypes.Run{
+		Children: []ctypes.RunChild{{Text: ctypes.TextFromString(text)}},
+		Property: &ctypes.RunProperty{Style: ctypes.NewRunStyle(constants.HyperLinkStyle)},
+	}
+	r.ct.Children = append(r.ct.Children, ctypes.ParagraphChild{Run: run})
+
+	return newRun(r.root, run)
+}
+
+// AddPicture appends an image from a file to the content of the hyperlink, which makes the
+// picture a link. See Paragraph.AddPicture.
+func (r *Hyperlink) AddPicture(path string, width units.Inch, height units.Inch) (*PicMeta, error) {
+	imgBytes, err := internal.FileToByte(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.AddPictureBytes(imgBytes, filepath.Ext(path), width, height)
+}
+
+// AddPictureBytes appends an image held in memory to the content of the hyperlink. See
+// Paragraph.AddPictureBytes. The Para of the returned picture is nil for a hyperlink returned by
+// Hyperlinks.
+func (r *Hyperlink) AddPictureBytes(imgBytes []byte, imgExt string, width units.Inch, height units.Inch) (*PicMeta, error) {
+	rID, err := r.root.addImagePart(imgBytes, imgExt)
+	if err != nil {
+		return nil, err
+	}
+
+	run, inline := newDrawingRun(rID, r.root.ImageCount, width.ToEmu(), height.ToEmu())
+	r.ct.Children = append(r.ct.Children, ctypes.ParagraphChild{Run: run})
+
+	return &PicMeta{
+		Para:   r.owner,
+		Inline: inline,
+	}, nil
+}
+
+// format applies a formatting change to the properties of every run of the hyperlink, creating
+// them if needed.
+func (r *Hyperlink) format(set func(prop *ctypes.RunProperty)) *Hyperlink {
+	for _, run := range linkRuns(r.ct) {
+		if run.Property == nil {
+			run.Property = &ctypes.RunProperty{}
+		}
+		set(run.Property)
 	}
-	return r.ct.Run.Property
+	return r
 }
 
 // Sets the color of the Hyperlink.
@@ -34,8 +107,9 @@ func (r *Hyperlink) getProp() *ctypes.RunProperty {
 // Returns:
 //   - *Hyperlink: The modified Hyperlink instance with the updated color.
 func (r *Hyperlink) Color(colorCode string) *Hyperlink {
-	r.getProp().Color = ctypes.NewColor(colorCode)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Color = ctypes.NewColor(colorCode)
+	})
 }
 
 // Sets the size of the Hyperlink.
@@ -52,128 +126,148 @@ func (r *Hyperlink) Color(colorCode string) *Hyperlink {
 // Returns:
 //   - *Hyperlink: The modified Hyperlink instance with the updated size.
 func (r *Hyperlink) Size(size uint64) *Hyperlink {
-	r.getProp().Size = ctypes.NewFontSize(size * 2)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Size = ctypes.NewFontSize(size * 2)
+	})
 }
 
 // Font sets the font for the hyperlink.
 func (r *Hyperlink) Font(font string) *Hyperlink {
-	if r.getProp().Fonts == nil {
-		r.getProp().Fonts = &ctypes.RunFonts{}
-	}
+	return r.format(func(prop *ctypes.RunProperty) {
+		if prop.Fonts == nil {
+			prop.Fonts = &ctypes.RunFonts{}
+		}
 
-	r.getProp().Fonts.Ascii = font
-	r.getProp().Fonts.HAnsi = font
-	return r
+		prop.Fonts.Ascii = font
+		prop.Fonts.HAnsi = font
+	})
 }
 
 // Shading sets the shading properties (type, color, fill) for the hyperlink
 func (r *Hyperlink) Shading(shdType stypes.Shading, color, fill string) *Hyperlink {
-	r.getProp().Shading = ctypes.NewShading().SetShadingType(shdType).SetColor(color).SetFill(fill)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Shading = ctypes.NewShading().SetShadingType(shdType).SetColor(color).SetFill(fill)
+	})
 }
 
 // AddHighlight sets the highlight color for the hyperlink.
 func (r *Hyperlink) Highlight(color string) *Hyperlink {
-	r.getProp().Highlight = ctypes.NewCTString(color)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Highlight = ctypes.NewCTString(color)
+	})
 }
 
 // AddBold enables bold formatting for the hyperlink.
 func (r *Hyperlink) Bold(value bool) *Hyperlink {
-	r.getProp().Bold = ctypes.OnOffFromBool(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Bold = ctypes.OnOffFromBool(value)
+	})
 }
 
 // Italic enables or disables italic formatting for the hyperlink.
 func (r *Hyperlink) Italic(value bool) *Hyperlink {
-	r.getProp().Italic = ctypes.OnOffFromBool(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Italic = ctypes.OnOffFromBool(value)
+	})
 }
 
 // Specifies that the contents of this hyperlink shall be displayed with a single horizontal line through the center of the line.
 func (r *Hyperlink) Strike(value bool) *Hyperlink {
-	r.getProp().Strike = ctypes.OnOffFromBool(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Strike = ctypes.OnOffFromBool(value)
+	})
 }
 
 // Specifies that the contents of this hyperlink shall be displayed with two horizontal lines through each character displayed on the line
 func (r *Hyperlink) DoubleStrike(value bool) *Hyperlink {
-	r.getProp().DoubleStrike = ctypes.OnOffFromBool(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.DoubleStrike = ctypes.OnOffFromBool(value)
+	})
 }
 
 // Display All Characters As Capital Letters
 // Any lowercase characters in this text hyperlink shall be formatted for display only as their capital letter character equivalents
 func (r *Hyperlink) Caps(value bool) *Hyperlink {
-	r.getProp().Caps = ctypes.OnOffFromBool(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Caps = ctypes.OnOffFromBool(value)
+	})
 }
 
 // Specifies that all small letter characters in this text hyperlink shall be formatted for display only as their capital letter character equivalents
 func (r *Hyperlink) SmallCaps(value bool) *Hyperlink {
-	r.getProp().Caps = ctypes.OnOffFromBool(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Caps = ctypes.OnOffFromBool(value)
+	})
 }
 
 // Outline enables or disables outline formatting for the hyperlink.
 func (r *Hyperlink) Outline(value bool) *Hyperlink {
-	r.getProp().Outline = ctypes.OnOffFromBool(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Outline = ctypes.OnOffFromBool(value)
+	})
 }
 
 // Shadow enables or disables shadow formatting for the hyperlink.
 func (r *Hyperlink) Shadow(value bool) *Hyperlink {
-	r.getProp().Shadow = ctypes.OnOffFromBool(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Shadow = ctypes.OnOffFromBool(value)
+	})
 }
 
 // Emboss enables or disables embossing formatting for the hyperlink.
 func (r *Hyperlink) Emboss(value bool) *Hyperlink {
-	r.getProp().Emboss = ctypes.OnOffFromBool(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Emboss = ctypes.OnOffFromBool(value)
+	})
 }
 
 // Imprint enables or disables imprint formatting for the hyperlink.
 func (r *Hyperlink) Imprint(value bool) *Hyperlink {
-	r.getProp().Imprint = ctypes.OnOffFromBool(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Imprint = ctypes.OnOffFromBool(value)
+	})
 }
 
 // Do Not Check Spelling or Grammar
 func (r *Hyperlink) NoGrammer(value bool) *Hyperlink {
-	r.getProp().NoGrammar = ctypes.OnOffFromBool(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.NoGrammar = ctypes.OnOffFromBool(value)
+	})
 }
 
 // Use Document Grid Settings For Inter-Character Spacing
 func (r *Hyperlink) SnapToGrid(value bool) *Hyperlink {
-	r.getProp().SnapToGrid = ctypes.OnOffFromBool(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.SnapToGrid = ctypes.OnOffFromBool(value)
+	})
 }
 
 // Hidden Text
 func (r *Hyperlink) HideText(value bool) *Hyperlink {
-	r.getProp().Vanish = ctypes.OnOffFromBool(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Vanish = ctypes.OnOffFromBool(value)
+	})
 }
 
 // Spacing sets the spacing between characters in the hyperlink.
 func (r *Hyperlink) Spacing(value int) *Hyperlink {
-	r.getProp().Spacing = ctypes.NewDecimalNum(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Spacing = ctypes.NewDecimalNum(value)
+	})
 }
 
 // Underline sets the underline style for the hyperlink.
 func (r *Hyperlink) Underline(value stypes.Underline) *Hyperlink {
-	r.getProp().Underline = ctypes.NewGenSingleStrVal(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Underline = ctypes.NewGenSingleStrVal(value)
+	})
 }
 
 // Style sets the style of the Hyperlink.
 func (r *Hyperlink) Style(value string) *Hyperlink {
-	r.getProp().Style = ctypes.NewRunStyle(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.Style = ctypes.NewRunStyle(value)
+	})
 }
 
 // VerticalAlign sets the vertical alignment for the hyperlink text.
@@ -184,6 +278,7 @@ func (r *Hyperlink) Style(value string) *Hyperlink {
 //
 // Returns: The modified Hyperlink instance with the updated vertical alignment.
 func (r *Hyperlink) VerticalAlign(value stypes.VerticalAlignRun) *Hyperlink {
-	r.getProp().VertAlign = ctypes.NewGenSingleStrVal(value)
-	return r
+	return r.format(func(prop *ctypes.RunProperty) {
+		prop.VertAlign = ctypes.NewGenSingleStrVal(value)
+	})
 }
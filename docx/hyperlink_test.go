@@ -0,0 +1,102 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHyperlink_AddText(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddEmptyParagraph()
+	link := p.AddLink("Read ", "https://example.com")
+	link.AddText("the docs").Bold(true)
+	link.Color("0000FF")
+
+	require.Len(t, link.ct.Children, 2)
+	assert.Nil(t, link.ct.Run)
+	assert.Equal(t, "Read the docs", link.Text())
+
+	first, second := link.ct.Children[0].Run, link.ct.Children[1].Run
+	assert.Nil(t, first.Property.Bold)
+	assert.True(t, second.Property.Bold.Bool())
+	for _, run := range []*ctypes.Run{first, second} {
+		assert.Equal(t, constants.HyperLinkStyle, run.Property.Style.Val)
+		assert.Equal(t, "0000FF", run.Property.Color.Val)
+	}
+
+	output, err := xml.Marshal(p.ct)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), `<w:hyperlink r:id="rId1"><w:r><w:rPr><w:rStyle w:val="Hyperlink"></w:rStyle>`+
+		`<w:color w:val="0000FF"></w:color></w:rPr><w:t xml:space="preserve">Read </w:t></w:r><w:r><w:rPr>`+
+		`<w:rStyle w:val="Hyperlink"></w:rStyle><w:b w:val="true"></w:b><w:color w:val="0000FF"></w:color></w:rPr><w:t>the docs</w:t>`)
+}
+
+func TestHyperlink_AddPictureBytes(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddEmptyParagraph()
+	link := p.AddLink("Logo", "https://example.com")
+
+	pic, err := link.AddPictureBytes([]byte("png"), ".png", 1, 1)
+	require.NoError(t, err)
+	assert.Same(t, p, pic.Para)
+
+	require.Len(t, link.ct.Children, 2)
+	drawing := link.ct.Children[1].Run.Children[0].Drawing
+	require.NotNil(t, drawing)
+	assert.Same(t, pic.Inline, &drawing.Inline[0])
+	assert.Len(t, rd.Document.DocRels.Relationships, 2)
+	content, ok := rd.ReadPart("media/image2.png")
+	require.True(t, ok)
+	assert.Equal(t, []byte("png"), content)
+
+	_, err = link.AddPictureBytes([]byte("bin"), ".unknown", 1, 1)
+	assert.Error(t, err)
+}
+
+func TestHyperlink_LoadedContent(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.AddParagraph("Overview").AddBookmark("overview")
+
+	var para ctypes.Paragraph
+	require.NoError(t, xml.Unmarshal([]byte(`<w:p `+mergeNS+`><w:hyperlink w:anchor="overview">`+
+		`<w:r><w:t>See the </w:t></w:r><w:r><w:rPr><w:i/></w:rPr><w:t>old section</w:t></w:r>`+
+		`<w:r><w:t xml:space="preserve">: </w:t></w:r>`+
+		`<w:r><w:fldChar w:fldCharType="begin"/></w:r><w:r><w:instrText> REF overview </w:instrText></w:r>`+
+		`<w:r><w:fldChar w:fldCharType="separate"/></w:r><w:r><w:t>stale</w:t></w:r><w:r><w:fldChar w:fldCharType="end"/></w:r>`+
+		`</w:hyperlink></w:p>`), &para))
+	p := newParagraph(rd)
+	p.ct = para
+	rd.Document.Body.Children = append(rd.Document.Body.Children, DocumentChild{Para: p})
+
+	links, err := rd.Hyperlinks()
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	link := links[0]
+	require.Len(t, link.ct.Children, 8)
+
+	// Every run is found when replacing text, updating fields and formatting
+	assert.Equal(t, 1, rd.ReplaceAll("old", "new"))
+	updated, err := rd.UpdateFields(FieldContext{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, "See the new section: Overview", link.Text())
+
+	link.Underline("single")
+	for _, child := range link.ct.Children {
+		assert.Equal(t, "single", string(child.Run.Property.Underline.Val))
+	}
+	assert.True(t, link.ct.Children[1].Run.Property.Italic.Bool())
+
+	output, err := xml.Marshal(p.ct)
+	require.NoError(t, err)
+	var loaded ctypes.Paragraph
+	require.NoError(t, xml.Unmarshal(output, &loaded))
+	require.Len(t, loaded.Children, 1)
+	assert.Len(t, loaded.Children[0].Link.Children, 8)
+	assert.Equal(t, "See the new section: Overview", paragraphPlainText(&loaded))
+}
@@ -0,0 +1,377 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/bfoley13/godocx/common/units"
+)
+
+// defaultImageDPI is the resolution assumed for images that do not record one.
+const defaultImageDPI = 96
+
+// ImageInfo describes the format, pixel dimensions and resolution of an image, read from its
+// header.
+type ImageInfo struct {
+	// Format is the file extension of the image format: "png", "jpeg", "gif", "bmp" or "tiff".
+	Format string
+
+	// The dimensions of the image in pixels.
+	Width  int
+	Height int
+
+	// The horizontal and vertical resolutions of the image in dots per inch. They are zero
+	// when the image does not record its resolution.
+	DPIX float64
+	DPIY float64
+}
+
+// Size returns the natural size of the image: its pixel dimensions at its resolution, or at
+// 96 DPI when it does not record one.
+func (info ImageInfo) Size() (width, height units.Emu) {
+	return pixelsToEmu(info.Width, info.DPIX), pixelsToEmu(info.Height, info.DPIY)
+}
+
+// aspectRatio returns the height of the image divided by its width, at its resolution.
+func (info ImageInfo) aspectRatio() float64 {
+	return pixelsToInches(info.Height, info.DPIY) / pixelsToInches(info.Width, info.DPIX)
+}
+
+// pixelsToEmu returns the length of a number of pixels at a resolution, or at 96 DPI when the
+// resolution is zero.
+func pixelsToEmu(pixels int, dpi float64) units.Emu {
+	return units.Inch(pixelsToInches(pixels, dpi)).ToEmu()
+}
+
+// pixelsToInches is pixelsToEmu in inches.
+func pixelsToInches(pixels int, dpi float64) float64 {
+	if dpi <= 0 {
+		dpi = defaultImageDPI
+	}
+	return float64(pixels) / dpi
+}
+
+// ReadImageInfo detects the format of an image from its magic bytes and reads its pixel
+// dimensions and resolution. PNG, JPEG, GIF, BMP and TIFF images are supported; the
+// resolution is read from the PNG pHYs chunk, the JPEG JFIF or EXIF header, the BMP info
+// header and the TIFF resolution tags.
+//
+// Returns:
+//   - ImageInfo: The format, dimensions and resolution of the image.
+//   - error: An error if the format is not recognized or the header is truncated.
+func ReadImageInfo(data []byte) (ImageInfo, error) {
+	var (
+		info ImageInfo
+		err  error
+	)
+
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		info, err = readPNGInfo(data)
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8}):
+		info, err = readJPEGInfo(data)
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		info, err = readGIFInfo(data)
+	case bytes.HasPrefix(data, []byte("BM")):
+		info, err = readBMPInfo(data)
+	case bytes.HasPrefix(data, []byte("II*\x00")), bytes.HasPrefix(data, []byte("MM\x00*")):
+		info, err = readTIFFInfo(data)
+	default:
+		return ImageInfo{}, errors.New("unrecognized image format")
+	}
+	if err != nil {
+		return ImageInfo{}, err
+	}
+
+	if info.Width <= 0 || info.Height <= 0 {
+		return ImageInfo{}, errors.New("image has no dimensions")
+	}
+	return info, nil
+}
+
+var errImageTruncated = errors.New("image header is truncated")
+
+// readPNGInfo reads the IHDR chunk and the pHYs chunk of a PNG image.
+func readPNGInfo(data []byte) (ImageInfo, error) {
+	info := ImageInfo{Format: "png"}
+
+	// The chunks follow the 8 byte signature, each with a 4 byte length, a 4 byte type, its
+	// data and a 4 byte CRC
+	for pos := 8; pos+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		kind := string(data[pos+4 : pos+8])
+		start, end := pos+8, pos+8+length
+		if length < 0 || end > len(data) {
+			return ImageInfo{}, errImageTruncated
+		}
+		chunk := data[start:end]
+
+		switch kind {
+		case "IHDR":
+			if len(chunk) < 8 {
+				return ImageInfo{}, errImageTruncated
+			}
+			info.Width = int(binary.BigEndian.Uint32(chunk))
+			info.Height = int(binary.BigEndian.Uint32(chunk[4:]))
+		case "pHYs":
+			// Pixels per unit on each axis, and a unit that is the meter when it is 1
+			if len(chunk) >= 9 && chunk[8] == 1 {
+				info.DPIX = float64(binary.BigEndian.Uint32(chunk)) * 0.0254
+				info.DPIY = float64(binary.BigEndian.Uint32(chunk[4:])) * 0.0254
+			}
+		case "IDAT", "IEND":
+			return info, nil
+		}
+
+		pos = end + 4
+	}
+
+	if info.Width == 0 {
+		return ImageInfo{}, errImageTruncated
+	}
+	return info, nil
+}
+
+// readJPEGInfo reads the frame header and the JFIF or EXIF resolution of a JPEG image. The
+// JFIF density is used when it has a unit, the EXIF resolution otherwise.
+func readJPEGInfo(data []byte) (ImageInfo, error) {
+	info := ImageInfo{Format: "jpeg"}
+	var exifX, exifY float64
+
+	for pos := 2; pos+4 <= len(data); {
+		if data[pos] != 0xFF {
+			return ImageInfo{}, errors.New("invalid JPEG marker")
+		}
+		marker := data[pos+1]
+		if marker == 0xFF {
+			// Fill byte before a marker
+			pos++
+			continue
+		}
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			// Markers without a segment
+			pos += 2
+			continue
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		start, end := pos+4, pos+2+length
+		if length < 2 || end > len(data) {
+			return ImageInfo{}, errImageTruncated
+		}
+		segment := data[start:end]
+
+		switch {
+		case marker == 0xE0 && bytes.HasPrefix(segment, []byte("JFIF\x00")) && len(segment) >= 12:
+			// Version, unit, then the horizontal and vertical densities
+			unit := segment[7]
+			x := float64(binary.BigEndian.Uint16(segment[8:]))
+			y := float64(binary.BigEndian.Uint16(segment[10:]))
+			switch unit {
+			case 1:
+				info.DPIX, info.DPIY = x, y
+			case 2:
+				info.DPIX, info.DPIY = x*2.54, y*2.54
+			}
+		case marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")):
+			if tiff, err := readTIFFTags(segment[6:]); err == nil {
+				exifX, exifY = tiff.dpi()
+			}
+		case isJPEGFrameMarker(marker):
+			// Precision, then the height and width of the frame
+			if len(segment) < 5 {
+				return ImageInfo{}, errImageTruncated
+			}
+			info.Height = int(binary.BigEndian.Uint16(segment[1:]))
+			info.Width = int(binary.BigEndian.Uint16(segment[3:]))
+			if info.DPIX == 0 {
+				info.DPIX, info.DPIY = exifX, exifY
+			}
+			return info, nil
+		case marker == 0xDA || marker == 0xD9:
+			// Start of scan or end of image before a frame header
+			return ImageInfo{}, errImageTruncated
+		}
+
+		pos = end
+	}
+
+	return ImageInfo{}, errImageTruncated
+}
+
+// isJPEGFrameMarker reports whether a marker starts a frame header (SOF0 to SOF15), whose
+// codes are shared with DHT, JPG and DAC.
+func isJPEGFrameMarker(marker byte) bool {
+	return marker >= 0xC0 && marker <= 0xCF && marker != 0xC4 && marker != 0xC8 && marker != 0xCC
+}
+
+// readGIFInfo reads the logical screen size of a GIF image, which has no resolution.
+func readGIFInfo(data []byte) (ImageInfo, error) {
+	if len(data) < 10 {
+		return ImageInfo{}, errImageTruncated
+	}
+	return ImageInfo{
+		Format: "gif",
+		Width:  int(binary.LittleEndian.Uint16(data[6:])),
+		Height: int(binary.LittleEndian.Uint16(data[8:])),
+	}, nil
+}
+
+// readBMPInfo reads the info header of a BMP image, or the core header of OS/2 bitmaps, which
+// have no resolution.
+func readBMPInfo(data []byte) (ImageInfo, error) {
+	if len(data) < 18 {
+		return ImageInfo{}, errImageTruncated
+	}
+
+	info := ImageInfo{Format: "bmp"}
+	headerSize := binary.LittleEndian.Uint32(data[14:])
+	if headerSize == 12 {
+		if len(data) < 22 {
+			return ImageInfo{}, errImageTruncated
+		}
+		info.Width = int(binary.LittleEndian.Uint16(data[18:]))
+		info.Height = int(binary.LittleEndian.Uint16(data[20:]))
+		return info, nil
+	}
+
+	if len(data) < 46 {
+		return ImageInfo{}, errImageTruncated
+	}
+	info.Width = int(int32(binary.LittleEndian.Uint32(data[18:])))
+	info.Height = int(int32(binary.LittleEndian.Uint32(data[22:])))
+	if info.Height < 0 {
+		// Top-down bitmap
+		info.Height = -info.Height
+	}
+
+	// Pixels per meter on each axis
+	x := int32(binary.LittleEndian.Uint32(data[38:]))
+	y := int32(binary.LittleEndian.Uint32(data[42:]))
+	if x > 0 && y > 0 {
+		info.DPIX, info.DPIY = float64(x)*0.0254, float64(y)*0.0254
+	}
+	return info, nil
+}
+
+// readTIFFInfo reads the dimensions and resolution tags of the first image of a TIFF file.
+func readTIFFInfo(data []byte) (ImageInfo, error) {
+	tags, err := readTIFFTags(data)
+	if err != nil {
+		return ImageInfo{}, err
+	}
+
+	info := ImageInfo{Format: "tiff", Width: tags.width, Height: tags.height}
+	info.DPIX, info.DPIY = tags.dpi()
+	return info, nil
+}
+
+// tiffTags holds the tags of the first image file directory of a TIFF structure, which is
+// also the layout of EXIF data.
+type tiffTags struct {
+	width, height int
+	xRes, yRes    float64
+	// resUnit is 2 for inches and 3 for centimeters
+	resUnit int
+}
+
+// dpi returns the resolution in dots per inch, or zeros when there is none.
+func (t tiffTags) dpi() (float64, float64) {
+	switch t.resUnit {
+	case 2:
+		return t.xRes, t.yRes
+	case 3:
+		return t.xRes * 2.54, t.yRes * 2.54
+	default:
+		return 0, 0
+	}
+}
+
+// TIFF tags and field types read by readTIFFTags.
+const (
+	tiffTagImageWidth     = 0x0100
+	tiffTagImageLength    = 0x0101
+	tiffTagXResolution    = 0x011A
+	tiffTagYResolution    = 0x011B
+	tiffTagResolutionUnit = 0x0128
+
+	tiffTypeShort    = 3
+	tiffTypeLong     = 4
+	tiffTypeRational = 5
+)
+
+// readTIFFTags reads the dimension and resolution tags of the first image file directory of
+// a TIFF structure.
+func readTIFFTags(data []byte) (tiffTags, error) {
+	if len(data) < 8 {
+		return tiffTags{}, errImageTruncated
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return tiffTags{}, errors.New("invalid TIFF byte order")
+	}
+
+	// The resolution unit defaults to inches
+	tags := tiffTags{resUnit: 2}
+
+	ifd := int(order.Uint32(data[4:]))
+	if ifd < 8 || ifd+2 > len(data) {
+		return tiffTags{}, errImageTruncated
+	}
+	count := int(order.Uint16(data[ifd:]))
+
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(data) {
+			return tiffTags{}, errImageTruncated
+		}
+		tag := order.Uint16(data[entry:])
+		kind := order.Uint16(data[entry+2:])
+		value := data[entry+8 : entry+12]
+
+		switch tag {
+		case tiffTagImageWidth, tiffTagImageLength, tiffTagResolutionUnit:
+			var v int
+			switch kind {
+			case tiffTypeShort:
+				v = int(order.Uint16(value))
+			case tiffTypeLong:
+				v = int(order.Uint32(value))
+			default:
+				continue
+			}
+			switch tag {
+			case tiffTagImageWidth:
+				tags.width = v
+			case tiffTagImageLength:
+				tags.height = v
+			default:
+				tags.resUnit = v
+			}
+		case tiffTagXResolution, tiffTagYResolution:
+			// Rationals do not fit in the entry, which holds their offset
+			offset := int(order.Uint32(value))
+			if kind != tiffTypeRational || offset < 0 || offset+8 > len(data) {
+				continue
+			}
+			num, den := order.Uint32(data[offset:]), order.Uint32(data[offset+4:])
+			if den == 0 {
+				continue
+			}
+			if tag == tiffTagXResolution {
+				tags.xRes = float64(num) / float64(den)
+			} else {
+				tags.yRes = float64(num) / float64(den)
+			}
+		}
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,193 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPNG returns a PNG image with a pHYs chunk of the given pixels per meter, unless it is 0.
+func testPNG(t *testing.T, width, height int, ppm uint32) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))))
+	data := buf.Bytes()
+	if ppm == 0 {
+		return data
+	}
+
+	chunk := make([]byte, 21)
+	binary.BigEndian.PutUint32(chunk, 9)
+	copy(chunk[4:], "pHYs")
+	binary.BigEndian.PutUint32(chunk[8:], ppm)
+	binary.BigEndian.PutUint32(chunk[12:], ppm)
+	chunk[16] = 1
+	binary.BigEndian.PutUint32(chunk[17:], crc32.ChecksumIEEE(chunk[4:17]))
+
+	// The signature and the IHDR chunk take 33 bytes
+	return append(append(append([]byte{}, data[:33]...), chunk...), data[33:]...)
+}
+
+// testJPEG returns a JPEG image with the given segments after its SOI marker.
+func testJPEG(t *testing.T, width, height int, segments ...[]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil))
+	data := buf.Bytes()
+
+	out := append([]byte{}, data[:2]...)
+	for _, segment := range segments {
+		out = append(out, segment...)
+	}
+	return append(out, data[2:]...)
+}
+
+// jpegSegment returns a JPEG segment with the given marker and content.
+func jpegSegment(marker byte, content []byte) []byte {
+	segment := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(content)+2))
+	return append(segment, content...)
+}
+
+// testTIFF returns a TIFF structure with the dimension and resolution tags.
+func testTIFF(order binary.ByteOrder, width, height, xRes, yRes uint32, unit uint16) []byte {
+	data := make([]byte, 8, 90)
+	if order == binary.LittleEndian {
+		copy(data, "II")
+	} else {
+		copy(data, "MM")
+	}
+	order.PutUint16(data[2:], 42)
+	order.PutUint32(data[4:], 8)
+
+	entry := func(tag, kind uint16, value uint32) {
+		b := make([]byte, 12)
+		order.PutUint16(b, tag)
+		order.PutUint16(b[2:], kind)
+		order.PutUint32(b[4:], 1)
+		if kind == tiffTypeShort {
+			order.PutUint16(b[8:], uint16(value))
+		} else {
+			order.PutUint32(b[8:], value)
+		}
+		data = append(data, b...)
+	}
+
+	// Five entries and the next directory offset, followed by the two rationals
+	data = append(data, 0, 0)
+	order.PutUint16(data[8:], 5)
+	entry(tiffTagImageWidth, tiffTypeLong, width)
+	entry(tiffTagImageLength, tiffTypeShort, height)
+	entry(tiffTagXResolution, tiffTypeRational, 74)
+	entry(tiffTagYResolution, tiffTypeRational, 82)
+	entry(tiffTagResolutionUnit, tiffTypeShort, uint32(unit))
+	data = append(data, 0, 0, 0, 0)
+
+	rational := make([]byte, 16)
+	order.PutUint32(rational, xRes)
+	order.PutUint32(rational[4:], 1)
+	order.PutUint32(rational[8:], yRes)
+	order.PutUint32(rational[12:], 1)
+	return append(data, rational...)
+}
+
+// testBMP returns the headers of a BMP image with the given pixels per meter.
+func testBMP(width, height int32, ppm int32) []byte {
+	data := make([]byte, 54)
+	copy(data, "BM")
+	binary.LittleEndian.PutUint32(data[14:], 40)
+	binary.LittleEndian.PutUint32(data[18:], uint32(width))
+	binary.LittleEndian.PutUint32(data[22:], uint32(height))
+	binary.LittleEndian.PutUint32(data[38:], uint32(ppm))
+	binary.LittleEndian.PutUint32(data[42:], uint32(ppm))
+	return data
+}
+
+func TestReadImageInfo(t *testing.T) {
+	var gifBuf bytes.Buffer
+	require.NoError(t, gif.Encode(&gifBuf, image.NewGray(image.Rect(0, 0, 30, 20)), nil))
+
+	jfif := jpegSegment(0xE0, []byte{'J', 'F', 'I', 'F', 0, 1, 1, 1, 0, 150, 0, 150, 0, 0})
+	jfifCm := jpegSegment(0xE0, []byte{'J', 'F', 'I', 'F', 0, 1, 1, 2, 0, 100, 0, 100, 0, 0})
+	jfifAspect := jpegSegment(0xE0, []byte{'J', 'F', 'I', 'F', 0, 1, 1, 0, 0, 1, 0, 1, 0, 0})
+	exif := jpegSegment(0xE1, append([]byte("Exif\x00\x00"), testTIFF(binary.BigEndian, 0, 0, 300, 300, 2)...))
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected ImageInfo
+	}{
+		{"PNG", testPNG(t, 40, 20, 0), ImageInfo{Format: "png", Width: 40, Height: 20}},
+		{"PNG pHYs", testPNG(t, 40, 20, 11811), ImageInfo{Format: "png", Width: 40, Height: 20, DPIX: 299.9994, DPIY: 299.9994}},
+		{"JPEG", testJPEG(t, 64, 48), ImageInfo{Format: "jpeg", Width: 64, Height: 48}},
+		{"JPEG JFIF", testJPEG(t, 64, 48, jfif), ImageInfo{Format: "jpeg", Width: 64, Height: 48, DPIX: 150, DPIY: 150}},
+		{"JPEG JFIF per cm", testJPEG(t, 64, 48, jfifCm), ImageInfo{Format: "jpeg", Width: 64, Height: 48, DPIX: 254, DPIY: 254}},
+		{"JPEG EXIF", testJPEG(t, 64, 48, exif), ImageInfo{Format: "jpeg", Width: 64, Height: 48, DPIX: 300, DPIY: 300}},
+		{"JPEG JFIF without unit and EXIF", testJPEG(t, 64, 48, jfifAspect, exif), ImageInfo{Format: "jpeg", Width: 64, Height: 48, DPIX: 300, DPIY: 300}},
+		{"GIF", gifBuf.Bytes(), ImageInfo{Format: "gif", Width: 30, Height: 20}},
+		{"BMP", testBMP(16, -8, 3780), ImageInfo{Format: "bmp", Width: 16, Height: 8, DPIX: 96.012, DPIY: 96.012}},
+		{"BMP without resolution", testBMP(16, 8, 0), ImageInfo{Format: "bmp", Width: 16, Height: 8}},
+		{"TIFF little endian", testTIFF(binary.LittleEndian, 300, 200, 72, 144, 2), ImageInfo{Format: "tiff", Width: 300, Height: 200, DPIX: 72, DPIY: 144}},
+		{"TIFF big endian per cm", testTIFF(binary.BigEndian, 300, 200, 100, 100, 3), ImageInfo{Format: "tiff", Width: 300, Height: 200, DPIX: 254, DPIY: 254}},
+		{"TIFF without unit", testTIFF(binary.BigEndian, 300, 200, 100, 100, 1), ImageInfo{Format: "tiff", Width: 300, Height: 200}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := ReadImageInfo(tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected.Format, info.Format)
+			assert.Equal(t, tt.expected.Width, info.Width)
+			assert.Equal(t, tt.expected.Height, info.Height)
+			assert.InDelta(t, tt.expected.DPIX, info.DPIX, 0.001)
+			assert.InDelta(t, tt.expected.DPIY, info.DPIY, 0.001)
+		})
+	}
+}
+
+func TestReadImageInfo_Errors(t *testing.T) {
+	png := testPNG(t, 40, 20, 0)
+	jpg := testJPEG(t, 64, 48)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"Empty", nil},
+		{"Unknown format", []byte("%PDF-1.7")},
+		{"Truncated PNG", png[:20]},
+		{"Truncated JPEG", jpg[:6]},
+		{"Truncated GIF", []byte("GIF89a\x01")},
+		{"Truncated BMP", testBMP(16, 8, 0)[:30]},
+		{"Truncated TIFF", testTIFF(binary.LittleEndian, 300, 200, 72, 72, 2)[:20]},
+		{"No dimensions", testBMP(0, 8, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadImageInfo(tt.data)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestImageInfo_Size(t *testing.T) {
+	width, height := ImageInfo{Width: 192, Height: 96}.Size()
+	assert.Equal(t, units.Inch(2).ToEmu(), width)
+	assert.Equal(t, units.Inch(1).ToEmu(), height)
+
+	width, height = ImageInfo{Width: 300, Height: 150, DPIX: 300, DPIY: 150}.Size()
+	assert.Equal(t, units.Inch(1).ToEmu(), width)
+	assert.Equal(t, units.Inch(1).ToEmu(), height)
+}
@@ -16,7 +16,13 @@ import (
 //
 // This function generates a new relationship ID, creates a Relationship object with the specified link as the target,
 // and appends it to the document's relationships collection (DocRels.Relationships). It returns the generated ID of the relationship.
+// An existing external hyperlink relationship to the same link is reused instead.
 func (doc *Document) addLinkRelation(link string) string {
+	for _, rel := range doc.DocRels.Relationships {
+		if isExternalLink(rel) && rel.Target == link {
+			return rel.ID
+		}
+	}
 
 	rID := doc.IncRelationID()
 
@@ -55,3 +61,8 @@ func (doc *Document) addRelation(relType string, fileName string) string {
 
 	return "rId" + strconv.Itoa(rID)
 }
+
+// GetRelationByID returns the document relationship with the given ID, or nil if it does not exist.
+func (doc *Document) GetRelationByID(rID string) *Relationship {
+	return doc.DocRels.GetRelationByID(rID)
+}
@@ -0,0 +1,852 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
+)
+
+// linkPart is a part of the document holding hyperlinks: the main document, a header or
+// footer, or the footnotes or endnotes part.
+type linkPart struct {
+	name  string         // name is the path of the part within the package.
+	rels  *Relationships // rels are the relationships the link targets are resolved from.
+	paras []*ctypes.Paragraph
+
+	// content returns the serialized part, searched for relationship references.
+	content func() ([]byte, error)
+
+	// sync writes the changes made to the hyperlinks of paras to the stored part. It is nil
+	// for the parts written from their model when the document is saved: the main document,
+	// the footnotes and the endnotes.
+	sync func() error
+
+	// links are the hyperlinks of paras in the stored part, in document order, and removed
+	// records those unlinked or removed since the last sync, with whether their content was
+	// kept. They are only used by sync.
+	links   []*ctypes.Hyperlink
+	removed map[*ctypes.Hyperlink]bool
+
+	// readOnly is the reason the hyperlinks of the part cannot be changed, or nil.
+	readOnly error
+
+	// save writes the relationships of the part back to the package after a change. It is
+	// nil for the main document, whose relationships are written when the document is saved.
+	save func() error
+}
+
+// Hyperlinks returns the hyperlinks of the document body, of the headers and footers, and of
+// the footnotes and endnotes, in this order.
+//
+// Changes made through the returned hyperlinks to the links of a header or footer are written
+// to the part immediately, which decodes it anew on the next call. The links of an earlier call
+// in the same header or footer should not be changed after that.
+//
+// Returns:
+//   - []*Hyperlink: The hyperlinks of the document.
+//   - error: An error if a part cannot be decoded.
+func (rd *RootDoc) Hyperlinks() ([]*Hyperlink, error) {
+	parts, err := rd.linkParts()
+	if err != nil {
+		return nil, err
+	}
+
+	var links []*Hyperlink
+	for _, part := range parts {
+		for _, para := range part.paras {
+			for _, child := range para.Children {
+				if child.Link != nil {
+					links = append(links, &Hyperlink{root: rd, ct: child.Link, para: para, part: part})
+				}
+			}
+		}
+	}
+	return links, nil
+}
+
+// linkParts returns the parts of the document that can hold hyperlinks.
+func (rd *RootDoc) linkParts() ([]*linkPart, error) {
+	if rd.Document == nil {
+		return nil, nil
+	}
+
+	parts := []*linkPart{{
+		name:  rd.mainPartName(),
+		rels:  &rd.Document.DocRels,
+		paras: rd.bodyParagraphs(),
+		content: func() ([]byte, error) {
+			return xml.Marshal(rd.Document)
+		},
+	}}
+
+	for _, rel := range rd.Document.DocRels.Relationships {
+		if rel.Type != constants.HeaderType && rel.Type != constants.FooterType {
+			continue
+		}
+		if _, ok := rd.ReadPart(rel.Target); !ok {
+			continue
+		}
+		hdrFtr, err := rd.HeaderFooter(rel.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", rel.Target, err)
+		}
+		part, err := rd.newLinkPart(hdrFtr.RelativePath, hdrFtr.Contents, hdrFtr)
+		if err != nil {
+			return nil, err
+		}
+		if err := rd.storedLinkPart(part); err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+
+	for _, load := range []func() (*ctypes.Footnotes, error){rd.Footnotes, rd.Endnotes} {
+		notes, err := load()
+		if err != nil {
+			return nil, err
+		}
+		if notes == nil {
+			continue
+		}
+		var contents []ctypes.TCBlockContent
+		for _, note := range notes.Notes {
+			contents = append(contents, note.Contents...)
+		}
+		part, err := rd.newLinkPart(notes.RelativePath, contents, notes)
+		if err != nil {
+			return nil, err
+		}
+		// Unchanged notes are searched as stored, with the markup their model does not cover
+		part.content = func() ([]byte, error) {
+			return rd.modelContent(notes.RelativePath, notes, &ctypes.Footnotes{})
+		}
+		parts = append(parts, part)
+	}
+
+	return parts, nil
+}
+
+// newLinkPart returns the link part of a part other than the main document, holding the block
+// contents and serialized from value. Its save function writes its relationships.
+func (rd *RootDoc) newLinkPart(name string, contents []ctypes.TCBlockContent, value any) (*linkPart, error) {
+	rels, err := rd.PartRelations(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if rels.Xmlns == "" {
+		rels.Xmlns = constants.XMLNS
+	}
+
+	var paras []*ctypes.Paragraph
+	for _, block := range contents {
+		if block.Paragraph != nil {
+			paras = append(paras, block.Paragraph)
+		}
+		if block.Table != nil {
+			paras = appendTableParagraphs(paras, block.Table)
+		}
+	}
+
+	return &linkPart{
+		name:  name,
+		rels:  rels,
+		paras: paras,
+		content: func() ([]byte, error) {
+			return xml.Marshal(value)
+		},
+		save: func() error {
+			content, err := marshal(rels)
+			if err != nil {
+				return err
+			}
+			rd.FileMap.Store(rels.RelativePath, content)
+			return nil
+		},
+	}, nil
+}
+
+// storedLinkPart makes a link part search and edit the stored part rather than its model,
+// which does not cover content such as VML pictures, alternate content and embedded objects.
+// Changes to the hyperlinks of the model are patched into the stored part by sync. When the
+// stored part holds paragraph hyperlinks the model does not, such as those of text boxes, the
+// hyperlinks cannot be matched and the part is read only.
+func (rd *RootDoc) storedLinkPart(part *linkPart) error {
+	part.content = func() ([]byte, error) {
+		content, ok := rd.FileMap.Load(part.name)
+		if !ok {
+			return nil, fmt.Errorf("part %s not found", part.name)
+		}
+		return content.([]byte), nil
+	}
+
+	content, err := part.content()
+	if err != nil {
+		return err
+	}
+	_, count, err := rewriteParagraphLinks(content, func(int, *xml.StartElement, *xmlScopes) linkAction {
+		return linkKeep
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", part.name, err)
+	}
+
+	part.links = paragraphLinks(part.paras)
+	if count != len(part.links) {
+		part.readOnly = fmt.Errorf("%s holds hyperlinks outside the content the library models, which cannot be changed", part.name)
+	}
+
+	part.sync = func() error {
+		if part.readOnly != nil {
+			return part.readOnly
+		}
+		content, err := part.content()
+		if err != nil {
+			return err
+		}
+
+		present := make(map[*ctypes.Hyperlink]bool)
+		for _, link := range paragraphLinks(part.paras) {
+			present[link] = true
+		}
+
+		patched, count, err := rewriteParagraphLinks(content, func(i int, start *xml.StartElement, scopes *xmlScopes) linkAction {
+			link := part.links[i]
+			if present[link] {
+				setLinkAttrs(start, link, scopes)
+				return linkKeep
+			}
+			if keep, ok := part.removed[link]; ok {
+				if keep {
+					return linkUnwrap
+				}
+				return linkDrop
+			}
+			return linkKeep
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", part.name, err)
+		}
+		if count != len(part.links) {
+			return fmt.Errorf("%s changed since its hyperlinks were read", part.name)
+		}
+
+		rd.FileMap.Store(part.name, patched)
+		kept := part.links[:0]
+		for _, link := range part.links {
+			if present[link] {
+				kept = append(kept, link)
+			}
+		}
+		part.links, part.removed = kept, nil
+		return nil
+	}
+
+	return nil
+}
+
+// paragraphLinks returns the hyperlinks directly held by the paragraphs, in order.
+func paragraphLinks(paras []*ctypes.Paragraph) []*ctypes.Hyperlink {
+	var links []*ctypes.Hyperlink
+	for _, para := range paras {
+		for _, child := range para.Children {
+			if child.Link != nil {
+				links = append(links, child.Link)
+			}
+		}
+	}
+	return links
+}
+
+// linkRelation returns the ID of an external hyperlink relationship of the part targeting
+// target, adding one if there is none.
+func (part *linkPart) linkRelation(target string) string {
+	for _, rel := range part.rels.Relationships {
+		if isExternalLink(rel) && rel.Target == target {
+			return rel.ID
+		}
+	}
+
+	id := ""
+	for n := len(part.rels.Relationships) + 1; id == ""; n++ {
+		if part.rels.GetRelationByID("rId"+strconv.Itoa(n)) == nil {
+			id = "rId" + strconv.Itoa(n)
+		}
+	}
+	part.rels.Relationships = append(part.rels.Relationships, &Relationship{
+		ID:         id,
+		Type:       constants.SourceRelationshipHyperLink,
+		Target:     target,
+		TargetMode: "External",
+	})
+	return id
+}
+
+// isExternalLink reports whether a relationship is an external hyperlink relationship.
+func isExternalLink(rel *Relationship) bool {
+	return rel.Type == constants.SourceRelationshipHyperLink && rel.TargetMode == "External"
+}
+
+// referencedRelations returns the number of references to each relationship ID in the part.
+func (part *linkPart) referencedRelations() (map[string]int, error) {
+	content, err := part.content()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]int)
+	d := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := d.RawToken()
+		if err == io.EOF {
+			return ids, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if elem, ok := token.(xml.StartElement); ok {
+			for _, attr := range elem.Attr {
+				if attr.Name.Space == "r" || isRelNamespace(attr.Name.Space) {
+					ids[attr.Value]++
+				}
+			}
+		}
+	}
+}
+
+// dropUnusedLinkRelations removes the hyperlink relationships with the given IDs that the part
+// does not reference and returns their number.
+func (part *linkPart) dropUnusedLinkRelations(ids map[string]bool) (int, error) {
+	used, err := part.referencedRelations()
+	if err != nil {
+		return 0, err
+	}
+
+	kept := part.rels.Relationships[:0]
+	dropped := 0
+	for _, rel := range part.rels.Relationships {
+		if rel.Type == constants.SourceRelationshipHyperLink && ids[rel.ID] && used[rel.ID] == 0 {
+			dropped++
+			continue
+		}
+		kept = append(kept, rel)
+	}
+	part.rels.Relationships = kept
+	return dropped, nil
+}
+
+// commit writes a change to the hyperlinks of the part, then removes the relationship a
+// hyperlink pointed to before the change when it is no longer referenced and saves the
+// relationships.
+func (part *linkPart) commit(previousID string) error {
+	if part.sync != nil {
+		if err := part.sync(); err != nil {
+			return err
+		}
+	}
+	if previousID != "" {
+		if _, err := part.dropUnusedLinkRelations(map[string]bool{previousID: true}); err != nil {
+			return err
+		}
+	}
+	if part.save == nil {
+		return nil
+	}
+	return part.save()
+}
+
+// Text returns the text shown for the hyperlink.
+func (r *Hyperlink) Text() string {
+	return paragraphPlainText(&ctypes.Paragraph{Children: []ctypes.ParagraphChild{{Link: r.ct}}})
+}
+
+// Anchor returns the name of the bookmark an internal hyperlink points to, or the empty
+// string for an external hyperlink.
+func (r *Hyperlink) Anchor() string {
+	return r.ct.Anchor
+}
+
+// Target returns the address an external hyperlink points to, resolved from the relationships
+// of the part holding the link. It is empty for an internal hyperlink or when the relationship
+// does not exist.
+func (r *Hyperlink) Target() string {
+	if r.ct.ID == "" || r.locate() != nil {
+		return ""
+	}
+	if rel := r.part.rels.GetRelationByID(r.ct.ID); rel != nil {
+		return rel.Target
+	}
+	return ""
+}
+
+// Part returns the path within the package of the part holding the hyperlink, such as
+// "word/document.xml" or "word/header1.xml".
+func (r *Hyperlink) Part() string {
+	if r.locate() != nil {
+		return ""
+	}
+	return r.part.name
+}
+
+// SetTarget points the hyperlink to an external address. The relationship of another link to
+// the same address is shared, and the previous relationship is removed when no other element
+// references it.
+func (r *Hyperlink) SetTarget(target string) error {
+	if target == "" {
+		return errors.New("hyperlink target is empty")
+	}
+	if err := r.editable(); err != nil {
+		return err
+	}
+
+	previousID := r.ct.ID
+	r.ct.ID = r.part.linkRelation(target)
+	r.ct.Anchor = ""
+	return r.part.commit(previousID)
+}
+
+// SetAnchor points the hyperlink to a bookmark of the document. The previous relationship is
+// removed when no other element references it.
+func (r *Hyperlink) SetAnchor(bookmark string) error {
+	if bookmark == "" {
+		return errors.New("hyperlink anchor is empty")
+	}
+	if err := r.editable(); err != nil {
+		return err
+	}
+
+	previousID := r.ct.ID
+	r.ct.ID = ""
+	r.ct.Anchor = bookmark
+	return r.part.commit(previousID)
+}
+
+// Unlink replaces the hyperlink with its content, which keeps its text but no longer links to
+// the target or uses the Hyperlink character style.
+func (r *Hyperlink) Unlink() error {
+	return r.replace(true)
+}
+
+// Remove removes the hyperlink and its text from the paragraph.
+func (r *Hyperlink) Remove() error {
+	return r.replace(false)
+}
+
+// replace replaces the hyperlink in its paragraph with its content, when keep is true, or
+// with nothing.
+func (r *Hyperlink) replace(keep bool) error {
+	if err := r.editable(); err != nil {
+		return err
+	}
+
+	var content []ctypes.ParagraphChild
+	if keep {
+		content = r.ct.Content()
+		for _, child := range content {
+			if child.Run != nil && child.Run.Property != nil && child.Run.Property.Style != nil &&
+				child.Run.Property.Style.Val == constants.HyperLinkStyle {
+				child.Run.Property.Style = nil
+			}
+		}
+	}
+
+	for i, child := range r.para.Children {
+		if child.Link != r.ct {
+			continue
+		}
+		children := make([]ctypes.ParagraphChild, 0, len(r.para.Children)-1+len(content))
+		children = append(children, r.para.Children[:i]...)
+		children = append(children, content...)
+		r.para.Children = append(children, r.para.Children[i+1:]...)
+		if r.part.removed == nil {
+			r.part.removed = make(map[*ctypes.Hyperlink]bool)
+		}
+		r.part.removed[r.ct] = keep
+		return r.part.commit(r.ct.ID)
+	}
+	return errors.New("hyperlink not found in its paragraph")
+}
+
+// editable locates the hyperlink and reports why it cannot be changed, if it cannot.
+func (r *Hyperlink) editable() error {
+	if err := r.locate(); err != nil {
+		return err
+	}
+	return r.part.readOnly
+}
+
+// locate finds the paragraph and part of a hyperlink that was not returned by Hyperlinks.
+func (r *Hyperlink) locate() error {
+	if r.part != nil {
+		return nil
+	}
+	if r.root == nil {
+		return errors.New("hyperlink not found in the document")
+	}
+
+	links, err := r.root.Hyperlinks()
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		if link.ct == r.ct {
+			r.para, r.part = link.para, link.part
+			return nil
+		}
+	}
+	return errors.New("hyperlink not found in the document")
+}
+
+// LinkIssueKind is the kind of problem found by ValidateHyperlinks.
+type LinkIssueKind int
+
+const (
+	// LinkMissingAnchor is an internal hyperlink to a bookmark that does not exist.
+	LinkMissingAnchor LinkIssueKind = iota
+	// LinkMissingRelationship is an external hyperlink whose relationship does not exist.
+	LinkMissingRelationship
+	// LinkUnusedRelationship is a hyperlink relationship that no element references.
+	LinkUnusedRelationship
+)
+
+// LinkIssue is a problem with the hyperlinks of a document.
+type LinkIssue struct {
+	Kind LinkIssueKind
+	Part string     // Part is the path within the package of the part with the problem.
+	Name string     // Name is the missing bookmark or the relationship ID.
+	Link *Hyperlink // Link is the hyperlink with the problem, nil for an unused relationship.
+}
+
+// String returns a description of the problem.
+func (i LinkIssue) String() string {
+	switch i.Kind {
+	case LinkMissingAnchor:
+		return fmt.Sprintf("%s: hyperlink to missing bookmark %q", i.Part, i.Name)
+	case LinkMissingRelationship:
+		return fmt.Sprintf("%s: hyperlink to missing relationship %s", i.Part, i.Name)
+	default:
+		return fmt.Sprintf("%s: unused hyperlink relationship %s", i.Part, i.Name)
+	}
+}
+
+// ValidateHyperlinks checks the hyperlinks of the document. It reports internal links to
+// bookmarks that do not exist, external links whose relationship does not exist, and hyperlink
+// relationships that no element of their part references.
+func (rd *RootDoc) ValidateHyperlinks() ([]LinkIssue, error) {
+	parts, err := rd.linkParts()
+	if err != nil {
+		return nil, err
+	}
+
+	// _top is the start of the document
+	bookmarks := map[string]bool{"_top": true}
+	for _, name := range rd.Bookmarks() {
+		bookmarks[name] = true
+	}
+
+	var issues []LinkIssue
+	for _, part := range parts {
+		for _, para := range part.paras {
+			for _, child := range para.Children {
+				link := child.Link
+				if link == nil {
+					continue
+				}
+				wrapped := &Hyperlink{root: rd, ct: link, para: para, part: part}
+				switch {
+				case link.ID != "" && part.rels.GetRelationByID(link.ID) == nil:
+					issues = append(issues, LinkIssue{Kind: LinkMissingRelationship, Part: part.name, Name: link.ID, Link: wrapped})
+				case link.ID == "" && link.Anchor != "" && !bookmarks[link.Anchor]:
+					issues = append(issues, LinkIssue{Kind: LinkMissingAnchor, Part: part.name, Name: link.Anchor, Link: wrapped})
+				}
+			}
+		}
+
+		used, err := part.referencedRelations()
+		if err != nil {
+			return nil, err
+		}
+		for _, rel := range part.rels.Relationships {
+			if rel.Type == constants.SourceRelationshipHyperLink && used[rel.ID] == 0 {
+				issues = append(issues, LinkIssue{Kind: LinkUnusedRelationship, Part: part.name, Name: rel.ID})
+			}
+		}
+	}
+	return issues, nil
+}
+
+// DeduplicateHyperlinks makes the hyperlinks of each part that point to the same external
+// address share one relationship, and removes the duplicate relationships that are no longer
+// referenced. Headers and footers with hyperlinks outside the content the library models, such
+// as those of text boxes, are left unchanged.
+//
+// Returns:
+//   - int: The number of relationships removed.
+//   - error: An error if a part cannot be decoded.
+func (rd *RootDoc) DeduplicateHyperlinks() (int, error) {
+	parts, err := rd.linkParts()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, part := range parts {
+		if part.readOnly != nil {
+			continue
+		}
+		first := make(map[string]string)
+		ids := make(map[string]string)
+		for _, rel := range part.rels.Relationships {
+			if !isExternalLink(rel) {
+				continue
+			}
+			if id, ok := first[rel.Target]; ok {
+				ids[rel.ID] = id
+			} else {
+				first[rel.Target] = rel.ID
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		for _, para := range part.paras {
+			for _, child := range para.Children {
+				if child.Link == nil {
+					continue
+				}
+				if id, ok := ids[child.Link.ID]; ok {
+					child.Link.ID = id
+				}
+			}
+		}
+
+		if part.sync != nil {
+			if err := part.sync(); err != nil {
+				return removed, err
+			}
+		}
+
+		duplicates := make(map[string]bool, len(ids))
+		for id := range ids {
+			duplicates[id] = true
+		}
+		dropped, err := part.dropUnusedLinkRelations(duplicates)
+		if err != nil {
+			return removed, err
+		}
+		removed += dropped
+		if part.save != nil {
+			if err := part.save(); err != nil {
+				return removed, err
+			}
+		}
+	}
+	return removed, nil
+}
+
+// linkAction is what rewriteParagraphLinks does with a hyperlink element.
+type linkAction int
+
+const (
+	linkKeep   linkAction = iota // linkKeep writes the element with its attributes as edited.
+	linkUnwrap                   // linkUnwrap writes the content of the element without it.
+	linkDrop                     // linkDrop writes neither the element nor its content.
+)
+
+// xmlScopes holds the namespace declarations in scope while raw XML tokens are processed.
+type xmlScopes []map[string]string
+
+// push adds the declarations of an element.
+func (s *xmlScopes) push(start xml.StartElement) {
+	scope := make(map[string]string)
+	for _, attr := range start.Attr {
+		switch {
+		case attr.Name.Space == "xmlns":
+			scope[attr.Name.Local] = attr.Value
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			scope[""] = attr.Value
+		}
+	}
+	*s = append(*s, scope)
+}
+
+// pop removes the declarations of the innermost element.
+func (s *xmlScopes) pop() {
+	*s = (*s)[:len(*s)-1]
+}
+
+// lookup returns the namespace bound to a prefix.
+func (s xmlScopes) lookup(prefix string) string {
+	for i := len(s) - 1; i >= 0; i-- {
+		if uri, ok := s[i][prefix]; ok {
+			return uri
+		}
+	}
+	return ""
+}
+
+// prefix returns a prefix bound to the namespace, or the empty string when there is none.
+func (s xmlScopes) prefix(ns string) string {
+	for i := len(s) - 1; i >= 0; i-- {
+		for prefix, uri := range s[i] {
+			if uri == ns && prefix != "" && s.lookup(prefix) == ns {
+				return prefix
+			}
+		}
+	}
+	return ""
+}
+
+// rewriteParagraphLinks copies XML content, calling edit for each hyperlink directly held by a
+// paragraph with its number in document order, and returns the content with the hyperlinks
+// edited, unwrapped or dropped and the number of hyperlinks. When a hyperlink is unwrapped, the
+// Hyperlink character style of its runs is dropped with it.
+//
+// The content is processed as raw tokens so that markup the library does not model is kept.
+func rewriteParagraphLinks(content []byte, edit func(n int, start *xml.StartElement, scopes *xmlScopes) linkAction) ([]byte, int, error) {
+	d := xml.NewDecoder(bytes.NewReader(content))
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+
+	type element struct {
+		name   xml.Name // name has the namespace resolved
+		action linkAction
+	}
+	var (
+		scopes xmlScopes
+		stack  []element
+		skip   int // skip is the depth within a dropped element
+		count  int
+	)
+	isWML := func(depth int, local string) bool {
+		return depth >= 0 && depth < len(stack) && isWMLNamespace(stack[depth].name.Space) && stack[depth].name.Local == local
+	}
+
+	for {
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			scopes.push(t)
+			name := xml.Name{Space: scopes.lookup(t.Name.Space), Local: t.Name.Local}
+			if skip > 0 {
+				skip++
+				stack = append(stack, element{name: name, action: linkDrop})
+				continue
+			}
+
+			start := xml.StartElement{Name: t.Name, Attr: append([]xml.Attr(nil), t.Attr...)}
+			action := linkKeep
+			top := len(stack) - 1
+			switch {
+			case isWMLNamespace(name.Space) && name.Local == "hyperlink" && isWML(top, "p"):
+				action = edit(count, &start, &scopes)
+				count++
+			case isWMLNamespace(name.Space) && name.Local == "rStyle" && isWML(top, "rPr") && isWML(top-1, "r") &&
+				top >= 2 && stack[top-2].action == linkUnwrap:
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "val" && isWMLNamespace(scopes.lookup(attr.Name.Space)) && attr.Value == constants.HyperLinkStyle {
+						action = linkDrop
+					}
+				}
+			}
+			stack = append(stack, element{name: name, action: action})
+
+			switch action {
+			case linkDrop:
+				skip = 1
+				continue
+			case linkUnwrap:
+				continue
+			}
+
+			start.Name = qualifiedName(start.Name)
+			for i, attr := range start.Attr {
+				start.Attr[i].Name = qualifiedName(attr.Name)
+			}
+			if err := e.EncodeToken(start); err != nil {
+				return nil, 0, err
+			}
+
+		case xml.EndElement:
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			scopes.pop()
+			if skip > 0 {
+				skip--
+				continue
+			}
+			if top.action == linkUnwrap {
+				continue
+			}
+			if err := e.EncodeToken(xml.EndElement{Name: qualifiedName(t.Name)}); err != nil {
+				return nil, 0, err
+			}
+
+		default:
+			if skip > 0 {
+				continue
+			}
+			if err := e.EncodeToken(xml.CopyToken(tok)); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	if err := e.Flush(); err != nil {
+		return nil, 0, err
+	}
+	return buf.Bytes(), count, nil
+}
+
+// setLinkAttrs sets the relationship ID and anchor attributes of a raw hyperlink element to
+// those of link, keeping its other attributes.
+func setLinkAttrs(start *xml.StartElement, link *ctypes.Hyperlink, scopes *xmlScopes) {
+	var attrs []xml.Attr
+	hasID, hasAnchor := false, false
+	for _, attr := range start.Attr {
+		if attr.Name.Space != "" && attr.Name.Space != "xmlns" {
+			switch ns := scopes.lookup(attr.Name.Space); {
+			case attr.Name.Local == "id" && isRelNamespace(ns):
+				if link.ID == "" {
+					continue
+				}
+				attr.Value, hasID = link.ID, true
+			case attr.Name.Local == "anchor" && isWMLNamespace(ns):
+				if link.Anchor == "" {
+					continue
+				}
+				attr.Value, hasAnchor = link.Anchor, true
+			}
+		}
+		attrs = append(attrs, attr)
+	}
+
+	if link.ID != "" && !hasID {
+		prefix := scopes.prefix(constants.XMLNS_R)
+		if prefix == "" {
+			prefix = "r"
+			for n := 1; scopes.lookup(prefix) != ""; n++ {
+				prefix = "r" + strconv.Itoa(n)
+			}
+			attrs = append(attrs, xml.Attr{Name: xml.Name{Space: "xmlns", Local: prefix}, Value: constants.XMLNS_R})
+		}
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Space: prefix, Local: "id"}, Value: link.ID})
+	}
+	if link.Anchor != "" && !hasAnchor {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Space: start.Name.Space, Local: "anchor"}, Value: link.Anchor})
+	}
+	start.Attr = attrs
+}
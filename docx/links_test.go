@@ -0,0 +1,299 @@
+package docx
+
+import (
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupLinkDoc returns a document with external and internal links in its body and an external
+// link in a header.
+func setupLinkDoc(t *testing.T) *RootDoc {
+	t.Helper()
+
+	rd := setupRootDoc(t)
+	rd.AddParagraph("Terms").AddBookmark("terms")
+	p := rd.AddParagraph("Visit ")
+	p.AddLink("our site", "https://old.example.com")
+	p.AddText(" or ")
+	p.AddInternalLink("the terms", "terms")
+	rd.AddTable().AddRow().AddCell().AddLink("Home", "https://old.example.com")
+
+	rd.FileMap.Store("word/header1.xml", []byte(`<w:hdr `+mergeNS+`><w:p>`+
+		`<w:hyperlink r:id="rId1"><w:r><w:t>Docs</w:t></w:r></w:hyperlink></w:p></w:hdr>`))
+	rd.FileMap.Store("word/_rels/header1.xml.rels", []byte(`<Relationships xmlns="`+constants.XMLNS+`">`+
+		`<Relationship Id="rId1" Type="`+constants.SourceRelationshipHyperLink+`" Target="https://old.example.com/docs" TargetMode="External"/>`+
+		`</Relationships>`))
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships,
+		&Relationship{ID: "rIdHeader", Type: constants.HeaderType, Target: "header1.xml"})
+
+	return rd
+}
+
+func TestHyperlinks(t *testing.T) {
+	rd := setupLinkDoc(t)
+
+	links, err := rd.Hyperlinks()
+	require.NoError(t, err)
+
+	expected := []struct {
+		text   string
+		target string
+		anchor string
+		part   string
+	}{
+		{"our site", "https://old.example.com", "", "word/document.xml"},
+		{"the terms", "", "terms", "word/document.xml"},
+		{"Home", "https://old.example.com", "", "word/document.xml"},
+		{"Docs", "https://old.example.com/docs", "", "word/header1.xml"},
+	}
+	require.Len(t, links, len(expected))
+	for i, link := range links {
+		assert.Equal(t, expected[i].text, link.Text())
+		assert.Equal(t, expected[i].target, link.Target())
+		assert.Equal(t, expected[i].anchor, link.Anchor())
+		assert.Equal(t, expected[i].part, link.Part())
+	}
+
+	// Links to the same address share their relationship
+	assert.Equal(t, links[0].ct.ID, links[2].ct.ID)
+	assert.Len(t, rd.Document.DocRels.Relationships, 2)
+}
+
+func TestHyperlink_SetTarget(t *testing.T) {
+	rd := setupLinkDoc(t)
+	links, err := rd.Hyperlinks()
+	require.NoError(t, err)
+	sharedID := links[0].ct.ID
+
+	// The shared relationship stays while the other link uses it
+	require.NoError(t, links[0].SetTarget("https://new.example.com"))
+	assert.Equal(t, "https://new.example.com", links[0].Target())
+	assert.NotNil(t, rd.Document.GetRelationByID(sharedID))
+
+	require.NoError(t, links[2].SetTarget("https://new.example.com"))
+	assert.Equal(t, links[0].ct.ID, links[2].ct.ID)
+	assert.Nil(t, rd.Document.GetRelationByID(sharedID))
+
+	require.NoError(t, links[1].SetTarget("https://new.example.com/terms"))
+	assert.Empty(t, links[1].Anchor())
+	require.NoError(t, links[0].SetAnchor("terms"))
+	assert.Equal(t, "terms", links[0].Anchor())
+	assert.Empty(t, links[0].Target())
+
+	// Header links are written back to the header part
+	require.NoError(t, links[3].SetTarget("https://new.example.com/docs"))
+	header, ok := rd.ReadPart("header1.xml")
+	require.True(t, ok)
+	assert.Contains(t, string(header), `<w:hyperlink r:id="rId2">`)
+	rels, err := rd.PartRelations("word/header1.xml")
+	require.NoError(t, err)
+	require.Len(t, rels.Relationships, 1)
+	assert.Equal(t, "https://new.example.com/docs", rels.Relationships[0].Target)
+	assert.Equal(t, "External", rels.Relationships[0].TargetMode)
+
+	links, err = rd.Hyperlinks()
+	require.NoError(t, err)
+	assert.Equal(t, "https://new.example.com/docs", links[3].Target())
+
+	assert.Error(t, links[0].SetTarget(""))
+	assert.Error(t, links[0].SetAnchor(""))
+}
+
+func TestHyperlink_UnlinkAndRemove(t *testing.T) {
+	rd := setupLinkDoc(t)
+	para := rd.Document.Body.Children[1].Para
+
+	// A link returned when it was added is found in the document
+	cellLink := rd.Document.Body.Children[2].Table.ct.RowContents[0].Row.Contents[0].Cell.Contents[0].Paragraph.Children[0].Link
+	require.NoError(t, (&Hyperlink{root: rd, ct: cellLink}).Remove())
+
+	links, err := rd.Hyperlinks()
+	require.NoError(t, err)
+	require.Len(t, links, 3)
+
+	require.NoError(t, links[0].Unlink())
+	assert.Equal(t, "Visit our site or the terms", paragraphPlainText(&para.ct))
+	assert.Nil(t, para.ct.Children[1].Link)
+	assert.Nil(t, para.ct.Children[1].Run.Property.Style)
+
+	require.NoError(t, links[1].Remove())
+	assert.Equal(t, "Visit our site or ", paragraphPlainText(&para.ct))
+
+	// The relationship is removed with the last link using it
+	for _, rel := range rd.Document.DocRels.Relationships {
+		assert.NotEqual(t, constants.SourceRelationshipHyperLink, rel.Type)
+	}
+
+	require.NoError(t, links[2].Unlink())
+	header, ok := rd.ReadPart("header1.xml")
+	require.True(t, ok)
+	assert.NotContains(t, string(header), "hyperlink")
+	assert.Contains(t, string(header), "Docs")
+
+	assert.Error(t, (&Hyperlink{root: rd, ct: cellLink}).Unlink())
+}
+
+func TestValidateHyperlinks(t *testing.T) {
+	rd := setupLinkDoc(t)
+
+	issues, err := rd.ValidateHyperlinks()
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	rd.AddParagraph("").AddInternalLink("missing", "nowhere")
+	rd.AddParagraph("").AddInternalLink("top", "_top")
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &Relationship{
+		ID: "rId9", Type: constants.SourceRelationshipHyperLink, Target: "https://unused.example.com", TargetMode: "External",
+	})
+	rd.AddParagraph("").AddLink("broken", "https://broken.example.com").ct.ID = "rId42"
+
+	issues, err = rd.ValidateHyperlinks()
+	require.NoError(t, err)
+	require.Len(t, issues, 4)
+	assert.Equal(t, LinkIssue{Kind: LinkMissingAnchor, Part: "word/document.xml", Name: "nowhere", Link: issues[0].Link}, issues[0])
+	assert.Equal(t, "missing", issues[0].Link.Text())
+	assert.Equal(t, LinkMissingRelationship, issues[1].Kind)
+	assert.Equal(t, "rId42", issues[1].Name)
+	assert.Equal(t, LinkUnusedRelationship, issues[2].Kind)
+	assert.Equal(t, "rId9", issues[2].Name)
+	assert.Equal(t, LinkUnusedRelationship, issues[3].Kind)
+	assert.Equal(t, `word/document.xml: hyperlink to missing bookmark "nowhere"`, issues[0].String())
+	assert.Equal(t, "word/document.xml: unused hyperlink relationship rId9", issues[2].String())
+}
+
+// vmlNS declares the namespaces of VML pictures and alternate content, which the header and
+// footer model does not cover.
+const vmlNS = `xmlns:v="urn:schemas-microsoft-com:vml" xmlns:o="urn:schemas-microsoft-com:office:office" ` +
+	`xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" ` +
+	`xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing" ` +
+	`xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"`
+
+// setupWatermarkHeader stores a header with a VML watermark, links and alternate content
+// holding a drawing hyperlink, and returns its name.
+func setupWatermarkHeader(t *testing.T, rd *RootDoc) string {
+	t.Helper()
+
+	rd.FileMap.Store("word/header1.xml", []byte(`<w:hdr `+mergeNS+` `+vmlNS+`>`+
+		`<w:p><w:r><w:pict><v:shape id="Watermark"><v:imagedata r:id="rId2" o:title="draft"/></v:shape></w:pict></w:r></w:p>`+
+		`<w:p><w:hyperlink r:id="rId1" w:history="1"><w:r><w:rPr><w:rStyle w:val="Hyperlink"/></w:rPr><w:t>Docs</w:t></w:r></w:hyperlink>`+
+		`<w:hyperlink r:id="rId1"><w:r><w:rPr><w:rStyle w:val="Hyperlink"/><w:b/></w:rPr><w:t>More</w:t></w:r></w:hyperlink>`+
+		`<w:hyperlink w:anchor="_top"><w:r><w:t>Top</w:t></w:r></w:hyperlink></w:p>`+
+		`<w:p><mc:AlternateContent><mc:Choice Requires="wps"><w:r><w:drawing><wp:inline><wp:docPr id="1" name="Logo">`+
+		`<a:hlinkClick r:id="rId3"/></wp:docPr></wp:inline></w:drawing></w:r></mc:Choice>`+
+		`<mc:Fallback><w:r><w:t>fallback</w:t></w:r></mc:Fallback></mc:AlternateContent></w:p></w:hdr>`))
+	rd.FileMap.Store("word/_rels/header1.xml.rels", []byte(`<Relationships xmlns="`+constants.XMLNS+`">`+
+		`<Relationship Id="rId1" Type="`+constants.SourceRelationshipHyperLink+`" Target="https://example.com/docs" TargetMode="External"/>`+
+		`<Relationship Id="rId2" Type="`+constants.SourceRelationshipImage+`" Target="media/watermark.png"/>`+
+		`<Relationship Id="rId3" Type="`+constants.SourceRelationshipHyperLink+`" Target="https://example.com" TargetMode="External"/>`+
+		`</Relationships>`))
+	rd.FileMap.Store("word/media/watermark.png", testPNG(t, 10, 10, 0))
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships,
+		&Relationship{ID: "rIdHeader", Type: constants.HeaderType, Target: "header1.xml"})
+
+	return "word/header1.xml"
+}
+
+func TestHyperlink_HeaderKeepsUnmodelledContent(t *testing.T) {
+	rd := setupRootDoc(t)
+	name := setupWatermarkHeader(t, rd)
+
+	// The relationship of the drawing hyperlink is referenced from the alternate content
+	issues, err := rd.ValidateHyperlinks()
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	links, err := rd.Hyperlinks()
+	require.NoError(t, err)
+	require.Len(t, links, 3)
+
+	require.NoError(t, links[0].SetTarget("https://example.com/new"))
+	require.NoError(t, links[2].SetTarget("https://example.com/top"))
+	require.NoError(t, links[1].Unlink())
+
+	content, ok := rd.FileMap.Load(name)
+	require.True(t, ok)
+	header := string(content.([]byte))
+	assert.Contains(t, header, `<v:imagedata r:id="rId2" o:title="draft">`)
+	assert.Contains(t, header, `<mc:AlternateContent><mc:Choice Requires="wps">`)
+	assert.Contains(t, header, `<a:hlinkClick r:id="rId3">`)
+	assert.Contains(t, header, `<w:hyperlink r:id="rId4" w:history="1"><w:r><w:rPr><w:rStyle w:val="Hyperlink">`)
+	assert.Contains(t, header, `<w:r><w:rPr><w:b></w:b></w:rPr><w:t>More</w:t></w:r><w:hyperlink r:id="rId5">`)
+	assert.NotContains(t, header, "w:anchor")
+
+	// The relationship is removed with the last link using it
+	rels, err := rd.PartRelations(name)
+	require.NoError(t, err)
+	assert.Nil(t, rels.GetRelationByID("rId1"))
+	assert.NotNil(t, rels.GetRelationByID("rId2"))
+	assert.NotNil(t, rels.GetRelationByID("rId3"))
+
+	require.NoError(t, links[0].Remove())
+	content, _ = rd.FileMap.Load(name)
+	header = string(content.([]byte))
+	assert.NotContains(t, header, "Docs")
+	assert.Contains(t, header, "<v:imagedata")
+	assert.Contains(t, header, `<w:hyperlink r:id="rId5">`)
+}
+
+func TestHyperlink_HeaderWithUnmodelledLinks(t *testing.T) {
+	rd := setupRootDoc(t)
+	stored := []byte(`<w:hdr ` + mergeNS + ` ` + vmlNS + `>` +
+		`<w:p><w:hyperlink r:id="rId1"><w:r><w:t>Docs</w:t></w:r></w:hyperlink>` +
+		`<w:hyperlink r:id="rId2"><w:r><w:t>Again</w:t></w:r></w:hyperlink>` +
+		`<w:r><w:pict><v:shape><v:textbox><w:txbxContent><w:p><w:hyperlink r:id="rId1"><w:r><w:t>Box</w:t></w:r></w:hyperlink></w:p>` +
+		`</w:txbxContent></v:textbox></v:shape></w:pict></w:r></w:p></w:hdr>`)
+	rd.FileMap.Store("word/header1.xml", stored)
+	rd.FileMap.Store("word/_rels/header1.xml.rels", []byte(`<Relationships xmlns="`+constants.XMLNS+`">`+
+		`<Relationship Id="rId1" Type="`+constants.SourceRelationshipHyperLink+`" Target="https://example.com" TargetMode="External"/>`+
+		`<Relationship Id="rId2" Type="`+constants.SourceRelationshipHyperLink+`" Target="https://example.com" TargetMode="External"/>`+
+		`</Relationships>`))
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships,
+		&Relationship{ID: "rIdHeader", Type: constants.HeaderType, Target: "header1.xml"})
+
+	// The link of the text box cannot be told apart from the others, so the header is not changed
+	links, err := rd.Hyperlinks()
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Error(t, links[0].SetTarget("https://example.com/new"))
+	assert.Error(t, links[1].Remove())
+
+	removed, err := rd.DeduplicateHyperlinks()
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+
+	content, _ := rd.FileMap.Load("word/header1.xml")
+	assert.Equal(t, stored, content)
+	rels, err := rd.PartRelations("word/header1.xml")
+	require.NoError(t, err)
+	assert.Len(t, rels.Relationships, 2)
+}
+
+func TestDeduplicateHyperlinks(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddParagraph("")
+	first := p.AddLink("one", "https://example.com")
+	second := p.AddLink("two", "https://example.com")
+	assert.Equal(t, first.ct.ID, second.ct.ID)
+
+	// Relationships duplicated by other tools are merged
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &Relationship{
+		ID: "rIdDup", Type: constants.SourceRelationshipHyperLink, Target: "https://example.com", TargetMode: "External",
+	})
+	second.ct.ID = "rIdDup"
+	third := p.AddLink("three", "https://other.example.com")
+
+	removed, err := rd.DeduplicateHyperlinks()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, first.ct.ID, second.ct.ID)
+	assert.Nil(t, rd.Document.GetRelationByID("rIdDup"))
+	assert.NotNil(t, rd.Document.GetRelationByID(third.ct.ID))
+	assert.Len(t, rd.Document.DocRels.Relationships, 2)
+
+	removed, err = rd.DeduplicateHyperlinks()
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+}
@@ -0,0 +1,322 @@
+package docx
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/bfoley13/godocx/common/constants"
+)
+
+// MediaPart is a file stored in the media folder of the package, such as an image.
+type MediaPart struct {
+	// Name is the name of the part within the package, such as "word/media/image1.png".
+	Name string
+
+	// ContentType is the content type of the part, such as "image/png".
+	ContentType string
+
+	// Size is the size of the file in bytes.
+	Size int
+
+	// Info holds the format and dimensions of an image. It is the zero value for files that
+	// are not PNG, JPEG, GIF, BMP or TIFF images.
+	Info ImageInfo
+
+	// Usages are the parts of the package referencing the file. A file without usages is
+	// unused.
+	Usages []MediaUsage
+}
+
+// MediaUsage is a part of the package referencing a media file through a relationship.
+type MediaUsage struct {
+	// Part is the name of the referencing part, such as "word/document.xml".
+	Part string
+
+	// RelationshipID is the ID of the relationship of the part targeting the file.
+	RelationshipID string
+
+	// Count is the number of references to the relationship in the part, such as the number
+	// of pictures showing an image.
+	Count int
+}
+
+// Media returns the files of the media folder of the package, sorted by name, with the parts
+// referencing them. The main document, headers, footers, footnotes, endnotes and any other part
+// with relationships are searched.
+//
+// Returns:
+//   - []MediaPart: The media files of the package.
+//   - error: An error if a part cannot be decoded.
+func (rd *RootDoc) Media() ([]MediaPart, error) {
+	parts, err := rd.relationParts()
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := rd.mediaUsages(parts)
+	if err != nil {
+		return nil, err
+	}
+
+	names := rd.mediaNames()
+	list := make([]MediaPart, 0, len(names))
+	for _, name := range names {
+		content, _ := rd.FileMap.Load(name)
+		data := content.([]byte)
+
+		contentType, _ := rd.ContentType.partContentType("/" + name)
+		info, _ := ReadImageInfo(data)
+		list = append(list, MediaPart{
+			Name:        name,
+			ContentType: contentType,
+			Size:        len(data),
+			Info:        info,
+			Usages:      media[name],
+		})
+	}
+
+	return list, nil
+}
+
+// ReplaceMedia replaces the content of a media file, which changes every picture showing it.
+// The pictures keep their size. The content type of the file is overridden when the format of
+// the new image does not match its extension.
+//
+// Parameters:
+//   - name: The name of the file, such as "word/media/image1.png" or "media/image1.png".
+//   - data: The new content of the file.
+//
+// Returns:
+//   - error: An error if the file is not found in the media folder.
+func (rd *RootDoc) ReplaceMedia(name string, data []byte) error {
+	partName := name
+	if !strings.HasPrefix(partName, constants.MediaPath) {
+		partName = rd.partPath(name)
+	}
+	if _, ok := rd.FileMap.Load(partName); !ok || !strings.HasPrefix(partName, constants.MediaPath) {
+		return fmt.Errorf("media file %s not found", name)
+	}
+
+	if info, err := ReadImageInfo(data); err == nil {
+		mime, err := MIMEFromExt(info.Format)
+		if err != nil {
+			return err
+		}
+		if contentType, _ := rd.ContentType.partContentType("/" + partName); contentType != mime {
+			if err := rd.ContentType.AddOverride("/"+partName, mime); err != nil {
+				return err
+			}
+		}
+	}
+
+	rd.FileMap.Store(partName, data)
+	return nil
+}
+
+// RemoveUnusedMedia removes the media files that no part of the package references, with the
+// relationships targeting them that are not referenced either. References are searched in the
+// stored parts, including VML pictures and alternate content, and only the relationships of
+// the parts are written back.
+//
+// Returns:
+//   - []string: The names of the removed files, sorted.
+//   - error: An error if a part cannot be decoded.
+func (rd *RootDoc) RemoveUnusedMedia() ([]string, error) {
+	parts, err := rd.relationParts()
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := rd.mediaUsages(parts)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	unused := make(map[string]bool)
+	for _, name := range rd.mediaNames() {
+		if len(media[name]) == 0 {
+			unused[name] = true
+			removed = append(removed, name)
+		}
+	}
+	if len(removed) == 0 {
+		return nil, nil
+	}
+
+	for _, part := range parts {
+		kept := part.rels.Relationships[:0]
+		for _, rel := range part.rels.Relationships {
+			if rel.TargetMode != "External" && unused[resolvePartTarget(part.name, rel.Target)] {
+				continue
+			}
+			kept = append(kept, rel)
+		}
+		if len(kept) == len(part.rels.Relationships) {
+			continue
+		}
+		part.rels.Relationships = kept
+		if part.save != nil {
+			if err := part.save(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, name := range removed {
+		rd.FileMap.Delete(name)
+		rd.ContentType.removeOverride("/" + name)
+	}
+
+	return removed, nil
+}
+
+// relationParts returns the parts of the package with relationships: the parts holding
+// hyperlinks, then the other parts whose relationships are stored in the package, by name.
+// The save function of the other parts writes their relationships.
+func (rd *RootDoc) relationParts() ([]*linkPart, error) {
+	parts, err := rd.linkParts()
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool)
+	for _, part := range parts {
+		known[part.name] = true
+	}
+
+	var relsPaths []string
+	rd.FileMap.Range(func(key, _ any) bool {
+		if name := key.(string); strings.HasSuffix(name, ".rels") {
+			relsPaths = append(relsPaths, name)
+		}
+		return true
+	})
+	sort.Strings(relsPaths)
+
+	for _, relsPath := range relsPaths {
+		name := path.Join(path.Dir(path.Dir(relsPath)), strings.TrimSuffix(path.Base(relsPath), ".rels"))
+		if known[name] || name == "." || partRelsPath(name) != relsPath {
+			continue
+		}
+		content, ok := rd.FileMap.Load(name)
+		if !ok {
+			continue
+		}
+
+		rels, err := rd.PartRelations(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if rels.Xmlns == "" {
+			rels.Xmlns = constants.XMLNS
+		}
+
+		data := content.([]byte)
+		parts = append(parts, &linkPart{
+			name: name,
+			rels: rels,
+			content: func() ([]byte, error) {
+				return data, nil
+			},
+			save: func() error {
+				content, err := marshal(rels)
+				if err != nil {
+					return err
+				}
+				rd.FileMap.Store(rels.RelativePath, content)
+				return nil
+			},
+		})
+	}
+
+	return parts, nil
+}
+
+// mediaUsages returns the usages of the media files referenced by the parts, by file name.
+func (rd *RootDoc) mediaUsages(parts []*linkPart) (map[string][]MediaUsage, error) {
+	usages := make(map[string][]MediaUsage)
+	for _, part := range parts {
+		var refs map[string]int
+		for _, rel := range part.rels.Relationships {
+			if rel.TargetMode == "External" {
+				continue
+			}
+			target := resolvePartTarget(part.name, rel.Target)
+			if !strings.HasPrefix(target, constants.MediaPath) {
+				continue
+			}
+
+			if refs == nil {
+				var err error
+				if refs, err = part.referencedRelations(); err != nil {
+					return nil, fmt.Errorf("%s: %w", part.name, err)
+				}
+			}
+			if refs[rel.ID] > 0 {
+				usages[target] = append(usages[target], MediaUsage{Part: part.name, RelationshipID: rel.ID, Count: refs[rel.ID]})
+			}
+		}
+	}
+	return usages, nil
+}
+
+// mediaNames returns the names of the files of the media folder, sorted.
+func (rd *RootDoc) mediaNames() []string {
+	var names []string
+	rd.FileMap.Range(func(key, _ any) bool {
+		if name := key.(string); strings.HasPrefix(name, constants.MediaPath) {
+			names = append(names, name)
+		}
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+// findMedia returns the name of the first media file with the same content as data, or the
+// empty string when there is none.
+func (rd *RootDoc) findMedia(data []byte) string {
+	sum := sha256.Sum256(data)
+	for _, name := range rd.mediaNames() {
+		content, _ := rd.FileMap.Load(name)
+		if other := content.([]byte); len(other) == len(data) && sha256.Sum256(other) == sum {
+			return name
+		}
+	}
+	return ""
+}
+
+// newMediaName returns an unused name of the media folder for an image with the extension ext,
+// given without the dot, numbered after the image count.
+func (rd *RootDoc) newMediaName(ext string) string {
+	for n := rd.ImageCount; ; n++ {
+		name := fmt.Sprintf("%simage%d.%s", constants.MediaPath, n, ext)
+		if _, exists := rd.FileMap.Load(name); !exists {
+			return name
+		}
+	}
+}
+
+// imageRelation returns the ID of an image relationship of the main document targeting the
+// media file name, or the empty string when there is none.
+func (rd *RootDoc) imageRelation(name string) string {
+	for _, rel := range rd.Document.DocRels.Relationships {
+		if rel.Type == constants.SourceRelationshipImage && rel.TargetMode != "External" &&
+			rd.partPath(rel.Target) == name {
+			return rel.ID
+		}
+	}
+	return ""
+}
+
+// resolvePartTarget returns the name of the part a relationship of the part source targets.
+func resolvePartTarget(source, target string) string {
+	if path.IsAbs(target) {
+		return target[1:]
+	}
+	return path.Join(path.Dir(source), target)
+}
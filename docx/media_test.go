@@ -0,0 +1,212 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddPicture_DeduplicatesMedia(t *testing.T) {
+	rd := setupRootDoc(t)
+	logo := testPNG(t, 20, 10, 0)
+
+	first, err := rd.AddParagraph("").AddPictureBytes(logo, ".png", 1, 1)
+	require.NoError(t, err)
+	second, err := rd.AddParagraph("").AddPictureFromBytes(logo, "", PictureOptions{})
+	require.NoError(t, err)
+	other, err := rd.AddParagraph("").AddPictureBytes(testPNG(t, 30, 10, 0), "png", 1, 1)
+	require.NoError(t, err)
+
+	// The drawings are numbered apart but share the media file and its relationship
+	embed := func(pic *PicMeta) string { return pic.Inline.Graphic.Data.Pic.BlipFill.Blip.EmbedID }
+	assert.Equal(t, embed(first), embed(second))
+	assert.NotEqual(t, embed(first), embed(other))
+	assert.NotEqual(t, first.Inline.DocProp.ID, second.Inline.DocProp.ID)
+	assert.Len(t, rd.Document.DocRels.Relationships, 2)
+	assert.Equal(t, []string{"word/media/image2.png", "word/media/image4.png"}, rd.mediaNames())
+
+	// Content types are only added when missing
+	assert.Equal(t, []Default{{Extension: "png", ContentType: "image/png"}}, rd.ContentType.Default)
+	assert.Empty(t, rd.ContentType.Override)
+
+	// A file without a relationship from the main document gets one
+	rd.Document.DocRels.Relationships = rd.Document.DocRels.Relationships[1:]
+	third, err := rd.AddParagraph("").AddPictureBytes(logo, ".png", 1, 1)
+	require.NoError(t, err)
+	rel := rd.Document.GetRelationByID(embed(third))
+	require.NotNil(t, rel)
+	assert.Equal(t, "media/image2.png", rel.Target)
+	assert.Len(t, rd.mediaNames(), 2)
+}
+
+// setupMediaDoc returns a document with two pictures of the same image and one of another in
+// the body, a header showing the first image and an unused media file with a relationship.
+func setupMediaDoc(t *testing.T) *RootDoc {
+	t.Helper()
+
+	rd := setupRootDoc(t)
+	logo := testPNG(t, 20, 10, 0)
+	for _, data := range [][]byte{logo, logo, testJPEG(t, 16, 16)} {
+		_, err := rd.AddPictureFromBytes(data, "", PictureOptions{})
+		require.NoError(t, err)
+	}
+
+	// The header shows the logo through a relationship of its own with the same ID
+	logoPara, err := xml.Marshal(rd.Document.Body.Children[0].Para.GetCT())
+	require.NoError(t, err)
+	rd.FileMap.Store("word/header1.xml", []byte(`<w:hdr `+mergeNS+` xmlns:wp="`+constants.WMLDrawingNS+
+		`" xmlns:a="`+constants.DrawingMLMainNS+`" xmlns:pic="`+constants.DrawingMLPicNS+`">`+
+		string(logoPara)+`</w:hdr>`))
+	rd.FileMap.Store("word/_rels/header1.xml.rels", []byte(`<Relationships xmlns="`+constants.XMLNS+`">`+
+		`<Relationship Id="rId1" Type="`+constants.SourceRelationshipImage+`" Target="media/image2.png"/>`+
+		`</Relationships>`))
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships,
+		&Relationship{ID: "rIdHeader", Type: constants.HeaderType, Target: "header1.xml"},
+		&Relationship{ID: "rIdOld", Type: constants.SourceRelationshipImage, Target: "media/old.gif"})
+	rd.FileMap.Store("word/media/old.gif", []byte("GIF89a"))
+	require.NoError(t, rd.ContentType.AddOverride("/word/media/old.gif", "image/gif"))
+
+	return rd
+}
+
+func TestMedia(t *testing.T) {
+	rd := setupMediaDoc(t)
+
+	media, err := rd.Media()
+	require.NoError(t, err)
+	require.Len(t, media, 3)
+
+	logo := media[0]
+	assert.Equal(t, "word/media/image2.png", logo.Name)
+	assert.Equal(t, "image/png", logo.ContentType)
+	assert.Equal(t, ImageInfo{Format: "png", Width: 20, Height: 10}, logo.Info)
+	assert.Equal(t, []MediaUsage{
+		{Part: "word/document.xml", RelationshipID: "rId1", Count: 2},
+		{Part: "word/header1.xml", RelationshipID: "rId1", Count: 1},
+	}, logo.Usages)
+
+	photo := media[1]
+	assert.Equal(t, "word/media/image4.jpeg", photo.Name)
+	assert.Equal(t, "image/jpeg", photo.ContentType)
+	assert.Equal(t, 16, photo.Info.Width)
+	assert.Len(t, photo.Usages, 1)
+
+	// An unreadable file is listed without dimensions
+	old := media[2]
+	assert.Equal(t, "word/media/old.gif", old.Name)
+	assert.Equal(t, "image/gif", old.ContentType)
+	assert.Equal(t, 6, old.Size)
+	assert.Zero(t, old.Info)
+	assert.Empty(t, old.Usages)
+}
+
+func TestReplaceMedia(t *testing.T) {
+	rd := setupMediaDoc(t)
+	replacement := testJPEG(t, 40, 20)
+
+	require.NoError(t, rd.ReplaceMedia("media/image2.png", replacement))
+	content, ok := rd.ReadPart("media/image2.png")
+	require.True(t, ok)
+	assert.Equal(t, replacement, content)
+
+	// The content type follows the format of the new image
+	contentType, ok := rd.ContentType.partContentType("/word/media/image2.png")
+	require.True(t, ok)
+	assert.Equal(t, "image/jpeg", contentType)
+
+	require.NoError(t, rd.ReplaceMedia("word/media/image4.jpeg", testJPEG(t, 8, 8)))
+	_, ok = rd.ContentType.overrideFor("/word/media/image4.jpeg")
+	assert.False(t, ok)
+
+	assert.Error(t, rd.ReplaceMedia("media/missing.png", replacement))
+	assert.Error(t, rd.ReplaceMedia("word/document.xml", replacement))
+}
+
+func TestRemoveUnusedMedia(t *testing.T) {
+	rd := setupMediaDoc(t)
+
+	removed, err := rd.RemoveUnusedMedia()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"word/media/old.gif"}, removed)
+
+	assert.Equal(t, []string{"word/media/image2.png", "word/media/image4.jpeg"}, rd.mediaNames())
+	assert.Nil(t, rd.Document.GetRelationByID("rIdOld"))
+	assert.NotNil(t, rd.Document.GetRelationByID("rIdHeader"))
+	_, ok := rd.ContentType.overrideFor("/word/media/old.gif")
+	assert.False(t, ok)
+
+	rels, err := rd.PartRelations("word/header1.xml")
+	require.NoError(t, err)
+	assert.Len(t, rels.Relationships, 1)
+
+	// Pictures removed from the body free their file once no part uses it
+	rd.Document.Body.Children = rd.Document.Body.Children[:2]
+	removed, err = rd.RemoveUnusedMedia()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"word/media/image4.jpeg"}, removed)
+
+	rd.Document.Body.Children = nil
+	removed, err = rd.RemoveUnusedMedia()
+	require.NoError(t, err)
+	assert.Empty(t, removed, "the header still shows the logo")
+}
+
+func TestRemoveUnusedMedia_VMLReferences(t *testing.T) {
+	rd := setupRootDoc(t)
+	name := setupWatermarkHeader(t, rd)
+	stored, _ := rd.FileMap.Load(name)
+
+	// The watermark is only referenced from VML, which the header model does not cover
+	media, err := rd.Media()
+	require.NoError(t, err)
+	require.Len(t, media, 1)
+	assert.Equal(t, []MediaUsage{{Part: name, RelationshipID: "rId2", Count: 1}}, media[0].Usages)
+
+	removed, err := rd.RemoveUnusedMedia()
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+	_, ok := rd.FileMap.Load("word/media/watermark.png")
+	assert.True(t, ok)
+
+	// Removing an unused file writes the relationships of the header but not the header
+	rd.FileMap.Store("word/media/unused.png", []byte("png"))
+	rels, err := rd.PartRelations(name)
+	require.NoError(t, err)
+	rels.Relationships = append(rels.Relationships, &Relationship{ID: "rId9", Type: constants.SourceRelationshipImage, Target: "media/unused.png"})
+	content, err := marshal(rels)
+	require.NoError(t, err)
+	rd.FileMap.Store(rels.RelativePath, content)
+
+	removed, err = rd.RemoveUnusedMedia()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"word/media/unused.png"}, removed)
+	header, _ := rd.FileMap.Load(name)
+	assert.Equal(t, stored, header)
+	rels, err = rd.PartRelations(name)
+	require.NoError(t, err)
+	assert.Nil(t, rels.GetRelationByID("rId9"))
+	assert.NotNil(t, rels.GetRelationByID("rId2"))
+}
+
+func TestAppendDocument_SharesMedia(t *testing.T) {
+	dst := setupRootDoc(t)
+	src := setupRootDoc(t)
+	logo := testPNG(t, 20, 10, 0)
+
+	_, err := dst.AddPictureFromBytes(logo, "", PictureOptions{})
+	require.NoError(t, err)
+	_, err = src.AddPictureFromBytes(logo, "", PictureOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, dst.AppendDocument(src, MergeOptions{}))
+	assert.Equal(t, []string{"word/media/image2.png"}, dst.mediaNames())
+
+	media, err := dst.Media()
+	require.NoError(t, err)
+	require.Len(t, media, 1)
+	require.Len(t, media[0].Usages, 1)
+	assert.Equal(t, 2, media[0].Usages[0].Count)
+}
@@ -0,0 +1,1146 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
+)
+
+// StyleConflict selects how a style defined by both documents of a merge is resolved.
+type StyleConflict int
+
+const (
+	// StyleUseDestination formats the merged content with the definition of the destination
+	// document, as Word does when pasting with destination styles.
+	StyleUseDestination StyleConflict = iota
+
+	// StyleKeepSource keeps the look of the merged content by adding the source definition
+	// under a new style ID when it differs from the destination definition.
+	StyleKeepSource
+
+	// StyleOverwrite replaces the definition of the destination document with the source
+	// definition, changing the look of the existing content using the style.
+	StyleOverwrite
+)
+
+// MergeOptions configures AppendDocument and InsertDocumentAt.
+type MergeOptions struct {
+	// Styles selects how styles defined by both documents are resolved.
+	Styles StyleConflict
+
+	// IgnoreSections merges the body content only, so that it continues the section it is
+	// merged into. By default the merged content keeps the page setup, headers and footers of
+	// the other document in a section of its own.
+	IgnoreSections bool
+}
+
+// AppendDocument appends the body content of another document to the end of the document.
+//
+// The styles, numbering definitions, images and other parts, hyperlinks, headers and footers,
+// footnotes, endnotes and comments used by the content are copied along with it. Copied
+// numbering definitions and notes get new IDs, copied parts new names and relationships new
+// IDs, and styles are resolved according to the options. The other document is not modified.
+// The captions of the appended content continue the numbering of the document.
+//
+// Parameters:
+//   - other: The document whose content is appended.
+//   - opts: Options controlling the merge.
+//
+// Returns:
+//   - error: An error if a part of the other document cannot be copied, in which case the
+//     document is left unchanged.
+//
+// Example:
+//
+//	for _, clause := range clauses {
+//		if err := contract.AppendDocument(clause, docx.MergeOptions{}); err != nil {
+//			return err
+//		}
+//	}
+func (rd *RootDoc) AppendDocument(other *RootDoc, opts MergeOptions) error {
+	children, sectPr, err := rd.mergeDocument(other, opts)
+	if err != nil {
+		return err
+	}
+
+	body := rd.Document.Body
+	if sectPr != nil {
+		// The existing content ends with its own section; the final section properties of the
+		// document become those of the appended content
+		if len(body.Children) > 0 && body.SectPr != nil {
+			rd.sectionBreak(len(body.Children), body.SectPr)
+		}
+		body.SectPr = sectPr
+	}
+	body.Children = append(body.Children, children...)
+	rd.renumberCaptions()
+
+	return nil
+}
+
+// InsertDocumentAt inserts the body content of another document before a paragraph of the
+// document body, copying the content dependencies as AppendDocument does.
+//
+// Unless sections are ignored, the inserted content forms a section of its own: the content
+// before the anchor ends with a section break keeping the section in effect at the anchor,
+// unless it ends with a section break already, and the inserted content ends with a section
+// break holding the section properties of the other document. Captions are renumbered to
+// follow the new order.
+//
+// Parameters:
+//   - anchor: The body paragraph the content is inserted before.
+//   - other: The document whose content is inserted.
+//   - opts: Options controlling the merge.
+//
+// Returns:
+//   - error: An error if the anchor is not a paragraph of the body or a part of the other
+//     document cannot be copied, in which case the document is left unchanged.
+func (rd *RootDoc) InsertDocumentAt(anchor *Paragraph, other *RootDoc, opts MergeOptions) error {
+	body := rd.Document.Body
+
+	index := -1
+	for i, child := range body.Children {
+		if child.Para != nil && child.Para == anchor {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return errors.New("anchor paragraph not found in the document body")
+	}
+
+	children, sectPr, err := rd.mergeDocument(other, opts)
+	if err != nil {
+		return err
+	}
+
+	if sectPr != nil {
+		// Content ending with a section break already is not given an empty section
+		if current := rd.sectionAt(index); current != nil && index > 0 && !hasSectionBreak(body.Children[index-1].Para) {
+			copied := *current
+			index += rd.sectionBreak(index, &copied)
+		}
+
+		last := len(children) - 1
+		if last >= 0 && children[last].Para != nil && !hasSectionBreak(children[last].Para) {
+			children[last].Para.ensureProp()
+			children[last].Para.ct.Property.SectPr = sectPr
+		} else {
+			p := newParagraph(rd)
+			p.ensureProp()
+			p.ct.Property.SectPr = sectPr
+			children = append(children, DocumentChild{Para: p})
+		}
+	}
+
+	rd.insertBodyChildren(index, children...)
+	rd.renumberCaptions()
+
+	return nil
+}
+
+// sectionAt returns the properties of the section containing the body element at index.
+func (rd *RootDoc) sectionAt(index int) *ctypes.SectionProp {
+	body := rd.Document.Body
+	for i := index; i < len(body.Children); i++ {
+		if p := body.Children[i].Para; hasSectionBreak(p) {
+			return p.ct.Property.SectPr
+		}
+	}
+	return body.SectPr
+}
+
+// sectionBreak ends a section before the body element at index with the given properties.
+// The break is added to the preceding paragraph when possible, otherwise an empty paragraph
+// holding it is inserted. It returns the number of inserted elements.
+func (rd *RootDoc) sectionBreak(index int, sectPr *ctypes.SectionProp) int {
+	body := rd.Document.Body
+
+	if p := body.Children[index-1].Para; p != nil && !hasSectionBreak(p) {
+		p.ensureProp()
+		p.ct.Property.SectPr = sectPr
+		return 0
+	}
+
+	p := newParagraph(rd)
+	p.ensureProp()
+	p.ct.Property.SectPr = sectPr
+
+	children := make([]DocumentChild, 0, len(body.Children)+1)
+	children = append(children, body.Children[:index]...)
+	children = append(children, DocumentChild{Para: p})
+	children = append(children, body.Children[index:]...)
+	body.Children = children
+
+	return 1
+}
+
+func hasSectionBreak(p *Paragraph) bool {
+	return p != nil && p.ct.Property != nil && p.ct.Property.SectPr != nil
+}
+
+// mergeDocument copies the body content of another document and its dependencies into the
+// document. It returns the copied body elements and, unless sections are ignored, the copied
+// final section properties of the other document. The definitions and parts copied before an
+// error are removed again.
+func (rd *RootDoc) mergeDocument(other *RootDoc, opts MergeOptions) ([]DocumentChild, *ctypes.SectionProp, error) {
+	if other == nil || other.Document == nil || other.Document.Body == nil {
+		return nil, nil, errors.New("document to merge has no body")
+	}
+
+	sectPr := other.Document.Body.SectPr
+	if opts.IgnoreSections {
+		sectPr = nil
+	}
+
+	cp := rd.Checkpoint()
+	children, sectPr, err := newMerger(rd, other, opts).merge(other.Document.Body.Children, sectPr)
+	if err != nil {
+		cp.Restore()
+		return nil, nil, err
+	}
+
+	return children, sectPr, nil
+}
+
+// merge copies body elements and section properties of the source document and the content
+// they reference. It returns the copied elements and section properties.
+func (m *merger) merge(children []DocumentChild, sectPr *ctypes.SectionProp) ([]DocumentChild, *ctypes.SectionProp, error) {
+	// The content is copied by encoding it, mapping the references it holds and decoding it
+	// for the destination document
+	src := &Document{Body: &Body{Children: children, SectPr: sectPr}}
+
+	content, err := xml.Marshal(src)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if content, err = m.rewrite(content, m.docRels, nil); err != nil {
+		return nil, nil, err
+	}
+
+	doc, err := LoadDocXml(m.dst, m.dst.Document.relativePath, content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := m.flush(); err != nil {
+		return nil, nil, err
+	}
+
+	if doc.Body == nil {
+		return nil, nil, nil
+	}
+	return doc.Body.Children, doc.Body.SectPr, nil
+}
+
+// merger copies content from a source document to a destination document, copying the
+// definitions and parts the content references on first use.
+type merger struct {
+	dst, src *RootDoc
+	opts     MergeOptions
+
+	// Source IDs mapped to destination IDs
+	styles       map[string]string
+	nums         map[int]int
+	abstractNums map[int]int
+	footnotes    map[int]int
+	endnotes     map[int]int
+	comments     map[string]string
+
+	// parts maps source part names to the names of their copies
+	parts map[string]string
+
+	// docRels copies the relationships of the main document part
+	docRels *relCopier
+
+	// partRels holds the relationships of destination parts other than the main document,
+	// written back by flush
+	partRels map[string]*Relationships
+
+	// Comments are copied as raw XML since the library has no model of the comments part
+	srcComments     map[string][]byte
+	srcCommentsPath string
+	srcCommentsNS   map[string]string
+	srcCommentsRoot xml.StartElement
+	dstComments     []byte
+	dstCommentsPath string
+	nextCommentID   int
+}
+
+func newMerger(dst, src *RootDoc, opts MergeOptions) *merger {
+	m := &merger{
+		dst:          dst,
+		src:          src,
+		opts:         opts,
+		styles:       make(map[string]string),
+		nums:         make(map[int]int),
+		abstractNums: make(map[int]int),
+		footnotes:    make(map[int]int),
+		endnotes:     make(map[int]int),
+		comments:     make(map[string]string),
+		parts:        make(map[string]string),
+		partRels:     make(map[string]*Relationships),
+	}
+
+	m.docRels = &relCopier{
+		m:       m,
+		srcPart: src.mainPartName(),
+		src:     &src.Document.DocRels,
+		dstPart: dst.mainPartName(),
+		dst:     &dst.Document.DocRels,
+		ids:     make(map[string]string),
+		newID: func() string {
+			for {
+				id := "rId" + strconv.Itoa(dst.Document.IncRelationID())
+				if dst.Document.GetRelationByID(id) == nil {
+					return id
+				}
+			}
+		},
+	}
+
+	return m
+}
+
+// mainPartName returns the package path of the main document part.
+func (rd *RootDoc) mainPartName() string {
+	if rd.Document != nil && rd.Document.relativePath != "" {
+		return rd.Document.relativePath
+	}
+	return "word/document.xml"
+}
+
+// flush writes the relationships of copied parts and the comments part to the file map.
+func (m *merger) flush() error {
+	for _, rels := range m.partRels {
+		content, err := marshal(rels)
+		if err != nil {
+			return err
+		}
+		m.dst.FileMap.Store(rels.RelativePath, content)
+	}
+
+	if m.dstComments != nil {
+		m.dst.FileMap.Store(m.dstCommentsPath, m.dstComments)
+	}
+
+	return nil
+}
+
+// isWMLNamespace reports whether a namespace is the WordprocessingML main namespace.
+func isWMLNamespace(ns string) bool {
+	return ns == constants.WMLNamespace || ns == constants.AltWMLNamespace
+}
+
+// isRelNamespace reports whether a namespace is the relationships namespace of r:id attributes.
+func isRelNamespace(ns string) bool {
+	return ns == constants.XMLNS_R || ns == constants.StrictSourceRelationship
+}
+
+// qualifiedName returns the prefixed name of a raw token name, as the encoders of the library
+// write names.
+func qualifiedName(name xml.Name) xml.Name {
+	if name.Space == "" {
+		return name
+	}
+	return xml.Name{Local: name.Space + ":" + name.Local}
+}
+
+// rewrite copies XML content of the source document, mapping the relationship IDs it holds
+// through rels and the style, numbering, note and comment IDs to those of the destination.
+//
+// The content is processed as raw tokens so that markup the library does not model is kept.
+// Namespace prefixes are resolved from the declarations in the content, preceded by those of
+// ns for fragments of a part.
+func (m *merger) rewrite(content []byte, rels *relCopier, ns map[string]string) ([]byte, error) {
+	d := xml.NewDecoder(bytes.NewReader(content))
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+
+	scopes := []map[string]string{ns}
+	lookup := func(prefix string) string {
+		for i := len(scopes) - 1; i >= 0; i-- {
+			if uri, ok := scopes[i][prefix]; ok {
+				return uri
+			}
+		}
+		return ""
+	}
+
+	for {
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			scope := make(map[string]string)
+			for _, attr := range t.Attr {
+				switch {
+				case attr.Name.Space == "xmlns":
+					scope[attr.Name.Local] = attr.Value
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					scope[""] = attr.Value
+				}
+			}
+			scopes = append(scopes, scope)
+
+			start := xml.StartElement{Name: qualifiedName(t.Name)}
+			wml := isWMLNamespace(lookup(t.Name.Space))
+			for _, attr := range t.Attr {
+				value := attr.Value
+				if attr.Name.Space != "" && attr.Name.Space != "xmlns" {
+					switch attrNS := lookup(attr.Name.Space); {
+					case isRelNamespace(attrNS) && rels != nil:
+						value, err = rels.mapID(value)
+					case isWMLNamespace(attrNS) && wml:
+						value, err = m.mapRef(t.Name.Local, attr.Name.Local, value)
+					}
+					if err != nil {
+						return nil, err
+					}
+				}
+				start.Attr = append(start.Attr, xml.Attr{Name: qualifiedName(attr.Name), Value: value})
+			}
+
+			if err := e.EncodeToken(start); err != nil {
+				return nil, err
+			}
+
+		case xml.EndElement:
+			scopes = scopes[:len(scopes)-1]
+			if err := e.EncodeToken(xml.EndElement{Name: qualifiedName(t.Name)}); err != nil {
+				return nil, err
+			}
+
+		default:
+			if err := e.EncodeToken(xml.CopyToken(tok)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mapRef maps the value of a WordprocessingML attribute that references a definition of the
+// source document.
+func (m *merger) mapRef(elem, attr, value string) (string, error) {
+	switch {
+	case attr == "val":
+		switch elem {
+		case "pStyle", "rStyle", "tblStyle", "basedOn", "next", "link", "styleLink", "numStyleLink":
+			return m.mapStyle(value)
+		case "numId":
+			return m.mapNum(value)
+		}
+	case attr == "id":
+		switch elem {
+		case "footnoteReference":
+			return m.mapNote(false, value)
+		case "endnoteReference":
+			return m.mapNote(true, value)
+		case "commentRangeStart", "commentRangeEnd", "commentReference", "comment":
+			return m.mapComment(value)
+		}
+	}
+	return value, nil
+}
+
+// relCopier copies the relationships referenced by a source part to a destination part.
+type relCopier struct {
+	m *merger
+
+	srcPart string
+	src     *Relationships
+	dstPart string
+	dst     *Relationships
+
+	// newID returns an unused relationship ID of the destination part
+	newID func() string
+
+	// ids maps source relationship IDs to the IDs of their copies
+	ids map[string]string
+}
+
+// partCopier returns a copier of the relationships of a source part to a destination part other
+// than the main document. The destination relationships are written back by flush.
+func (m *merger) partCopier(srcPart, dstPart string) (*relCopier, error) {
+	src, err := m.src.PartRelations(srcPart)
+	if err != nil {
+		return nil, err
+	}
+
+	dst, ok := m.partRels[dstPart]
+	if !ok {
+		if dst, err = m.dst.PartRelations(dstPart); err != nil {
+			return nil, err
+		}
+		if dst.Xmlns == "" {
+			dst.Xmlns = constants.XMLNS
+		}
+		m.partRels[dstPart] = dst
+	}
+
+	return &relCopier{
+		m:       m,
+		srcPart: srcPart,
+		src:     src,
+		dstPart: dstPart,
+		dst:     dst,
+		ids:     make(map[string]string),
+		newID: func() string {
+			for n := len(dst.Relationships) + 1; ; n++ {
+				id := "rId" + strconv.Itoa(n)
+				if dst.GetRelationByID(id) == nil {
+					return id
+				}
+			}
+		},
+	}, nil
+}
+
+// mapID returns the ID of the copy of a source relationship, copying the relationship and the
+// part it targets on first use. Unknown IDs are kept.
+func (c *relCopier) mapID(id string) (string, error) {
+	if copied, ok := c.ids[id]; ok {
+		return copied, nil
+	}
+
+	rel := c.src.GetRelationByID(id)
+	if rel == nil {
+		return id, nil
+	}
+
+	copied := &Relationship{
+		Type:       rel.Type,
+		Target:     rel.Target,
+		TargetMode: rel.TargetMode,
+	}
+
+	if rel.TargetMode != "External" {
+		target := rel.Target
+		if strings.HasPrefix(target, "/") {
+			target = target[1:]
+		} else {
+			target = path.Join(path.Dir(c.srcPart), target)
+		}
+
+		partName, err := c.m.copyPart(target)
+		if err != nil {
+			return "", err
+		}
+
+		// Targets are relative to the directory of the part holding the relationship
+		dir := path.Dir(c.dstPart) + "/"
+		if strings.HasPrefix(partName, dir) {
+			copied.Target = strings.TrimPrefix(partName, dir)
+		} else {
+			copied.Target = "/" + partName
+		}
+	}
+
+	// A shared part is reached through the relationship the destination already has
+	for _, existing := range c.dst.Relationships {
+		if existing.Type == copied.Type && existing.Target == copied.Target && existing.TargetMode == copied.TargetMode {
+			c.ids[id] = existing.ID
+			return existing.ID, nil
+		}
+	}
+
+	copied.ID = c.newID()
+	c.dst.Relationships = append(c.dst.Relationships, copied)
+	c.ids[id] = copied.ID
+
+	return copied.ID, nil
+}
+
+// copyPart copies a part of the source package, such as an image or a header, to the
+// destination package and returns the name of the copy. Media files are renamed after the
+// image count of the destination, or share a destination file with the same content. Other
+// parts get the first unused number of their name. XML parts are rewritten with the
+// relationships they use.
+func (m *merger) copyPart(partName string) (string, error) {
+	if copied, ok := m.parts[partName]; ok {
+		return copied, nil
+	}
+
+	raw, ok := m.src.FileMap.Load(partName)
+	if !ok {
+		return "", fmt.Errorf("part %s not found", partName)
+	}
+	content := raw.([]byte)
+
+	ext := path.Ext(partName)
+	var copied string
+	if strings.HasPrefix(partName, constants.MediaPath) {
+		// Media already in the destination package is shared
+		if existing := m.dst.findMedia(content); existing != "" {
+			m.parts[partName] = existing
+			return existing, nil
+		}
+		for {
+			m.dst.ImageCount++
+			copied = fmt.Sprintf("%simage%d%s", constants.MediaPath, m.dst.ImageCount, ext)
+			if _, exists := m.dst.FileMap.Load(copied); !exists {
+				break
+			}
+		}
+	} else {
+		dir, base := path.Split(partName)
+		stem := strings.TrimRight(strings.TrimSuffix(base, ext), "0123456789")
+		for n := 1; ; n++ {
+			copied = fmt.Sprintf("%s%s%d%s", dir, stem, n, ext)
+			if _, exists := m.dst.FileMap.Load(copied); !exists {
+				break
+			}
+		}
+	}
+
+	// The name is reserved before the content is rewritten, which may copy further parts
+	m.parts[partName] = copied
+	m.dst.FileMap.Store(copied, content)
+
+	if contentType, ok := m.src.ContentType.overrideFor("/" + partName); ok {
+		if err := m.dst.ContentType.AddOverride("/"+copied, contentType); err != nil {
+			return "", err
+		}
+	} else if extension := strings.TrimPrefix(ext, "."); extension != "" {
+		if _, ok := m.dst.ContentType.defaultFor(extension); !ok {
+			if contentType, ok := m.src.ContentType.defaultFor(extension); ok {
+				if err := m.dst.ContentType.AddExtension(extension, contentType); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+
+	if strings.EqualFold(ext, ".xml") {
+		rels, err := m.partCopier(partName, copied)
+		if err != nil {
+			return "", err
+		}
+
+		if content, err = m.rewrite(content, rels, nil); err != nil {
+			return "", err
+		}
+		m.dst.FileMap.Store(copied, content)
+
+		if len(rels.dst.Relationships) == 0 {
+			delete(m.partRels, copied)
+		}
+	}
+
+	return copied, nil
+}
+
+// styleIndex returns the index of the style with the given ID, or -1.
+func styleIndex(styles *ctypes.Styles, styleID string) int {
+	if styles == nil {
+		return -1
+	}
+	for i, style := range styles.StyleList {
+		if style.ID != nil && *style.ID == styleID {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameStyle reports whether two style definitions are encoded identically.
+func sameStyle(a, b ctypes.Style) bool {
+	encodedA, errA := xml.Marshal(&a)
+	encodedB, errB := xml.Marshal(&b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+// mapStyle returns the destination ID of a source style, copying the style on first use
+// according to the style conflict policy.
+func (m *merger) mapStyle(styleID string) (string, error) {
+	if mapped, ok := m.styles[styleID]; ok {
+		return mapped, nil
+	}
+
+	srcIndex := styleIndex(m.src.DocStyles, styleID)
+	if srcIndex < 0 {
+		m.styles[styleID] = styleID
+		return styleID, nil
+	}
+	source := m.src.DocStyles.StyleList[srcIndex]
+
+	if m.dst.DocStyles == nil {
+		m.dst.DocStyles = &ctypes.Styles{}
+	}
+	dstIndex := styleIndex(m.dst.DocStyles, styleID)
+
+	switch {
+	case dstIndex < 0:
+		m.styles[styleID] = styleID
+		copied, err := m.copyStyle(source)
+		if err != nil {
+			return "", err
+		}
+		if copied.Type != nil && m.dst.defaultStyle(*copied.Type) != nil {
+			copied.Default = nil
+		}
+		m.dst.DocStyles.StyleList = append(m.dst.DocStyles.StyleList, copied)
+
+	case m.opts.Styles == StyleOverwrite:
+		m.styles[styleID] = styleID
+		copied, err := m.copyStyle(source)
+		if err != nil {
+			return "", err
+		}
+		copied.Default = m.dst.DocStyles.StyleList[dstIndex].Default
+		m.dst.DocStyles.StyleList[dstIndex] = copied
+
+	case m.opts.Styles == StyleKeepSource && !sameStyle(source, m.dst.DocStyles.StyleList[dstIndex]):
+		n := 1
+		for styleIndex(m.dst.DocStyles, fmt.Sprintf("%s_%d", styleID, n)) >= 0 {
+			n++
+		}
+		newID := fmt.Sprintf("%s_%d", styleID, n)
+		m.styles[styleID] = newID
+
+		copied, err := m.copyStyle(source)
+		if err != nil {
+			return "", err
+		}
+		copied.ID = &newID
+		copied.Default = nil
+		if copied.Name != nil {
+			copied.Name = ctypes.NewCTString(fmt.Sprintf("%s_%d", copied.Name.Val, n))
+		}
+		m.dst.DocStyles.StyleList = append(m.dst.DocStyles.StyleList, copied)
+
+	default:
+		m.styles[styleID] = styleID
+	}
+
+	return m.styles[styleID], nil
+}
+
+// copyStyle returns a copy of a source style whose references are mapped to the destination.
+func (m *merger) copyStyle(style ctypes.Style) (ctypes.Style, error) {
+	content, err := xml.Marshal(&ctypes.Styles{Attr: m.src.DocStyles.Attr, StyleList: []ctypes.Style{style}})
+	if err != nil {
+		return style, err
+	}
+
+	if content, err = m.rewrite(content, nil, nil); err != nil {
+		return style, err
+	}
+
+	copied := ctypes.Styles{}
+	if err := xml.Unmarshal(content, &copied); err != nil {
+		return style, err
+	}
+	if len(copied.StyleList) != 1 {
+		return style, fmt.Errorf("style %s could not be copied", *style.ID)
+	}
+
+	return copied.StyleList[0], nil
+}
+
+// mapNum returns the destination ID of a source numbering instance, copying the instance and
+// its abstract definition with new IDs on first use.
+func (m *merger) mapNum(value string) (string, error) {
+	numID, err := strconv.Atoi(value)
+	if err != nil || numID == 0 || m.src.Numbering == nil {
+		return value, nil
+	}
+
+	if mapped, ok := m.nums[numID]; ok {
+		return strconv.Itoa(mapped), nil
+	}
+
+	num := m.src.Numbering.NumByID(numID)
+	if num == nil {
+		return value, nil
+	}
+
+	numbering := m.dst.ensureNumbering()
+	newID := 1
+	for _, existing := range numbering.Nums {
+		if existing.ID >= newID {
+			newID = existing.ID + 1
+		}
+	}
+
+	// The ID is reserved before the instance is copied, which may copy further instances
+	m.nums[numID] = newID
+	numbering.Nums = append(numbering.Nums, ctypes.Num{ID: newID})
+
+	content, err := xml.Marshal(&ctypes.Numbering{Attr: m.src.Numbering.Attr, Nums: []ctypes.Num{*num}})
+	if err != nil {
+		return "", err
+	}
+	copied, err := m.copyNumbering(content)
+	if err != nil {
+		return "", err
+	}
+	if len(copied.Nums) != 1 {
+		return "", fmt.Errorf("numbering %d could not be copied", numID)
+	}
+
+	instance := copied.Nums[0]
+	instance.ID = newID
+	if instance.AbstractNumID != nil {
+		abstractID, err := m.mapAbstractNum(instance.AbstractNumID.Val)
+		if err != nil {
+			return "", err
+		}
+		instance.AbstractNumID = ctypes.NewDecimalNum(abstractID)
+	}
+	*m.dst.Numbering.NumByID(newID) = instance
+
+	return strconv.Itoa(newID), nil
+}
+
+// mapAbstractNum returns the destination ID of a source abstract numbering definition, copying
+// the definition on first use.
+func (m *merger) mapAbstractNum(abstractID int) (int, error) {
+	if mapped, ok := m.abstractNums[abstractID]; ok {
+		return mapped, nil
+	}
+
+	abstract := m.src.Numbering.AbstractNumByID(abstractID)
+	if abstract == nil {
+		return abstractID, nil
+	}
+
+	numbering := m.dst.ensureNumbering()
+	newID := 0
+	for _, existing := range numbering.AbstractNums {
+		if existing.ID >= newID {
+			newID = existing.ID + 1
+		}
+	}
+
+	m.abstractNums[abstractID] = newID
+	numbering.AbstractNums = append(numbering.AbstractNums, ctypes.AbstractNum{ID: newID})
+
+	content, err := xml.Marshal(&ctypes.Numbering{Attr: m.src.Numbering.Attr, AbstractNums: []ctypes.AbstractNum{*abstract}})
+	if err != nil {
+		return 0, err
+	}
+	copied, err := m.copyNumbering(content)
+	if err != nil {
+		return 0, err
+	}
+	if len(copied.AbstractNums) != 1 {
+		return 0, fmt.Errorf("abstract numbering %d could not be copied", abstractID)
+	}
+
+	definition := copied.AbstractNums[0]
+	definition.ID = newID
+	// A new identifier keeps Word from joining the copy with the list it was copied from, and
+	// picture bullets are not copied, so their levels fall back to the level text
+	definition.Nsid = nil
+	for i := range definition.Levels {
+		definition.Levels[i].LvlPicBulletID = nil
+	}
+	*m.dst.Numbering.AbstractNumByID(newID) = definition
+
+	return newID, nil
+}
+
+// copyNumbering decodes encoded numbering definitions with their references mapped to the
+// destination.
+func (m *merger) copyNumbering(content []byte) (*ctypes.Numbering, error) {
+	content, err := m.rewrite(content, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := &ctypes.Numbering{}
+	if err := xml.Unmarshal(content, copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
+
+// mapNote returns the destination ID of a source footnote or endnote, copying the note on first
+// use. The notes part of the destination is created when it has none.
+func (m *merger) mapNote(endnotes bool, value string) (string, error) {
+	noteID, err := strconv.Atoi(value)
+	if err != nil {
+		return value, nil
+	}
+
+	ids, load, relType, contentType, name := m.footnotes, m.src.Footnotes, constants.FootnotesType, constants.FootnotesContentType, "footnotes.xml"
+	if endnotes {
+		ids, load, relType, contentType, name = m.endnotes, m.src.Endnotes, constants.EndnotesType, constants.EndnotesContentType, "endnotes.xml"
+	}
+
+	if mapped, ok := ids[noteID]; ok {
+		return strconv.Itoa(mapped), nil
+	}
+
+	srcNotes, err := load()
+	if err != nil {
+		return "", err
+	}
+	if srcNotes == nil || srcNotes.NoteByID(noteID) == nil {
+		return value, nil
+	}
+	note := srcNotes.NoteByID(noteID)
+
+	dstNotes, err := m.dst.Footnotes()
+	if endnotes {
+		dstNotes, err = m.dst.Endnotes()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if dstNotes == nil {
+		dstNotes = &ctypes.Footnotes{
+			RelativePath: m.dst.partPath(name),
+			Attr:         srcNotes.Attr,
+			Endnotes:     endnotes,
+		}
+		// Word expects the separator notes in every notes part
+		for _, separator := range srcNotes.Notes {
+			if separator.Type != nil {
+				dstNotes.Notes = append(dstNotes.Notes, separator)
+			}
+		}
+
+		m.dst.Document.addRelation(relType, name)
+		if override, ok := m.src.ContentType.overrideFor("/" + srcNotes.RelativePath); ok {
+			contentType = override
+		}
+		if err := m.dst.ContentType.AddOverride("/"+dstNotes.RelativePath, contentType); err != nil {
+			return "", err
+		}
+
+		if endnotes {
+			m.dst.endnotes = dstNotes
+		} else {
+			m.dst.footnotes = dstNotes
+		}
+	}
+
+	newID := 1
+	for _, existing := range dstNotes.Notes {
+		if existing.ID >= newID {
+			newID = existing.ID + 1
+		}
+	}
+	ids[noteID] = newID
+
+	rels, err := m.partCopier(srcNotes.RelativePath, dstNotes.RelativePath)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := xml.Marshal(&ctypes.Footnotes{Attr: srcNotes.Attr, Endnotes: endnotes, Notes: []ctypes.Footnote{*note}})
+	if err != nil {
+		return "", err
+	}
+	if content, err = m.rewrite(content, rels, nil); err != nil {
+		return "", err
+	}
+
+	copied := ctypes.Footnotes{}
+	if err := xml.Unmarshal(content, &copied); err != nil {
+		return "", err
+	}
+	if len(copied.Notes) != 1 {
+		return "", fmt.Errorf("note %d could not be copied", noteID)
+	}
+
+	copied.Notes[0].ID = newID
+	dstNotes.Notes = append(dstNotes.Notes, copied.Notes[0])
+
+	return strconv.Itoa(newID), nil
+}
+
+// mapComment returns the destination ID of a source comment, copying the comment on first use.
+// The comments part of the destination is created when it has none.
+func (m *merger) mapComment(value string) (string, error) {
+	if mapped, ok := m.comments[value]; ok {
+		return mapped, nil
+	}
+
+	if m.srcComments == nil {
+		if err := m.loadComments(); err != nil {
+			return "", err
+		}
+	}
+
+	comment, ok := m.srcComments[value]
+	if !ok {
+		return value, nil
+	}
+
+	if m.dstComments == nil {
+		if err := m.openComments(); err != nil {
+			return "", err
+		}
+	}
+
+	newID := strconv.Itoa(m.nextCommentID)
+	m.nextCommentID++
+	m.comments[value] = newID
+
+	rels, err := m.partCopier(m.srcCommentsPath, m.dstCommentsPath)
+	if err != nil {
+		return "", err
+	}
+	if comment, err = m.rewrite(comment, rels, m.srcCommentsNS); err != nil {
+		return "", err
+	}
+
+	end := bytes.LastIndex(m.dstComments, []byte("</"))
+	if end < 0 {
+		return "", fmt.Errorf("comments part %s has no end tag", m.dstCommentsPath)
+	}
+
+	merged := make([]byte, 0, len(m.dstComments)+len(comment))
+	merged = append(merged, m.dstComments[:end]...)
+	merged = append(merged, comment...)
+	merged = append(merged, m.dstComments[end:]...)
+	m.dstComments = merged
+
+	return newID, nil
+}
+
+// commentsPart returns the name and content of the comments part of a document, or "" if the
+// document has none.
+func (rd *RootDoc) commentsPart() (string, []byte) {
+	for _, rel := range rd.Document.DocRels.Relationships {
+		if rel.Type != constants.SourceRelationshipComments {
+			continue
+		}
+		partName := rd.partPath(rel.Target)
+		if content, ok := rd.FileMap.Load(partName); ok {
+			return partName, content.([]byte)
+		}
+	}
+	return "", nil
+}
+
+// loadComments indexes the raw comment elements of the source comments part by ID.
+func (m *merger) loadComments() error {
+	m.srcComments = make(map[string][]byte)
+
+	partName, content := m.src.commentsPart()
+	if content == nil {
+		return nil
+	}
+	m.srcCommentsPath = partName
+
+	d := xml.NewDecoder(bytes.NewReader(content))
+	depth := 0
+	var (
+		start int64
+		id    string
+	)
+	for {
+		offset := d.InputOffset()
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+			switch {
+			case depth == 1:
+				m.srcCommentsNS = make(map[string]string)
+				m.srcCommentsRoot = xml.StartElement{Name: qualifiedName(t.Name)}
+				for _, attr := range t.Attr {
+					if attr.Name.Space == "xmlns" {
+						m.srcCommentsNS[attr.Name.Local] = attr.Value
+					}
+					m.srcCommentsRoot.Attr = append(m.srcCommentsRoot.Attr, xml.Attr{Name: qualifiedName(attr.Name), Value: attr.Value})
+				}
+			case depth == 2 && t.Name.Local == "comment":
+				start, id = offset, ""
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "id" {
+						id = attr.Value
+					}
+				}
+			}
+		case xml.EndElement:
+			if depth == 2 && t.Name.Local == "comment" && id != "" {
+				m.srcComments[id] = content[start:d.InputOffset()]
+			}
+			depth--
+		}
+	}
+}
+
+// openComments loads the comments part of the destination, creating it with the root element
+// of the source part when the destination has none.
+func (m *merger) openComments() error {
+	partName, content := m.dst.commentsPart()
+	if content != nil {
+		m.dstCommentsPath = partName
+		m.dstComments = content
+
+		d := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			tok, err := d.RawToken()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if t, ok := tok.(xml.StartElement); ok && t.Name.Local == "comment" {
+				for _, attr := range t.Attr {
+					if id, err := strconv.Atoi(attr.Value); err == nil && attr.Name.Local == "id" && id >= m.nextCommentID {
+						m.nextCommentID = id + 1
+					}
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Write(constants.XMLHeader)
+	e := xml.NewEncoder(&buf)
+	if err := e.EncodeToken(m.srcCommentsRoot); err != nil {
+		return err
+	}
+	if err := e.EncodeToken(m.srcCommentsRoot.End()); err != nil {
+		return err
+	}
+	if err := e.Flush(); err != nil {
+		return err
+	}
+
+	m.dstCommentsPath = m.dst.partPath("comments.xml")
+	m.dstComments = buf.Bytes()
+	m.dst.Document.addRelation(constants.SourceRelationshipComments, "comments.xml")
+
+	contentType := constants.CommentsContentType
+	if override, ok := m.src.ContentType.overrideFor("/" + m.srcCommentsPath); ok {
+		contentType = override
+	}
+	return m.dst.ContentType.AddOverride("/"+m.dstCommentsPath, contentType)
+}
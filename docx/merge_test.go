@@ -0,0 +1,275 @@
+package docx
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mergeNS = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+
+// setupMergeDocs returns a destination document and a source document whose content uses a
+// style, a list, a link, an image, a header with its own image, a footnote and a comment.
+func setupMergeDocs(t *testing.T) (dst, src *RootDoc) {
+	t.Helper()
+
+	dst = setupStyledRootDoc(t)
+	dst.AddListDefinition(ListLevel{Format: stypes.NumFmtBullet})
+	_, err := dst.AddParagraph("Intro").AddPictureBytes([]byte("dst-png"), ".png", 1, 1)
+	require.NoError(t, err)
+	dst.FileMap.Store("word/header1.xml", []byte(`<w:hdr `+mergeNS+`><w:p/></w:hdr>`))
+	dst.Document.DocRels.Relationships = append(dst.Document.DocRels.Relationships,
+		&Relationship{ID: "rIdDstHeader", Type: constants.HeaderType, Target: "header1.xml"})
+	dst.Document.Body.SectPr = &ctypes.SectionProp{HeaderReference: &ctypes.HeaderReference{Type: stypes.HdrFtrDefault, ID: "rIdDstHeader"}}
+
+	src = setupStyledRootDoc(t)
+	src.DocStyles.StyleList[1].RunProp.Color = ctypes.NewColor("0000FF")
+
+	src.AddParagraph("Clause").Style("Heading1")
+	numID := src.AddListDefinition(ListLevel{Format: stypes.NumFmtDecimal, Start: 3})
+	src.AddParagraph("Item").Numbering(numID, 0)
+
+	p := src.AddParagraph("See ")
+	p.AddLink("terms", "https://example.com/terms")
+	_, err = p.AddPictureBytes([]byte("src-png"), ".png", 1, 1)
+	require.NoError(t, err)
+
+	src.FileMap.Store("word/footnotes.xml", []byte(`<w:footnotes `+mergeNS+`>`+
+		`<w:footnote w:type="separator" w:id="-1"><w:p><w:r><w:separator/></w:r></w:p></w:footnote>`+
+		`<w:footnote w:id="1"><w:p><w:r><w:t>Note</w:t></w:r></w:p></w:footnote></w:footnotes>`))
+	src.FileMap.Store("word/comments.xml", []byte(`<w:comments `+mergeNS+`>`+
+		`<w:comment w:id="0" w:author="A"><w:p><w:r><w:t>Check</w:t></w:r></w:p></w:comment></w:comments>`))
+	src.FileMap.Store("word/header1.xml", []byte(`<w:hdr `+mergeNS+`><w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr>`+
+		`<w:r><w:drawing><a:blip xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" r:embed="rId1"/></w:drawing></w:r></w:p></w:hdr>`))
+	src.FileMap.Store("word/_rels/header1.xml.rels", []byte(`<Relationships xmlns="`+constants.XMLNS+`">`+
+		`<Relationship Id="rId1" Type="`+constants.SourceRelationshipImage+`" Target="media/logo.png"/></Relationships>`))
+	src.FileMap.Store("word/media/logo.png", []byte("logo-png"))
+	src.Document.DocRels.Relationships = append(src.Document.DocRels.Relationships,
+		&Relationship{ID: "rIdNotes", Type: constants.FootnotesType, Target: "footnotes.xml"},
+		&Relationship{ID: "rIdComments", Type: constants.SourceRelationshipComments, Target: "comments.xml"},
+		&Relationship{ID: "rIdHeader", Type: constants.HeaderType, Target: "header1.xml"},
+	)
+	src.Document.Body.SectPr = &ctypes.SectionProp{HeaderReference: &ctypes.HeaderReference{Type: stypes.HdrFtrDefault, ID: "rIdHeader"}}
+
+	noted := src.AddParagraph("Noted")
+	noted.GetCT().Children = append(noted.GetCT().Children, ctypes.ParagraphChild{Run: &ctypes.Run{
+		Children: []ctypes.RunChild{{FootnoteReference: &ctypes.FootnoteReference{ID: 1}}, {CmntRef: &ctypes.Markup{ID: 0}}},
+	}})
+
+	return dst, src
+}
+
+func TestAppendDocument(t *testing.T) {
+	dst, src := setupMergeDocs(t)
+
+	require.NoError(t, dst.AppendDocument(src, MergeOptions{Styles: StyleKeepSource}))
+
+	children := dst.Document.Body.Children
+	require.Len(t, children, 5)
+
+	// The existing content keeps its section, the appended content takes the source section
+	assert.Equal(t, "rIdDstHeader", children[0].Para.GetCT().Property.SectPr.HeaderReference.ID)
+	headerRel := dst.Document.GetRelationByID(dst.Document.Body.SectPr.HeaderReference.ID)
+	require.NotNil(t, headerRel)
+	assert.Equal(t, "header2.xml", headerRel.Target)
+	contentType, ok := dst.ContentType.partContentType("/word/media/image3.png")
+	assert.True(t, ok)
+	assert.Equal(t, "image/png", contentType)
+
+	// Differing styles are copied under a new ID, identical ones are shared
+	heading := children[1].Para.GetCT()
+	assert.Equal(t, "Heading1_1", heading.Property.Style.Val)
+	copied := dst.GetStyleByID("Heading1_1", stypes.StyleTypeParagraph)
+	require.NotNil(t, copied)
+	assert.Equal(t, "heading 1_1", copied.Name.Val)
+	assert.Equal(t, "Normal", copied.BasedOn.Val)
+	assert.Equal(t, "0000FF", copied.RunProp.Color.Val)
+	assert.Nil(t, dst.GetStyleByID("Heading1", stypes.StyleTypeParagraph).RunProp.Color)
+
+	// Lists get new numbering definitions
+	numPr := children[2].Para.GetCT().Property.NumProp
+	assert.Equal(t, 2, numPr.NumID.Val)
+	level := dst.Numbering.Level(2, 0)
+	require.NotNil(t, level)
+	assert.Equal(t, stypes.NumFmtDecimal, level.NumFmt.Val)
+	assert.Equal(t, 3, level.Start.Val)
+	assert.Len(t, dst.Numbering.AbstractNums, 2)
+
+	// Links and images point to copied relationships and media
+	linked := children[3].Para.GetCT()
+	linkRel := dst.Document.GetRelationByID(linked.Children[1].Link.ID)
+	require.NotNil(t, linkRel)
+	assert.Equal(t, "https://example.com/terms", linkRel.Target)
+	assert.Equal(t, "External", linkRel.TargetMode)
+
+	blip := linked.Children[2].Run.Children[0].Drawing.Inline[0].Graphic.Data.Pic.BlipFill.Blip
+	imageRel := dst.Document.GetRelationByID(blip.EmbedID)
+	require.NotNil(t, imageRel)
+	assert.Equal(t, "media/image3.png", imageRel.Target)
+	image, ok := dst.ReadPart("media/image3.png")
+	require.True(t, ok)
+	assert.Equal(t, "src-png", string(image))
+
+	// Headers are copied with their own relationships
+	header, ok := dst.ReadPart("header2.xml")
+	require.True(t, ok)
+	assert.Contains(t, string(header), `<w:pStyle w:val="Heading1_1">`)
+	headerRels, err := dst.PartRelations("word/header2.xml")
+	require.NoError(t, err)
+	require.Len(t, headerRels.Relationships, 1)
+	assert.Equal(t, "media/image4.png", headerRels.Relationships[0].Target)
+	assert.Contains(t, string(header), `r:embed="`+headerRels.Relationships[0].ID+`"`)
+
+	// Notes and comments are copied into parts created for them
+	run := children[4].Para.GetCT().Children[1].Run
+	assert.Equal(t, 1, run.Children[0].FootnoteReference.ID)
+	assert.Equal(t, 0, run.Children[1].CmntRef.ID)
+
+	notes, err := dst.Footnotes()
+	require.NoError(t, err)
+	require.NotNil(t, notes)
+	require.Len(t, notes.Notes, 2)
+	assert.Equal(t, 1, notes.Notes[1].ID)
+
+	comments, ok := dst.ReadPart("comments.xml")
+	require.True(t, ok)
+	assert.Contains(t, string(comments), `<w:comment w:id="0" w:author="A">`)
+	assert.Contains(t, dst.ContentType.Override, Override{PartName: "/word/comments.xml", ContentType: constants.CommentsContentType})
+
+	// The source document is left unchanged
+	assert.Equal(t, "Heading1", src.Document.Body.Children[0].Para.GetCT().Property.Style.Val)
+}
+
+func TestAppendDocument_StyleConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   StyleConflict
+		styleID  string
+		color    string
+		numStyle int
+	}{
+		{"UseDestination", StyleUseDestination, "Heading1", "", 4},
+		{"KeepSource", StyleKeepSource, "Heading1_1", "0000FF", 5},
+		{"Overwrite", StyleOverwrite, "Heading1", "0000FF", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst, src := setupMergeDocs(t)
+
+			require.NoError(t, dst.AppendDocument(src, MergeOptions{Styles: tt.policy}))
+
+			assert.Equal(t, tt.styleID, dst.Document.Body.Children[1].Para.GetCT().Property.Style.Val)
+			style := dst.GetStyleByID(tt.styleID, stypes.StyleTypeParagraph)
+			require.NotNil(t, style)
+			if tt.color == "" {
+				assert.Nil(t, style.RunProp.Color)
+			} else {
+				assert.Equal(t, tt.color, style.RunProp.Color.Val)
+			}
+			assert.Len(t, dst.DocStyles.StyleList, tt.numStyle)
+		})
+	}
+}
+
+func TestInsertDocumentAt(t *testing.T) {
+	dst, src := setupMergeDocs(t)
+	dst.AddParagraph("Outro")
+	anchor := dst.Document.Body.Children[1].Para
+
+	require.NoError(t, dst.InsertDocumentAt(anchor, src, MergeOptions{}))
+
+	var texts []string
+	for _, child := range dst.Document.Body.Children {
+		var sb strings.Builder
+		for _, pc := range child.Para.GetCT().Children {
+			if pc.Run != nil {
+				for _, rc := range pc.Run.Children {
+					if rc.Text != nil {
+						sb.WriteString(rc.Text.Text)
+					}
+				}
+			}
+		}
+		texts = append(texts, sb.String())
+	}
+	assert.Equal(t, []string{"Intro", "Clause", "Item", "See ", "Noted", "Outro"}, texts)
+
+	children := dst.Document.Body.Children
+	assert.Equal(t, "rIdDstHeader", children[0].Para.GetCT().Property.SectPr.HeaderReference.ID)
+	inserted := children[4].Para.GetCT().Property.SectPr
+	require.NotNil(t, inserted)
+	assert.Equal(t, "header2.xml", dst.Document.GetRelationByID(inserted.HeaderReference.ID).Target)
+	assert.Equal(t, "rIdDstHeader", dst.Document.Body.SectPr.HeaderReference.ID)
+
+	// Content inserted right after a section break does not get an empty section before it
+	require.NoError(t, dst.InsertDocumentAt(children[5].Para, src, MergeOptions{}))
+	children = dst.Document.Body.Children
+	require.Len(t, children, 10)
+	assert.Same(t, inserted, children[4].Para.GetCT().Property.SectPr)
+	assert.Nil(t, children[5].Para.GetCT().Property.SectPr)
+	assert.NotNil(t, children[8].Para.GetCT().Property.SectPr)
+
+	assert.Error(t, dst.InsertDocumentAt(newParagraph(dst), src, MergeOptions{}))
+}
+
+func TestAppendDocument_ErrorKeepsDocument(t *testing.T) {
+	dst, src := setupMergeDocs(t)
+	// The header of the source section is copied last and refers to a missing image
+	src.FileMap.Delete("word/media/logo.png")
+
+	files := func() []string {
+		var names []string
+		dst.FileMap.Range(func(key, _ any) bool {
+			names = append(names, key.(string))
+			return true
+		})
+		sort.Strings(names)
+		return names
+	}
+	before := files()
+	children := len(dst.Document.Body.Children)
+	styles := len(dst.DocStyles.StyleList)
+	nums := len(dst.Numbering.Nums)
+	rels := len(dst.Document.DocRels.Relationships)
+	contentTypes := dst.ContentType
+
+	for _, insert := range []bool{false, true} {
+		var err error
+		if insert {
+			err = dst.InsertDocumentAt(dst.Document.Body.Children[0].Para, src, MergeOptions{Styles: StyleKeepSource})
+		} else {
+			err = dst.AppendDocument(src, MergeOptions{Styles: StyleKeepSource})
+		}
+		require.Error(t, err)
+
+		assert.Equal(t, before, files())
+		assert.Len(t, dst.Document.Body.Children, children)
+		assert.Len(t, dst.DocStyles.StyleList, styles)
+		assert.Len(t, dst.Numbering.Nums, nums)
+		assert.Len(t, dst.Document.DocRels.Relationships, rels)
+		assert.Equal(t, contentTypes, dst.ContentType)
+		notes, err := dst.Footnotes()
+		require.NoError(t, err)
+		assert.Nil(t, notes)
+	}
+}
+
+func TestAppendDocument_IgnoreSections(t *testing.T) {
+	dst, src := setupMergeDocs(t)
+
+	require.NoError(t, dst.AppendDocument(src, MergeOptions{IgnoreSections: true}))
+
+	assert.Len(t, dst.Document.Body.Children, 5)
+	assert.Nil(t, dst.Document.Body.Children[0].Para.GetCT().Property)
+	assert.Equal(t, "rIdDstHeader", dst.Document.Body.SectPr.HeaderReference.ID)
+	_, ok := dst.FileMap.Load("word/header2.xml")
+	assert.False(t, ok)
+}
@@ -0,0 +1,94 @@
+package docx
+
+import (
+	"encoding/xml"
+	"path"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
+)
+
+// Footnotes returns the footnotes part of the document.
+//
+// The part is decoded on first use and cached on the RootDoc; changes made to the returned
+// value are written back when the document is saved. It returns nil without error if the
+// document has no footnotes part.
+func (rd *RootDoc) Footnotes() (*ctypes.Footnotes, error) {
+	if rd.footnotes == nil {
+		notes, err := rd.loadNotes(constants.FootnotesType)
+		if err != nil {
+			return nil, err
+		}
+		rd.footnotes = notes
+	}
+
+	return rd.footnotes, nil
+}
+
+// Endnotes returns the endnotes part of the document.
+//
+// It behaves like Footnotes for the endnotes part.
+func (rd *RootDoc) Endnotes() (*ctypes.Footnotes, error) {
+	if rd.endnotes == nil {
+		notes, err := rd.loadNotes(constants.EndnotesType)
+		if err != nil {
+			return nil, err
+		}
+		rd.endnotes = notes
+	}
+
+	return rd.endnotes, nil
+}
+
+// loadNotes decodes the footnotes or endnotes part targeted by the given relationship type.
+func (rd *RootDoc) loadNotes(relType string) (*ctypes.Footnotes, error) {
+	if rd.Document == nil {
+		return nil, nil
+	}
+
+	for _, rel := range rd.Document.DocRels.Relationships {
+		if rel.Type != relType {
+			continue
+		}
+
+		partName := rd.partPath(rel.Target)
+		content, ok := rd.FileMap.Load(partName)
+		if !ok {
+			return nil, nil
+		}
+
+		notes := &ctypes.Footnotes{}
+		if err := xml.Unmarshal(content.([]byte), notes); err != nil {
+			return nil, err
+		}
+		notes.RelativePath = partName
+
+		return notes, nil
+	}
+
+	return nil, nil
+}
+
+// partPath resolves a relationship target of the main document part to its path within the package.
+func (rd *RootDoc) partPath(target string) string {
+	if path.IsAbs(target) {
+		return target[1:]
+	}
+
+	docDir := "word"
+	if rd.Document != nil && rd.Document.relativePath != "" {
+		docDir = path.Dir(rd.Document.relativePath)
+	}
+
+	return path.Join(docDir, target)
+}
+
+// ReadPart returns the raw content of a document relationship target, such as "media/image1.png".
+func (rd *RootDoc) ReadPart(target string) ([]byte, bool) {
+	content, ok := rd.FileMap.Load(rd.partPath(target))
+	if !ok {
+		return nil, false
+	}
+
+	return content.([]byte), true
+}
@@ -0,0 +1,128 @@
+package docx
+
+import (
+	"fmt"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/internal"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// ListIndentStep is the indentation, in twips, added for each list level created by AddListDefinition.
+const ListIndentStep = 720
+
+// bulletSymbols are the bullet characters used for successive bullet list levels.
+var bulletSymbols = []string{"•", "◦", "▪"}
+
+// ListLevel describes one level of a list definition created with AddListDefinition.
+type ListLevel struct {
+	// Format is the numbering format of the level, such as stypes.NumFmtBullet or stypes.NumFmtDecimal.
+	Format stypes.NumFmt
+
+	// Text is the level text, such as "•" or "%1."; it is derived from Format when empty.
+	Text string
+
+	// Start is the first number of the level; 1 is used when zero.
+	Start int
+}
+
+// AddListDefinition adds a multi-level list definition to the numbering part and returns the
+// numbering ID to use with Paragraph.Numbering.
+//
+// Each call creates a new abstract numbering definition and a numbering instance referencing
+// it, so lists created from separate calls are numbered independently. The numbering part is
+// created when the document does not have one yet.
+//
+// Parameters:
+//   - levels: The definitions of the list levels, starting with level 0. At most nine levels are used.
+//
+// Returns:
+//   - int: The numbering ID of the new list.
+//
+// Example:
+//
+//	numID := document.AddListDefinition(docx.ListLevel{Format: stypes.NumFmtDecimal}, docx.ListLevel{Format: stypes.NumFmtBullet})
+//	document.AddParagraph("First item").Numbering(numID, 0)
+func (rd *RootDoc) AddListDefinition(levels ...ListLevel) int {
+	numbering := rd.ensureNumbering()
+
+	abstractID := 0
+	for _, abstract := range numbering.AbstractNums {
+		if abstract.ID >= abstractID {
+			abstractID = abstract.ID + 1
+		}
+	}
+
+	numID := 1
+	for _, num := range numbering.Nums {
+		if num.ID >= numID {
+			numID = num.ID + 1
+		}
+	}
+
+	if len(levels) > 9 {
+		levels = levels[:9]
+	}
+
+	abstract := ctypes.AbstractNum{
+		ID:             abstractID,
+		MultiLevelType: ctypes.NewCTString("hybridMultilevel"),
+	}
+
+	for i, level := range levels {
+		if level.Format == "" {
+			level.Format = stypes.NumFmtBullet
+		}
+
+		if level.Text == "" {
+			if level.Format == stypes.NumFmtBullet {
+				level.Text = bulletSymbols[i%len(bulletSymbols)]
+			} else {
+				level.Text = fmt.Sprintf("%%%d.", i+1)
+			}
+		}
+
+		if level.Start == 0 {
+			level.Start = 1
+		}
+
+		abstract.Levels = append(abstract.Levels, ctypes.NumLevel{
+			ILvl:    i,
+			Start:   ctypes.NewDecimalNum(level.Start),
+			NumFmt:  ctypes.NewGenSingleStrVal(level.Format),
+			LvlText: ctypes.NewCTString(level.Text),
+			LvlJc:   ctypes.NewGenSingleStrVal(stypes.JustificationLeft),
+			ParaProp: &ctypes.ParagraphProp{
+				Indent: &ctypes.Indent{
+					Left:    internal.ToPtr(ListIndentStep * (i + 1)),
+					Hanging: internal.ToPtr(uint64(ListIndentStep / 2)),
+				},
+			},
+		})
+	}
+
+	numbering.AbstractNums = append(numbering.AbstractNums, abstract)
+	numbering.Nums = append(numbering.Nums, ctypes.Num{
+		ID:            numID,
+		AbstractNumID: ctypes.NewDecimalNum(abstractID),
+	})
+
+	return numID
+}
+
+// ensureNumbering returns the numbering part of the document, creating the part, its
+// relationship and its content type override when the document has none.
+func (rd *RootDoc) ensureNumbering() *ctypes.Numbering {
+	if rd.Numbering != nil {
+		return rd.Numbering
+	}
+
+	rd.Numbering = ctypes.NewNumbering()
+	rd.Numbering.RelativePath = rd.partPath("numbering.xml")
+
+	rd.Document.addRelation(constants.NumberingType, "numbering.xml")
+	_ = rd.ContentType.AddOverride("/"+rd.Numbering.RelativePath, constants.NumberingContentType)
+
+	return rd.Numbering
+}
@@ -0,0 +1,44 @@
+package docx
+
+import (
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddListDefinition(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	numID := rd.AddListDefinition(ListLevel{Format: stypes.NumFmtDecimal, Start: 4}, ListLevel{})
+	assert.Equal(t, 1, numID)
+
+	// The numbering part is created on first use
+	require.NotNil(t, rd.Numbering)
+	assert.Equal(t, "word/numbering.xml", rd.Numbering.RelativePath)
+	require.Len(t, rd.Document.DocRels.Relationships, 1)
+	assert.Equal(t, constants.NumberingType, rd.Document.DocRels.Relationships[0].Type)
+	assert.Contains(t, rd.ContentType.Override, Override{PartName: "/word/numbering.xml", ContentType: constants.NumberingContentType})
+
+	first := rd.Numbering.Level(numID, 0)
+	require.NotNil(t, first)
+	assert.Equal(t, stypes.NumFmtDecimal, first.NumFmt.Val)
+	assert.Equal(t, "%1.", first.LvlText.Val)
+	assert.Equal(t, 4, first.Start.Val)
+
+	second := rd.Numbering.Level(numID, 1)
+	require.NotNil(t, second)
+	assert.Equal(t, stypes.NumFmtBullet, second.NumFmt.Val)
+	assert.Equal(t, "◦", second.LvlText.Val)
+	assert.Equal(t, ListIndentStep*2, *second.ParaProp.Indent.Left)
+
+	// Further lists get a new definition and are numbered independently
+	other := rd.AddListDefinition(ListLevel{Format: stypes.NumFmtBullet})
+	assert.Equal(t, 2, other)
+	assert.Len(t, rd.Numbering.AbstractNums, 2)
+	assert.Equal(t, &ctypes.DecimalNum{Val: 1}, rd.Numbering.NumByID(other).AbstractNumID)
+	assert.Len(t, rd.Document.DocRels.Relationships, 1)
+}
@@ -0,0 +1,129 @@
+package docx
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FormatNumber formats a number with a numeric picture as used by the \# field switch, such
+// as "#,##0.00" or "$#,##0.00;($#,##0.00);-".
+//
+// The picture supports the digit placeholders 0 (digit or zero) and # (digit or nothing), a
+// decimal point, the grouping separator, literal text in quotes or around the placeholders,
+// and up to three sections separated by semicolons for positive, negative and zero values.
+// Negative values formatted with the positive section are prefixed with a minus sign.
+func FormatNumber(value float64, picture string) string {
+	sections := splitPicture(picture)
+
+	section := sections[0]
+	negative := value < 0
+	switch {
+	case value == 0 && len(sections) > 2:
+		section = sections[2]
+	case negative && len(sections) > 1:
+		section = sections[1]
+		negative = false
+	}
+	value = math.Abs(value)
+
+	// The number part runs from the first to the last placeholder, separators included
+	first := strings.IndexAny(section, "0#")
+	if first < 0 {
+		return unquotePicture(section)
+	}
+	last := strings.LastIndexAny(section, "0#")
+	if dot := strings.IndexByte(section[first:], '.'); dot >= 0 && first+dot > last {
+		last = first + dot
+	}
+	prefix := unquotePicture(section[:first])
+	suffix := unquotePicture(section[last+1:])
+	number := section[first : last+1]
+
+	intPart, fracPart := number, ""
+	if dot := strings.IndexByte(number, '.'); dot >= 0 {
+		intPart, fracPart = number[:dot], number[dot+1:]
+	}
+
+	required := strings.Count(fracPart, "0")
+	decimals := strings.Count(fracPart, "0") + strings.Count(fracPart, "#")
+	digits := strconv.FormatFloat(value, 'f', decimals, 64)
+
+	whole, frac := digits, ""
+	if dot := strings.IndexByte(digits, '.'); dot >= 0 {
+		whole, frac = digits[:dot], digits[dot+1:]
+	}
+	for len(frac) > required && strings.HasSuffix(frac, "0") {
+		frac = frac[:len(frac)-1]
+	}
+
+	minWhole := strings.Count(intPart, "0")
+	if whole == "0" && minWhole == 0 {
+		whole = ""
+	}
+	for len(whole) < minWhole {
+		whole = "0" + whole
+	}
+	if strings.Contains(intPart, ",") {
+		whole = groupDigits(whole)
+	}
+
+	var sb strings.Builder
+	if negative {
+		sb.WriteByte('-')
+	}
+	sb.WriteString(prefix)
+	sb.WriteString(whole)
+	if frac != "" {
+		sb.WriteByte('.')
+		sb.WriteString(frac)
+	}
+	sb.WriteString(suffix)
+	return sb.String()
+}
+
+// splitPicture splits a numeric picture into its sections, ignoring quoted semicolons.
+func splitPicture(picture string) []string {
+	var sections []string
+	var quote rune
+	start := 0
+	for i, r := range picture {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ';':
+			sections = append(sections, picture[start:i])
+			start = i + 1
+		}
+	}
+	return append(sections, picture[start:])
+}
+
+// unquotePicture returns literal picture text without its quotes.
+func unquotePicture(text string) string {
+	return strings.NewReplacer("'", "", `"`, "").Replace(text)
+}
+
+// groupDigits inserts a comma between groups of three digits.
+func groupDigits(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var sb strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		sb.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if sb.Len() > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
@@ -0,0 +1,32 @@
+package docx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		value    float64
+		picture  string
+		expected string
+	}{
+		{1234.5, "#,##0.00", "1,234.50"},
+		{1234567, "#,##0", "1,234,567"},
+		{0.5, "0.00", "0.50"},
+		{0.5, "#.##", ".5"},
+		{7, "000", "007"},
+		{-1234.5, "#,##0.00", "-1,234.50"},
+		{-12, "$#,##0.00;($#,##0.00)", "($12.00)"},
+		{0, "#,##0;-#,##0;'zero'", "zero"},
+		{19.999, "0.0 'kg'", "20.0 kg"},
+		{42, "'No.' 0", "No. 42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.picture, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatNumber(tt.value, tt.picture))
+		})
+	}
+}
@@ -250,27 +250,35 @@ func (p *Paragraph) GetStyle() (*ctypes.Style, error) {
 func (p *Paragraph) AddLink(text string, link string) *Hyperlink {
 	rId := p.root.Document.addLinkRelation(link)
 
-	runChildren := []ctypes.RunChild{}
-	runChildren = append(runChildren, ctypes.RunChild{
-		Text: ctypes.TextFromString(text),
-	})
-	run := &ctypes.Run{
-		Children: runChildren,
-		Property: &ctypes.RunProperty{
-			Style: &ctypes.CTString{
-				Val: constants.HyperLinkStyle,
-			},
-		},
-	}
+	return p.addHyperlink(&ctypes.Hyperlink{ID: rId}, text)
+}
 
-	hyperLink := &ctypes.Hyperlink{
-		ID:  rId,
-		Run: run,
-	}
+// AddInternalLink adds a hyperlink to a bookmark of the document, such as one added with
+// AddBookmark. Following the link moves to the bookmark instead of opening an address.
+//
+// Parameters:
+//   - text: The text shown for the link.
+//   - bookmark: The name of the bookmark the link points to.
+//
+// Returns:
+//   - *Hyperlink: The added hyperlink.
+func (p *Paragraph) AddInternalLink(text string, bookmark string) *Hyperlink {
+	return p.addHyperlink(&ctypes.Hyperlink{
+		Anchor:  bookmark,
+		History: ctypes.OnOffFromBool(true),
+	}, text)
+}
 
+// addHyperlink appends the hyperlink to the paragraph with a run of text in the hyperlink
+// character style.
+func (p *Paragraph) addHyperlink(hyperLink *ctypes.Hyperlink, text string) *Hyperlink {
 	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Link: hyperLink})
 
-	return newHyperlink(p.root, hyperLink)
+	link := newHyperlink(p.root, hyperLink)
+	link.owner = p
+	link.AddText(text)
+
+	return link
 }
 
 // AddDrawing adds a new drawing (image) to the Paragraph.
@@ -278,15 +286,22 @@ func (p *Paragraph) AddLink(text string, link string) *Hyperlink {
 // Parameters:
 //   - rID: The relationship ID of the image in the document.
 //   - imgCount: The count of images in the document.
-//   - width: The width of the image in inches.
-//   - height: The height of the image in inches.
+//   - width: The width of the image in EMUs.
+//   - height: The height of the image in EMUs.
 //
 // Returns:
 //   - *dml.Inline: The created Inline instance representing the added drawing.
-func (p *Paragraph) addDrawing(rID string, imgCount uint, width units.Inch, height units.Inch) *dml.Inline {
-	eWidth := width.ToEmu()
-	eHeight := height.ToEmu()
+func (p *Paragraph) addDrawing(rID string, imgCount uint, width units.Emu, height units.Emu) *dml.Inline {
+	run, inline := newDrawingRun(rID, imgCount, width, height)
+
+	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Run: run})
+
+	return inline
+}
 
+// newDrawingRun returns a run holding an inline drawing of the image with the relationship ID
+// rID, and the inline element of the drawing.
+func newDrawingRun(rID string, imgCount uint, eWidth units.Emu, eHeight units.Emu) (*ctypes.Run, *dml.Inline) {
 	inline := dml.NewInline(
 		*dmlct.NewPostvSz2D(eWidth, eHeight),
 		dml.DocProp{
@@ -309,9 +324,7 @@ func (p *Paragraph) addDrawing(rID string, imgCount uint, width units.Inch, heig
 		Children: runChildren,
 	}
 
-	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Run: run})
-
-	return &inline
+	return run, &drawing.Inline[0]
 }
 
 func (p *Paragraph) AddPicture(path string, width units.Inch, height units.Inch) (*PicMeta, error) {
@@ -321,38 +334,70 @@ func (p *Paragraph) AddPicture(path string, width units.Inch, height units.Inch)
 		return nil, err
 	}
 
-	imgExt := filepath.Ext(path)
-	p.root.ImageCount += 1
-	fileName := fmt.Sprintf("image%d%s", p.root.ImageCount, imgExt)
-	fileIdxPath := fmt.Sprintf("%s%s", constants.MediaPath, fileName)
+	return p.AddPictureBytes(imgBytes, filepath.Ext(path), width, height)
+}
 
-	imgExtStripDot := strings.TrimPrefix(imgExt, ".")
-	imgMIME, err := MIMEFromExt(imgExtStripDot)
+// AddPictureBytes adds an image held in memory to the paragraph.
+//
+// Parameters:
+//   - imgBytes: The content of the image file.
+//   - imgExt: The file extension of the image, such as ".png", which determines its content type.
+//   - width: The width of the image in inches.
+//   - height: The height of the image in inches.
+//
+// Returns:
+//   - *PicMeta: Metadata about the added picture, including the Paragraph instance and Inline element.
+//   - error: An error if the extension is not a known image type.
+func (p *Paragraph) AddPictureBytes(imgBytes []byte, imgExt string, width units.Inch, height units.Inch) (*PicMeta, error) {
+	rID, err := p.root.addImagePart(imgBytes, imgExt)
 	if err != nil {
 		return nil, err
 	}
 
-	err = p.root.ContentType.AddExtension(imgExtStripDot, imgMIME)
-	if err != nil {
-		return nil, err
-	}
+	inline := p.addDrawing(rID, p.root.ImageCount, width.ToEmu(), height.ToEmu())
 
-	overridePart := fmt.Sprintf("/%s%s", constants.MediaPath, fileName)
-	err = p.root.ContentType.AddOverride(overridePart, imgMIME)
+	return &PicMeta{
+		Para:   p,
+		Inline: inline,
+	}, nil
+}
+
+// addImagePart stores an image in the media folder of the package, with its content type and
+// a relationship from the main document, and returns the relationship ID. An image with the
+// same content as a media file is not stored again: the file is reused, with its relationship
+// when the main document has one. The image count, which numbers the drawings, is incremented
+// in both cases.
+func (rd *RootDoc) addImagePart(imgBytes []byte, imgExt string) (string, error) {
+	imgExt = strings.TrimPrefix(imgExt, ".")
+	imgMIME, err := MIMEFromExt(imgExt)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	p.root.FileMap.Store(fileIdxPath, imgBytes)
-
-	relName := fmt.Sprintf("media/%s", fileName)
-
-	rID := p.root.Document.addRelation(constants.SourceRelationshipImage, relName)
+	rd.ImageCount += 1
+
+	partName := rd.findMedia(imgBytes)
+	if partName != "" {
+		if rID := rd.imageRelation(partName); rID != "" {
+			return rID, nil
+		}
+	} else {
+		partName = rd.newMediaName(imgExt)
+
+		// The default of the extension covers the part unless it is another content type
+		if contentType, ok := rd.ContentType.defaultFor(imgExt); !ok {
+			err = rd.ContentType.AddExtension(imgExt, imgMIME)
+		} else if contentType != imgMIME {
+			err = rd.ContentType.AddOverride("/"+partName, imgMIME)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		rd.FileMap.Store(partName, imgBytes)
+	}
 
-	inline := p.addDrawing(rID, p.root.ImageCount, width, height)
+	relName := strings.TrimPrefix(partName, "word/")
 
-	return &PicMeta{
-		Para:   p,
-		Inline: inline,
-	}, nil
+	return rd.Document.addRelation(constants.SourceRelationshipImage, relName), nil
 }
@@ -1,13 +1,23 @@
 package docx
 
 import (
+	"errors"
+	"io"
+	"math"
+
 	"github.com/bfoley13/godocx/common/units"
 	"github.com/bfoley13/godocx/dml"
 )
 
+// emuPerTwip is the number of EMUs in a twentieth of a point.
+const emuPerTwip = 635
+
 type PicMeta struct {
 	Para   *Paragraph
 	Inline *dml.Inline
+
+	// Anchor is the element of a floating picture, whose Inline is nil.
+	Anchor *dml.Anchor
 }
 
 // AddPicture adds a new image to the document.
@@ -39,3 +49,141 @@ func (rd *RootDoc) AddPicture(path string, width units.Inch, height units.Inch)
 
 	return p.AddPicture(path, width, height)
 }
+
+// PictureOptions sets the size and placement of a picture added with AddPictureFromReader or
+// AddPictureFromBytes.
+type PictureOptions struct {
+	// Width and Height are the size of the picture in any unit, such as units.Cm or
+	// units.Point. When both are nil the picture has its natural size, from its pixel
+	// dimensions and resolution. When one is nil it is computed from the other, keeping the
+	// aspect ratio of the image.
+	Width  units.Length
+	Height units.Length
+
+	// FitWidth scales the picture to the width of the text area of its section, keeping the
+	// aspect ratio of the image. It takes precedence over Width and Height.
+	FitWidth bool
+
+	// Floating makes the picture a floating picture placed and wrapped by these options.
+	Floating *FloatingOptions
+}
+
+// size returns the size of a picture of the image, in a text area of the given width.
+func (opts PictureOptions) size(info ImageInfo, textWidth units.Emu) (units.Emu, units.Emu, error) {
+	width, height := info.Size()
+	aspect := info.aspectRatio()
+
+	switch {
+	case opts.FitWidth:
+		width, height = textWidth, scaleEmu(textWidth, aspect)
+	case opts.Width != nil && opts.Height != nil:
+		width, height = opts.Width.ToEmu(), opts.Height.ToEmu()
+	case opts.Width != nil:
+		width = opts.Width.ToEmu()
+		height = scaleEmu(width, aspect)
+	case opts.Height != nil:
+		height = opts.Height.ToEmu()
+		width = scaleEmu(height, 1/aspect)
+	}
+
+	if width <= 0 || height <= 0 {
+		return 0, 0, errors.New("picture size must be positive")
+	}
+	return width, height, nil
+}
+
+// scaleEmu returns value multiplied by factor, rounded to the nearest EMU.
+func scaleEmu(value units.Emu, factor float64) units.Emu {
+	return units.Emu(math.Round(float64(value) * factor))
+}
+
+// AddPictureFromReader adds a new paragraph holding an image read from r to the document. See
+// Paragraph.AddPictureFromBytes.
+func (rd *RootDoc) AddPictureFromReader(r io.Reader, name string, opts PictureOptions) (*PicMeta, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return rd.AddPictureFromBytes(data, name, opts)
+}
+
+// AddPictureFromBytes adds a new paragraph holding an image held in memory to the document.
+// See Paragraph.AddPictureFromBytes.
+func (rd *RootDoc) AddPictureFromBytes(data []byte, name string, opts PictureOptions) (*PicMeta, error) {
+	p := newParagraph(rd)
+
+	bodyElem := DocumentChild{
+		Para: p,
+	}
+	rd.Document.Body.Children = append(rd.Document.Body.Children, bodyElem)
+
+	return p.AddPictureFromBytes(data, name, opts)
+}
+
+// AddPictureFromReader adds an image read from r to the paragraph. See AddPictureFromBytes.
+func (p *Paragraph) AddPictureFromReader(r io.Reader, name string, opts PictureOptions) (*PicMeta, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.AddPictureFromBytes(data, name, opts)
+}
+
+// AddPictureFromBytes adds an image held in memory to the paragraph. The format is detected
+// from the content of the image rather than a file extension, and the picture has the natural
+// size of the image unless the options size it.
+//
+// Example usage:
+//
+//	// Add a chart rendered in memory, as wide as the text
+//	_, err = para.AddPictureFromBytes(chart, "Sales chart", docx.PictureOptions{FitWidth: true})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Parameters:
+//   - data: The content of a PNG, JPEG, GIF, BMP or TIFF image.
+//   - name: The name of the picture shown by Word, or the empty string for a generated name.
+//   - opts: The size and placement of the picture.
+//
+// Returns:
+//   - *PicMeta: Metadata about the added picture, including the Paragraph instance and Inline element.
+//   - error: An error if the image format is not recognized or the options are invalid.
+func (p *Paragraph) AddPictureFromBytes(data []byte, name string, opts PictureOptions) (*PicMeta, error) {
+	info, err := ReadImageInfo(data)
+	if err != nil {
+		return nil, err
+	}
+
+	width, height, err := opts.size(info, units.Emu(p.textWidth())*emuPerTwip)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Floating != nil {
+		if err := opts.Floating.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	rID, err := p.root.addImagePart(data, info.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	inline := p.addDrawing(rID, p.root.ImageCount, width, height)
+	if name != "" {
+		inline.DocProp.Name = name
+	}
+
+	pic := &PicMeta{
+		Para:   p,
+		Inline: inline,
+	}
+	if opts.Floating != nil {
+		return pic, pic.MakeFloating(*opts.Floating)
+	}
+	return pic, nil
+}
@@ -0,0 +1,131 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/units"
+	"github.com/bfoley13/godocx/internal"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddPictureFromBytes_Size(t *testing.T) {
+	// 300x150 pixels at 150 DPI is 2x1 inches
+	data := testTIFF(binary.LittleEndian, 300, 150, 150, 150, 2)
+
+	tests := []struct {
+		name   string
+		opts   PictureOptions
+		width  units.Emu
+		height units.Emu
+	}{
+		{"Natural size", PictureOptions{}, 1828800, 914400},
+		{"Width keeps aspect ratio", PictureOptions{Width: units.Cm(10)}, 3600000, 1800000},
+		{"Height keeps aspect ratio", PictureOptions{Height: units.Point(36)}, 914400, 457200},
+		{"Width and height", PictureOptions{Width: units.Inch(1), Height: units.Inch(1)}, 914400, 914400},
+		{"Fit width", PictureOptions{FitWidth: true, Width: units.Inch(1)}, 9360 * emuPerTwip, 9360 * emuPerTwip / 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rd := setupRootDoc(t)
+
+			pic, err := rd.AddPictureFromBytes(data, "", tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(tt.width), pic.Inline.Extent.Width)
+			assert.Equal(t, uint64(tt.height), pic.Inline.Extent.Height)
+			assert.Equal(t, uint64(tt.width), pic.Inline.Graphic.Data.Pic.PicShapeProp.TransformGroup.Extent.Width)
+		})
+	}
+}
+
+func TestAddPictureFromBytes_FitSectionWidth(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	// The first section has a narrower text area than the last one
+	first := rd.AddEmptyParagraph()
+	rd.AddEmptyParagraph().ensureProp()
+	rd.Document.Body.Children[1].Para.ct.Property.SectPr = &ctypes.SectionProp{
+		PageSize:   &ctypes.PageSize{Width: internal.ToPtr(uint64(6000))},
+		PageMargin: &ctypes.PageMargin{Left: internal.ToPtr(1000), Right: internal.ToPtr(1000)},
+	}
+	last := rd.AddEmptyParagraph()
+
+	data := testPNG(t, 100, 100, 0)
+	pic, err := first.AddPictureFromBytes(data, "", PictureOptions{FitWidth: true})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4000*emuPerTwip), pic.Inline.Extent.Width)
+
+	pic, err = last.AddPictureFromBytes(data, "", PictureOptions{FitWidth: true})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(defaultTextWidth*emuPerTwip), pic.Inline.Extent.Height)
+}
+
+func TestAddPictureFromReader(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddParagraph("Chart: ")
+
+	// The format comes from the content, not the name
+	data := testTIFF(binary.LittleEndian, 96, 48, 96, 96, 2)
+	pic, err := p.AddPictureFromReader(bytes.NewReader(data), "chart.png", PictureOptions{
+		Floating: &FloatingOptions{Wrap: PictureWrapTopAndBottom},
+	})
+	require.NoError(t, err)
+	assert.Same(t, p, pic.Para)
+	require.NotNil(t, pic.Anchor)
+	assert.Equal(t, "chart.png", pic.Anchor.DocProp.Name)
+	assert.Equal(t, uint64(914400), pic.Anchor.Extent.Width)
+
+	content, ok := rd.ReadPart("media/image2.tiff")
+	require.True(t, ok)
+	assert.Equal(t, data, content)
+	assert.Equal(t, "media/image2.tiff", rd.Document.DocRels.Relationships[0].Target)
+
+	pic, err = rd.AddPictureFromReader(bytes.NewReader(testPNG(t, 10, 10, 0)), "", PictureOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "Image3", pic.Inline.DocProp.Name)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func TestAddPictureFromBytes_Errors(t *testing.T) {
+	data := testPNG(t, 10, 10, 0)
+
+	tests := []struct {
+		name string
+		add  func(p *Paragraph) (*PicMeta, error)
+	}{
+		{"Unknown format", func(p *Paragraph) (*PicMeta, error) {
+			return p.AddPictureFromBytes([]byte("%PDF-1.7"), "", PictureOptions{})
+		}},
+		{"Zero width", func(p *Paragraph) (*PicMeta, error) {
+			return p.AddPictureFromBytes(data, "", PictureOptions{Width: units.Cm(0)})
+		}},
+		{"Invalid floating options", func(p *Paragraph) (*PicMeta, error) {
+			return p.AddPictureFromBytes(data, "", PictureOptions{Floating: &FloatingOptions{Wrap: -1}})
+		}},
+		{"Read error", func(p *Paragraph) (*PicMeta, error) {
+			return p.AddPictureFromReader(failingReader{}, "", PictureOptions{})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rd := setupRootDoc(t)
+			p := rd.AddEmptyParagraph()
+
+			_, err := tt.add(p)
+			assert.Error(t, err)
+			assert.Empty(t, p.ct.Children)
+			assert.Empty(t, rd.Document.DocRels.Relationships)
+		})
+	}
+}
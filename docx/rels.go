@@ -2,6 +2,7 @@ package docx
 
 import (
 	"encoding/xml"
+	"path"
 )
 
 // Relationship represents a relationship between elements in an Office Open XML (OOXML) document.
@@ -45,3 +46,43 @@ func (r Relationship) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 
 	return e.EncodeElement("", start)
 }
+
+// PartRelations decodes the relationships of a package part other than the main document,
+// such as a header or the footnotes part.
+//
+// Parameters:
+//   - partName: The path of the part within the package, such as "word/header1.xml".
+//
+// Returns:
+//   - *Relationships: The relationships of the part; empty if the part has none.
+//   - error: An error if the relationships part cannot be decoded.
+func (rd *RootDoc) PartRelations(partName string) (*Relationships, error) {
+	relsPath := partRelsPath(partName)
+	rels := &Relationships{RelativePath: relsPath}
+
+	content, ok := rd.FileMap.Load(relsPath)
+	if !ok {
+		return rels, nil
+	}
+
+	if err := xml.Unmarshal(content.([]byte), rels); err != nil {
+		return nil, err
+	}
+
+	return rels, nil
+}
+
+// partRelsPath returns the path of the relationships part of a package part.
+func partRelsPath(partName string) string {
+	return path.Join(path.Dir(partName), "_rels", path.Base(partName)+".rels")
+}
+
+// GetRelationByID returns the relationship with the given ID, or nil if there is none.
+func (r *Relationships) GetRelationByID(rID string) *Relationship {
+	for _, rel := range r.Relationships {
+		if rel.ID == rID {
+			return rel
+		}
+	}
+	return nil
+}
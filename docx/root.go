@@ -15,11 +15,19 @@ type RootDoc struct {
 	FileMap     sync.Map      // FileMap is a synchronized map for managing files related to the document.
 	RootRels    Relationships // RootRels represents relationships at the root level.
 	ContentType ContentTypes
-	Document    *Document      // Document is the main document structure.
-	DocStyles   *ctypes.Styles // Document styles
+	Document    *Document         // Document is the main document structure.
+	DocStyles   *ctypes.Styles    // Document styles
+	Numbering   *ctypes.Numbering // Numbering definitions, nil if the document has no numbering part
 
 	rID        int // rId is used to generate unique relationship IDs.
 	ImageCount uint
+
+	footnotes *ctypes.Footnotes // Footnotes part, decoded on first use
+	endnotes  *ctypes.Footnotes // Endnotes part, decoded on first use
+
+	// emptyCellParas are the empty paragraphs added to cells with AddEmptyPara, which are
+	// kept when the next paragraph is added, unlike the paragraph closing a nested table.
+	emptyCellParas map[*ctypes.Paragraph]bool
 }
 
 // NewRootDoc creates a new instance of the RootDoc structure.
@@ -61,3 +69,15 @@ func LoadStyles(fileName string, fileBytes []byte) (*ctypes.Styles, error) {
 	styles.RelativePath = fileName
 	return &styles, nil
 }
+
+// LoadNumbering decodes numbering.xml into a Numbering struct
+func LoadNumbering(fileName string, fileBytes []byte) (*ctypes.Numbering, error) {
+	numbering := ctypes.Numbering{}
+	err := xml.Unmarshal(fileBytes, &numbering)
+	if err != nil {
+		return nil, err
+	}
+
+	numbering.RelativePath = fileName
+	return &numbering, nil
+}
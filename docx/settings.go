@@ -0,0 +1,108 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bfoley13/godocx/common/constants"
+)
+
+const settingsContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.settings+xml"
+
+// settingsAfterUpdateFields lists the settings that follow updateFields in the schema.
+var settingsAfterUpdateFields = map[string]bool{
+	"hdrShapeDefaults": true, "footnotePr": true, "endnotePr": true, "compat": true, "docVars": true,
+	"rsids": true, "attachedSchema": true, "themeFontLang": true, "clrSchemeMapping": true,
+	"doNotIncludeSubdocsInStats": true, "doNotAutoCompressPictures": true, "forceUpgrade": true,
+	"captions": true, "readModeInkLockDown": true, "smartTagType": true, "shapeDefaults": true,
+	"doNotEmbedSmartTags": true, "decimalSymbol": true, "listSeparator": true,
+}
+
+// SetUpdateFieldsOnOpen sets whether Word updates the fields of the document, such as tables of
+// contents and page references, when the document is opened. A settings part is added to the
+// document if it has none; the other settings are kept as they are.
+func (rd *RootDoc) SetUpdateFieldsOnOpen(value bool) error {
+	if rd.Document == nil {
+		return errors.New("document has no main part")
+	}
+
+	content, ok := rd.settingsPart()
+	if !ok {
+		target := "settings.xml"
+		content = []byte(`<w:settings xmlns:w="` + constants.WMLNamespace + `"></w:settings>`)
+		if err := rd.ContentType.AddOverride("/"+rd.partPath(target), settingsContentType); err != nil {
+			return err
+		}
+		rd.Document.addRelation(constants.SettingsType, target)
+	}
+
+	updated, err := setUpdateFields(content, value)
+	if err != nil {
+		return fmt.Errorf("settings: %w", err)
+	}
+
+	for _, rel := range rd.Document.DocRels.Relationships {
+		if rel.Type == constants.SettingsType {
+			rd.FileMap.Store(rd.partPath(rel.Target), updated)
+			break
+		}
+	}
+	return nil
+}
+
+// setUpdateFields sets the updateFields element of a settings part. An existing element is
+// replaced, otherwise the element is inserted at its position in the schema order.
+func setUpdateFields(content []byte, value bool) ([]byte, error) {
+	d := xml.NewDecoder(bytes.NewReader(content))
+
+	prefix := ""
+	depth := 0
+	start, end := -1, -1
+	for end < 0 {
+		offset := int(d.InputOffset())
+		token, err := d.RawToken()
+		if err == io.EOF {
+			return nil, errors.New("settings element is not closed")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			depth++
+			switch {
+			case depth == 1:
+				prefix = elem.Name.Space
+			case depth == 2 && start < 0 && elem.Name.Space == prefix && elem.Name.Local == "updateFields":
+				start = offset
+			case depth == 2 && start < 0 && (elem.Name.Space != prefix || settingsAfterUpdateFields[elem.Name.Local]):
+				start, end = offset, offset
+			}
+		case xml.EndElement:
+			depth--
+			switch {
+			case depth == 1 && start >= 0:
+				end = int(d.InputOffset())
+			case depth == 0:
+				start, end = offset, offset
+			}
+		}
+	}
+
+	name := "updateFields"
+	val := "val"
+	if prefix != "" {
+		name = prefix + ":" + name
+		val = prefix + ":" + val
+	}
+	elem := fmt.Sprintf(`<%s %s="%t"/>`, name, val, value)
+
+	updated := make([]byte, 0, len(content)+len(elem))
+	updated = append(updated, content[:start]...)
+	updated = append(updated, elem...)
+	return append(updated, content[end:]...), nil
+}
@@ -0,0 +1,298 @@
+package docx
+
+import (
+	"encoding/xml"
+	"errors"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// SplitStrategy selects where Split divides a document.
+type SplitStrategy int
+
+const (
+	// SplitBySection produces one document per section.
+	SplitBySection SplitStrategy = iota
+
+	// SplitByHeading1 produces one document per chapter, starting at each level one heading.
+	// Content before the first heading forms a document of its own.
+	SplitByHeading1
+
+	// SplitByPageBreak produces one document per explicit page break. Paragraphs holding only
+	// page breaks separate documents and are dropped; paragraphs set to start on a new page
+	// begin a new document.
+	SplitByPageBreak
+)
+
+// sharedPartTypes lists the relationship types of document level parts that every document
+// produced by Split keeps. The numbering part is only kept by documents using lists, which
+// create it when their first list is copied.
+var sharedPartTypes = map[string]bool{
+	constants.StylesType:      true,
+	constants.SettingsType:    true,
+	constants.WebSettingsType: true,
+	constants.FontTableType:   true,
+	constants.ThemeType:       true,
+	constants.CustomXMLType:   true,
+}
+
+// splitPiece is the body content and final section properties of one split document.
+type splitPiece struct {
+	children []DocumentChild
+	sectPr   *ctypes.SectionProp
+}
+
+// Split divides the document into independent documents according to the strategy.
+//
+// Each document carries the styles, numbering definitions, media, headers, footers and other
+// parts its content uses, the section properties of its last section and the document level
+// parts such as settings and theme. The document itself is left unchanged.
+func (rd *RootDoc) Split(strategy SplitStrategy) ([]*RootDoc, error) {
+	if rd.Document == nil || rd.Document.Body == nil {
+		return nil, errors.New("document has no body")
+	}
+
+	var pieces []splitPiece
+	switch strategy {
+	case SplitBySection:
+		pieces = rd.splitBySection()
+	case SplitByHeading1:
+		pieces = rd.splitByHeading1()
+	case SplitByPageBreak:
+		pieces = rd.splitByPageBreak()
+	default:
+		return nil, errors.New("unknown split strategy")
+	}
+
+	docs := make([]*RootDoc, 0, len(pieces))
+	for _, piece := range pieces {
+		doc, err := rd.splitDocument(piece)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+func (rd *RootDoc) splitBySection() []splitPiece {
+	body := rd.Document.Body
+
+	var pieces []splitPiece
+	start := 0
+	for i, child := range body.Children {
+		if hasSectionBreak(child.Para) {
+			pieces = append(pieces, splitPiece{children: body.Children[start : i+1], sectPr: child.Para.ct.Property.SectPr})
+			start = i + 1
+		}
+	}
+
+	if start < len(body.Children) || len(pieces) == 0 {
+		pieces = append(pieces, splitPiece{children: body.Children[start:], sectPr: body.SectPr})
+	}
+
+	return pieces
+}
+
+func (rd *RootDoc) splitByHeading1() []splitPiece {
+	return rd.splitAt(func(child DocumentChild) (bool, bool) {
+		if child.Para == nil {
+			return false, false
+		}
+		ct := child.Para.GetCT()
+		return rd.OutlineLevel(ct) == 0 && rd.paragraphStyleID(ct) != "Title", false
+	})
+}
+
+func (rd *RootDoc) splitByPageBreak() []splitPiece {
+	return rd.splitAt(func(child DocumentChild) (bool, bool) {
+		p := child.Para
+		if p == nil {
+			return false, false
+		}
+		if isPageBreakParagraph(&p.ct) && !hasSectionBreak(p) {
+			return true, true
+		}
+		prop := p.ct.Property
+		return prop != nil && prop.PageBreakBefore.Bool(), false
+	})
+}
+
+// splitAt divides the body before every element for which starts returns true, dropping the
+// element if drop is true. Each piece takes the properties of the section its last element
+// belongs to.
+func (rd *RootDoc) splitAt(starts func(child DocumentChild) (start, drop bool)) []splitPiece {
+	body := rd.Document.Body
+
+	var pieces []splitPiece
+	var current []DocumentChild
+	last := -1
+
+	end := func() {
+		if len(current) > 0 {
+			pieces = append(pieces, splitPiece{children: current, sectPr: rd.sectionAt(last)})
+		}
+		current = nil
+	}
+
+	for i, child := range body.Children {
+		start, drop := starts(child)
+		if start {
+			end()
+		}
+		if drop {
+			continue
+		}
+		current = append(current, child)
+		last = i
+	}
+	end()
+
+	if len(pieces) == 0 {
+		pieces = append(pieces, splitPiece{sectPr: body.SectPr})
+	}
+
+	return pieces
+}
+
+// isPageBreakParagraph reports whether a paragraph holds page breaks and nothing else.
+func isPageBreakParagraph(p *ctypes.Paragraph) bool {
+	breaks := 0
+	for _, child := range p.Children {
+		if child.Run == nil {
+			return false
+		}
+		for _, rc := range child.Run.Children {
+			if rc.Break == nil || rc.Break.BreakType == nil || *rc.Break.BreakType != stypes.BreakTypePage {
+				return false
+			}
+			breaks++
+		}
+	}
+	return breaks > 0
+}
+
+// splitDocument creates a document holding a copy of the piece and everything it uses.
+func (rd *RootDoc) splitDocument(piece splitPiece) (*RootDoc, error) {
+	doc, err := rd.splitShell()
+	if err != nil {
+		return nil, err
+	}
+
+	m := newMerger(doc, rd, MergeOptions{})
+
+	// Default styles apply to content without a style reference, so they are always kept
+	if rd.DocStyles != nil {
+		for _, style := range rd.DocStyles.StyleList {
+			if style.ID != nil && style.Default != nil && (&ctypes.OnOff{Val: style.Default}).Bool() {
+				if _, err := m.mapStyle(*style.ID); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	children, sectPr, err := m.merge(piece.children, piece.sectPr)
+	if err != nil {
+		return nil, err
+	}
+
+	// The section ended by the last paragraph is now the final section of the body
+	if n := len(children); n > 0 && hasSectionBreak(children[n-1].Para) {
+		p := children[n-1].Para
+		p.ct.Property.SectPr = nil
+		if len(p.ct.Children) == 0 && n > 1 {
+			children = children[:n-1]
+		}
+	}
+
+	doc.Document.Body.Children = children
+	doc.Document.Body.SectPr = sectPr
+
+	return doc, nil
+}
+
+// splitShell returns an empty document with the package and document level parts of the
+// document, such as document properties, settings and theme, but none of its content.
+func (rd *RootDoc) splitShell() (*RootDoc, error) {
+	shell := NewRootDoc()
+	shell.Path = rd.Path
+
+	shell.RootRels = Relationships{RelativePath: rd.RootRels.RelativePath, Xmlns: rd.RootRels.Xmlns}
+	for _, rel := range rd.RootRels.Relationships {
+		copied := *rel
+		shell.RootRels.Relationships = append(shell.RootRels.Relationships, &copied)
+	}
+
+	src := rd.Document
+	shell.Document = &Document{
+		Root:         shell,
+		Body:         NewBody(shell),
+		relativePath: src.relativePath,
+		DocRels:      Relationships{RelativePath: src.DocRels.RelativePath, Xmlns: src.DocRels.Xmlns},
+	}
+	if src.Background != nil {
+		bg := *src.Background
+		shell.Document.Background = &bg
+	}
+
+	keep := make(map[string]bool)
+	for _, rel := range src.DocRels.Relationships {
+		if !sharedPartTypes[rel.Type] {
+			continue
+		}
+		copied := *rel
+		shell.Document.DocRels.Relationships = append(shell.Document.DocRels.Relationships, &copied)
+		if n, err := strconv.Atoi(strings.TrimPrefix(rel.ID, "rId")); err == nil && n > shell.Document.RID {
+			shell.Document.RID = n
+		}
+		// The styles part is written from the styles the content uses when the document is saved
+		if rel.TargetMode != "External" && rel.Type != constants.StylesType {
+			partName := rd.partPath(rel.Target)
+			keep[partName] = true
+			keep[partRelsPath(partName)] = true
+		}
+	}
+
+	wordDir := path.Dir(rd.mainPartName()) + "/"
+	rd.FileMap.Range(func(key, value any) bool {
+		name := key.(string)
+		if keep[name] || !strings.HasPrefix(name, wordDir) {
+			shell.FileMap.Store(name, value)
+		}
+		return true
+	})
+
+	if rd.DocStyles != nil {
+		// Document defaults and latent styles are copied by encoding them
+		content, err := xml.Marshal(&ctypes.Styles{
+			Attr:        rd.DocStyles.Attr,
+			DocDefaults: rd.DocStyles.DocDefaults,
+			LatentStyle: rd.DocStyles.LatentStyle,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if shell.DocStyles, err = LoadStyles(rd.DocStyles.RelativePath, content); err != nil {
+			return nil, err
+		}
+	}
+
+	shell.ContentType.Default = append(shell.ContentType.Default, rd.ContentType.Default...)
+	for _, override := range rd.ContentType.Override {
+		partName := strings.TrimPrefix(override.PartName, "/")
+		_, stored := shell.FileMap.Load(partName)
+		isStyles := rd.DocStyles != nil && partName == rd.DocStyles.RelativePath
+		if stored || isStyles || partName == rd.mainPartName() {
+			shell.ContentType.Override = append(shell.ContentType.Override, override)
+		}
+	}
+
+	return shell, nil
+}
@@ -0,0 +1,175 @@
+package docx
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSplitDoc returns a document with a settings part and two chapters. The first chapter
+// uses a list and an image and ends a section with a header, the second uses a character
+// style.
+func setupSplitDoc(t *testing.T) *RootDoc {
+	t.Helper()
+
+	rd := setupStyledRootDoc(t)
+	rd.FileMap.Store("word/settings.xml", []byte(`<w:settings `+mergeNS+`/>`))
+	rd.FileMap.Store("docProps/core.xml", []byte(`<cp:coreProperties/>`))
+	rd.FileMap.Store("word/header1.xml", []byte(`<w:hdr `+mergeNS+`><w:p/></w:hdr>`))
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships,
+		&Relationship{ID: "rId7", Type: constants.SettingsType, Target: "settings.xml"},
+		&Relationship{ID: "rId8", Type: constants.HeaderType, Target: "header1.xml"},
+	)
+	rd.ContentType.Override = append(rd.ContentType.Override,
+		Override{PartName: "/word/settings.xml", ContentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.settings+xml"})
+
+	rd.AddParagraph("Chapter one").Style("Heading1")
+	numID := rd.AddListDefinition(ListLevel{Format: stypes.NumFmtDecimal})
+	rd.AddParagraph("Item").Numbering(numID, 0)
+	p := rd.AddParagraph("Figure")
+	_, err := p.AddPictureBytes([]byte("png"), ".png", 1, 1)
+	require.NoError(t, err)
+	p.ensureProp()
+	p.ct.Property.SectPr = &ctypes.SectionProp{HeaderReference: &ctypes.HeaderReference{Type: stypes.HdrFtrDefault, ID: "rId8"}}
+
+	rd.AddPageBreak()
+	rd.AddParagraph("Chapter two").Style("Heading1")
+	rd.AddParagraph("").AddText("stressed").Style("Emphasis")
+	rd.Document.Body.SectPr = &ctypes.SectionProp{}
+
+	return rd
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy SplitStrategy
+		lengths  []int
+		header   bool // whether the first document ends with the section holding the header
+	}{
+		{"BySection", SplitBySection, []int{3, 3}, true},
+		{"ByHeading1", SplitByHeading1, []int{4, 2}, false},
+		{"ByPageBreak", SplitByPageBreak, []int{3, 2}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rd := setupSplitDoc(t)
+
+			docs, err := rd.Split(tt.strategy)
+			require.NoError(t, err)
+			require.Len(t, docs, len(tt.lengths))
+
+			for i, doc := range docs {
+				assert.Len(t, doc.Document.Body.Children, tt.lengths[i])
+				assert.NotNil(t, doc.Document.Body.SectPr)
+
+				_, ok := doc.ReadPart("settings.xml")
+				assert.True(t, ok)
+				_, ok = doc.FileMap.Load("docProps/core.xml")
+				assert.True(t, ok)
+			}
+
+			// The section break ending the first chapter is copied with its header, and becomes
+			// the final section when it ends the document
+			first := docs[0]
+			sectPr := first.Document.Body.SectPr
+			if !tt.header {
+				sectPr = first.Document.Body.Children[2].Para.GetCT().Property.SectPr
+			} else {
+				assert.Nil(t, first.Document.Body.Children[2].Para.GetCT().Property.SectPr)
+			}
+			require.NotNil(t, sectPr)
+			headerRel := first.Document.GetRelationByID(sectPr.HeaderReference.ID)
+			require.NotNil(t, headerRel)
+			_, ok := first.ReadPart(headerRel.Target)
+			assert.True(t, ok)
+
+			// Only the styles, numbering and media each chapter uses are carried
+			assert.NotNil(t, first.GetStyleByID("Normal", stypes.StyleTypeParagraph))
+			assert.NotNil(t, first.GetStyleByID("Heading1", stypes.StyleTypeParagraph))
+			assert.Nil(t, first.GetStyleByID("Emphasis", stypes.StyleTypeCharacter))
+			require.NotNil(t, first.Numbering)
+			assert.Len(t, first.Numbering.Nums, 1)
+			_, ok = first.ReadPart("media/image1.png")
+			assert.True(t, ok)
+
+			second := docs[1]
+			assert.NotNil(t, second.GetStyleByID("Normal", stypes.StyleTypeParagraph))
+			assert.NotNil(t, second.GetStyleByID("Emphasis", stypes.StyleTypeCharacter))
+			assert.Nil(t, second.Numbering)
+			assert.Nil(t, second.Document.Body.SectPr.HeaderReference)
+			_, ok = second.FileMap.Load("word/header1.xml")
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestSplit_PartsReachable(t *testing.T) {
+	rd := setupSplitDoc(t)
+	rd.RootRels = Relationships{RelativePath: "_rels/.rels", Relationships: []*Relationship{
+		{ID: "rId1", Type: constants.OFFICE_DOC_TYPE, Target: "word/document.xml"},
+		{ID: "rId2", Type: constants.CORE_PROP_TYPE, Target: "docProps/core.xml"},
+	}}
+	rd.Document.relativePath = "word/document.xml"
+	rd.Document.DocRels.RelativePath = "word/_rels/document.xml.rels"
+	rd.DocStyles.RelativePath = "word/styles.xml"
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships,
+		&Relationship{ID: "rId9", Type: constants.StylesType, Target: "styles.xml"})
+
+	docs, err := rd.Split(SplitByHeading1)
+	require.NoError(t, err)
+
+	for i, doc := range docs {
+		require.NoError(t, doc.Write(io.Discard))
+
+		// Walk the relationships from the package root
+		reached := map[string]bool{}
+		queue := []string{""}
+		for len(queue) > 0 {
+			source := queue[0]
+			queue = queue[1:]
+
+			relsPath := "_rels/.rels"
+			if source != "" {
+				relsPath = partRelsPath(source)
+			}
+			content, ok := doc.FileMap.Load(relsPath)
+			if !ok {
+				continue
+			}
+			rels := &Relationships{}
+			require.NoError(t, xml.Unmarshal(content.([]byte), rels))
+			for _, rel := range rels.Relationships {
+				if target := resolvePartTarget(source, rel.Target); !reached[target] {
+					reached[target] = true
+					queue = append(queue, target)
+				}
+			}
+		}
+
+		doc.FileMap.Range(func(key, _ any) bool {
+			name := key.(string)
+			if name != "[Content_Types].xml" && !strings.HasSuffix(name, ".rels") {
+				assert.True(t, reached[name], "document %d: %s is not reachable", i, name)
+			}
+			return true
+		})
+		assert.True(t, reached["word/styles.xml"])
+		assert.Equal(t, i == 0, reached["word/numbering.xml"], "numbering is kept with the list")
+	}
+}
+
+func TestSplit_UnknownStrategy(t *testing.T) {
+	rd := setupSplitDoc(t)
+
+	_, err := rd.Split(SplitStrategy(10))
+	assert.Error(t, err)
+}
@@ -1,6 +1,10 @@
 package docx
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
+
 	"github.com/bfoley13/godocx/wml/ctypes"
 	"github.com/bfoley13/godocx/wml/stypes"
 )
@@ -32,3 +36,218 @@ func (rd *RootDoc) GetStyleByID(styleID string, styleType stypes.StyleType) *cty
 	}
 	return nil
 }
+
+// getStyleByName returns the style with the given display name and type, or nil.
+func (rd *RootDoc) getStyleByName(name string, styleType stypes.StyleType) *ctypes.Style {
+	if rd.DocStyles == nil {
+		return nil
+	}
+
+	for i := range rd.DocStyles.StyleList {
+		style := &rd.DocStyles.StyleList[i]
+		if style.Name == nil || style.Type == nil {
+			continue
+		}
+
+		if strings.EqualFold(style.Name.Val, name) && *style.Type == styleType {
+			return style
+		}
+	}
+	return nil
+}
+
+// defaultStyle returns the style marked as the default for the given style type, or nil.
+func (rd *RootDoc) defaultStyle(styleType stypes.StyleType) *ctypes.Style {
+	if rd.DocStyles == nil {
+		return nil
+	}
+
+	for i := range rd.DocStyles.StyleList {
+		style := &rd.DocStyles.StyleList[i]
+		if style.Type == nil || *style.Type != styleType || style.Default == nil {
+			continue
+		}
+
+		if (&ctypes.OnOff{Val: style.Default}).Bool() {
+			return style
+		}
+	}
+	return nil
+}
+
+// styleChain returns the style with the given ID followed by the styles it is based on,
+// ordered from the most general ancestor to the style itself.
+func (rd *RootDoc) styleChain(styleID string, styleType stypes.StyleType) []*ctypes.Style {
+	var chain []*ctypes.Style
+	seen := make(map[string]bool)
+
+	for styleID != "" && !seen[styleID] {
+		seen[styleID] = true
+		style := rd.GetStyleByID(styleID, styleType)
+		if style == nil {
+			// Paragraph.Style is commonly given the display name of a style
+			style = rd.getStyleByName(styleID, styleType)
+		}
+		if style == nil {
+			break
+		}
+		chain = append([]*ctypes.Style{style}, chain...)
+
+		styleID = ""
+		if style.BasedOn != nil {
+			styleID = style.BasedOn.Val
+		}
+	}
+
+	return chain
+}
+
+// paragraphStyleID returns the ID of the paragraph style applied to p, falling back to the
+// document's default paragraph style.
+func (rd *RootDoc) paragraphStyleID(p *ctypes.Paragraph) string {
+	if p != nil && p.Property != nil && p.Property.Style != nil {
+		return p.Property.Style.Val
+	}
+
+	if style := rd.defaultStyle(stypes.StyleTypeParagraph); style != nil && style.ID != nil {
+		return *style.ID
+	}
+	return ""
+}
+
+// EffectiveParaProp resolves the paragraph properties that apply to a paragraph.
+//
+// Properties are merged from the document defaults, the paragraph style and the styles it is
+// based on, and finally the direct formatting of the paragraph. Each later level overrides the
+// properties set by an earlier one.
+//
+// Parameters:
+//   - p: The paragraph whose properties should be resolved.
+//
+// Returns:
+//   - *ctypes.ParagraphProp: A new ParagraphProp holding the merged properties; never nil.
+func (rd *RootDoc) EffectiveParaProp(p *ctypes.Paragraph) *ctypes.ParagraphProp {
+	result := &ctypes.ParagraphProp{}
+
+	if rd.DocStyles != nil && rd.DocStyles.DocDefaults != nil && rd.DocStyles.DocDefaults.ParaProp != nil {
+		mergeProps(result, rd.DocStyles.DocDefaults.ParaProp.ParaProp)
+	}
+
+	for _, style := range rd.styleChain(rd.paragraphStyleID(p), stypes.StyleTypeParagraph) {
+		mergeProps(result, style.ParaProp)
+	}
+
+	if p != nil {
+		mergeProps(result, p.Property)
+	}
+
+	return result
+}
+
+// EffectiveRunProp resolves the run properties that apply to a run within a paragraph.
+//
+// Properties are merged from the document defaults, the paragraph style chain, the character
+// style chain of the run and finally the direct formatting of the run.
+//
+// Parameters:
+//   - p: The paragraph containing the run; may be nil.
+//   - r: The run whose properties should be resolved; may be nil.
+//
+// Returns:
+//   - *ctypes.RunProperty: A new RunProperty holding the merged properties; never nil.
+func (rd *RootDoc) EffectiveRunProp(p *ctypes.Paragraph, r *ctypes.Run) *ctypes.RunProperty {
+	result := &ctypes.RunProperty{}
+
+	if rd.DocStyles != nil && rd.DocStyles.DocDefaults != nil && rd.DocStyles.DocDefaults.RunProp != nil {
+		mergeProps(result, rd.DocStyles.DocDefaults.RunProp.RunProp)
+	}
+
+	for _, style := range rd.styleChain(rd.paragraphStyleID(p), stypes.StyleTypeParagraph) {
+		mergeProps(result, style.RunProp)
+	}
+
+	if r == nil || r.Property == nil {
+		return result
+	}
+
+	if r.Property.Style != nil {
+		for _, style := range rd.styleChain(r.Property.Style.Val, stypes.StyleTypeCharacter) {
+			mergeProps(result, style.RunProp)
+		}
+	}
+
+	mergeProps(result, r.Property)
+
+	return result
+}
+
+// OutlineLevel returns the zero based outline level of a paragraph, or -1 if the paragraph is
+// body text.
+//
+// The outline level is taken from the effective paragraph properties. Paragraphs styled with a
+// built-in heading or title style that does not define an outline level are recognised by
+// their style ID.
+func (rd *RootDoc) OutlineLevel(p *ctypes.Paragraph) int {
+	props := rd.EffectiveParaProp(p)
+	if props.OutlineLvl != nil {
+		// Level 9 explicitly marks body text
+		if props.OutlineLvl.Val >= 0 && props.OutlineLvl.Val < 9 {
+			return props.OutlineLvl.Val
+		}
+		return -1
+	}
+
+	styleID := rd.paragraphStyleID(p)
+	if styleID == "Title" {
+		return 0
+	}
+
+	var level int
+	if _, err := fmt.Sscanf(styleID, "Heading%d", &level); err == nil && level >= 1 && level <= 9 {
+		return level - 1
+	}
+
+	return -1
+}
+
+// mergeProps copies every field that is set in src over the corresponding field of dst.
+// Both arguments must be pointers to the same struct type; a nil src is ignored.
+func mergeProps[T any](dst, src *T) {
+	if src == nil {
+		return
+	}
+
+	dstVal := reflect.ValueOf(dst).Elem()
+	srcVal := reflect.ValueOf(src).Elem()
+	for i := 0; i < srcVal.NumField(); i++ {
+		field := srcVal.Field(i)
+		if !dstVal.Field(i).CanSet() || field.IsZero() {
+			continue
+		}
+		dstVal.Field(i).Set(field)
+	}
+}
+
+// ResolveStyle merges the properties of a style with those of the styles it is based on.
+//
+// Unlike EffectiveParaProp and EffectiveRunProp, the document defaults are not included, so
+// the result describes the formatting the style itself contributes.
+//
+// Parameters:
+//   - styleID: The ID or display name of the style.
+//   - styleType: The type of the style.
+//
+// Returns:
+//   - *ctypes.ParagraphProp: The merged paragraph properties; never nil.
+//   - *ctypes.RunProperty: The merged run properties; never nil.
+func (rd *RootDoc) ResolveStyle(styleID string, styleType stypes.StyleType) (*ctypes.ParagraphProp, *ctypes.RunProperty) {
+	paraProp := &ctypes.ParagraphProp{}
+	runProp := &ctypes.RunProperty{}
+
+	for _, style := range rd.styleChain(styleID, styleType) {
+		mergeProps(paraProp, style.ParaProp)
+		mergeProps(runProp, style.RunProp)
+	}
+
+	return paraProp, runProp
+}
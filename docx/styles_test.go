@@ -0,0 +1,95 @@
+package docx
+
+import (
+	"testing"
+
+	"github.com/bfoley13/godocx/internal"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupStyledRootDoc(t *testing.T) *RootDoc {
+	t.Helper()
+
+	rd := setupRootDoc(t)
+	paraType := stypes.StyleTypeParagraph
+	charType := stypes.StyleTypeCharacter
+	isDefault := stypes.OnOffTrue
+
+	rd.DocStyles = &ctypes.Styles{
+		DocDefaults: &ctypes.DocDefault{
+			RunProp: &ctypes.RunPropDefault{RunProp: &ctypes.RunProperty{Size: ctypes.NewFontSize(22)}},
+		},
+		StyleList: []ctypes.Style{
+			{ID: internal.ToPtr("Normal"), Type: &paraType, Default: &isDefault, Name: &ctypes.CTString{Val: "Normal"}},
+			{
+				ID:       internal.ToPtr("Heading1"),
+				Type:     &paraType,
+				Name:     &ctypes.CTString{Val: "heading 1"},
+				BasedOn:  &ctypes.CTString{Val: "Normal"},
+				ParaProp: &ctypes.ParagraphProp{OutlineLvl: ctypes.NewDecimalNum(0)},
+				RunProp:  &ctypes.RunProperty{Bold: ctypes.OnOffFromBool(true), Size: ctypes.NewFontSize(28)},
+			},
+			{
+				ID:      internal.ToPtr("Heading2"),
+				Type:    &paraType,
+				BasedOn: &ctypes.CTString{Val: "Heading1"},
+				RunProp: &ctypes.RunProperty{Italic: ctypes.OnOffFromBool(true)},
+			},
+			{
+				ID:      internal.ToPtr("Emphasis"),
+				Type:    &charType,
+				RunProp: &ctypes.RunProperty{Italic: ctypes.OnOffFromBool(true), Color: ctypes.NewColor("FF0000")},
+			},
+		},
+	}
+
+	return rd
+}
+
+func TestEffectiveRunProp(t *testing.T) {
+	rd := setupStyledRootDoc(t)
+
+	para := rd.AddParagraph("")
+	para.Style("Heading1")
+	run := para.AddText("text").Style("Emphasis").Size(30)
+
+	props := rd.EffectiveRunProp(para.GetCT(), run.ct)
+	assert.True(t, props.Bold.Bool())
+	assert.True(t, props.Italic.Bool())
+	assert.Equal(t, "FF0000", props.Color.Val)
+	assert.Equal(t, uint64(60), props.Size.Value)
+
+	plain := rd.AddParagraph("plain")
+	props = rd.EffectiveRunProp(plain.GetCT(), nil)
+	assert.False(t, props.Bold.Bool())
+	assert.Equal(t, uint64(22), props.Size.Value)
+}
+
+func TestOutlineLevel(t *testing.T) {
+	rd := setupStyledRootDoc(t)
+
+	tests := []struct {
+		name     string
+		style    string
+		expected int
+	}{
+		{name: "Body text", style: "", expected: -1},
+		{name: "Style outline level", style: "Heading1", expected: 0},
+		{name: "Inherited outline level", style: "Heading2", expected: 0},
+		{name: "Style display name", style: "heading 1", expected: 0},
+		{name: "Heading style without definition", style: "Heading4", expected: 3},
+		{name: "Title", style: "Title", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			para := rd.AddParagraph("text")
+			if tt.style != "" {
+				para.Style(tt.style)
+			}
+			assert.Equal(t, tt.expected, rd.OutlineLevel(para.GetCT()))
+		})
+	}
+}
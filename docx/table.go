@@ -117,11 +117,11 @@ func (rd *RootDoc) AddTable() *Table {
 func (t *Table) AddRow() *Row {
 	row := Row{
 		root: t.root,
-		ct:   *ctypes.DefaultRow(),
+		ct:   ctypes.DefaultRow(),
 	}
 
 	t.ct.RowContents = append(t.ct.RowContents, ctypes.RowContent{
-		Row: &row.ct,
+		Row: row.ct,
 	})
 
 	return &row
@@ -192,19 +192,24 @@ type Row struct {
 	// Reverse inheriting the Rootdoc into paragraph to access other elements
 	root *RootDoc
 
-	// Row Complex Type
-	ct ctypes.Row
+	// Row Complex Type, shared with the table holding the row
+	ct *ctypes.Row
+}
+
+// GetCT returns a pointer to the underlying Row Complex Type.
+func (r *Row) GetCT() *ctypes.Row {
+	return r.ct
 }
 
 // Add Cell to row and returns Cell
 func (r *Row) AddCell() *Cell {
 	cell := Cell{
 		root: r.root,
-		ct:   *ctypes.DefaultCell(),
+		ct:   ctypes.DefaultCell(),
 	}
 
 	r.ct.Contents = append(r.ct.Contents, ctypes.TRCellContent{
-		Cell: &cell.ct,
+		Cell: cell.ct,
 	})
 
 	return &cell
@@ -215,80 +220,98 @@ type Cell struct {
 	// Reverse inheriting the Rootdoc into paragraph to access other elements
 	root *RootDoc
 
-	// Cell Complex Type
-	ct ctypes.Cell
+	// Cell Complex Type, shared with the row holding the cell
+	ct *ctypes.Cell
+}
+
+// GetCT returns a pointer to the underlying Cell Complex Type.
+func (c *Cell) GetCT() *ctypes.Cell {
+	return c.ct
 }
 
 // Adds paragraph with text and returns Paragraph
 func (c *Cell) AddParagraph(text string) *Paragraph {
-	p := newParagraph(c.root, paraWithText(text))
-	tblContent := ctypes.TCBlockContent{
-		Paragraph: &p.ct,
-	}
-
-	c.ct.Contents = append(c.ct.Contents, tblContent)
-
-	return p
+	return c.addParagraph(newParagraph(c.root, paraWithText(text)))
 }
 
 // Add empty paragraph without any text and returns Paragraph
 func (c *Cell) AddEmptyPara() *Paragraph {
-	p := newParagraph(c.root)
-	tblContent := ctypes.TCBlockContent{
-		Paragraph: &p.ct,
+	p := c.addParagraph(newParagraph(c.root))
+	if c.root != nil {
+		if c.root.emptyCellParas == nil {
+			c.root.emptyCellParas = make(map[*ctypes.Paragraph]bool)
+		}
+		c.root.emptyCellParas[&p.ct] = true
 	}
-
-	c.ct.Contents = append(c.ct.Contents, tblContent)
-
 	return p
 }
 
-// ColSpan sets the number of columns a cell should span across in a table.
+func (c *Cell) ensureProp() {
+	if c.ct.Property == nil {
+		c.ct.Property = &ctypes.CellProperty{}
+	}
+}
+
+// ColSpan sets the number of grid columns a cell spans. It is meant for building rows; use
+// Table.MergeCells to merge existing cells, which also removes the cells it covers.
 func (c *Cell) ColSpan(cols int) *Cell {
-	if c.ct.Property != nil {
+	c.ensureProp()
+	if cols > 1 {
 		c.ct.Property.GridSpan = &ctypes.DecimalNum{Val: cols}
+	} else {
+		c.ct.Property.GridSpan = nil
 	}
 	return c
 }
 
-// RowSpan sets the cell to span vertically in a table, indicating it is part of a vertically merged group of cells.
+// RowSpan marks the cell as the start of a vertically merged region. The cells below it in
+// the same grid columns that belong to the region are marked with RowSpanContinue.
 func (c *Cell) RowSpan() *Cell {
-	if c.ct.Property != nil {
-		vMerge := ctypes.AnnotationVMergeRest
-		c.ct.Property.CellMerge = &ctypes.CellMerge{
-			VMerge: &vMerge,
-		}
-	}
+	c.ensureProp()
+	c.ct.Property.VMerge = ctypes.NewGenOptStrVal(stypes.MergeCellRestart)
+	return c
+}
+
+// RowSpanContinue marks the cell as continuing the vertically merged region started in the
+// row above.
+func (c *Cell) RowSpanContinue() *Cell {
+	c.ensureProp()
+	c.ct.Property.VMerge = ctypes.NewGenOptStrVal(stypes.MergeCellContinue)
 	return c
 }
 
 // VerticalAlign sets the vertical alignment of a cell based on the provided string: "top", "center", "middle", or "bottom".
 func (c *Cell) VerticalAlign(valign string) *Cell {
-	if c.ct.Property != nil {
-		switch valign {
-		case "top":
-			c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcTop)
-		case "center", "middle":
-			c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcCenter)
-		case "bottom":
-			c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcBottom)
-		}
+	c.ensureProp()
+	switch valign {
+	case "top":
+		c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcTop)
+	case "center", "middle":
+		c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcCenter)
+	case "bottom":
+		c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcBottom)
 	}
 	return c
 }
 
 func (c *Cell) BackgroundColor(color string) *Cell {
+	c.ensureProp()
+	if c.ct.Property.Shading == nil {
+		c.ct.Property.Shading = ctypes.DefaultShading()
+	}
 	c.ct.Property.Shading.Fill = &color
 	return c
 }
 
 func (c *Cell) Width(width int, widthType stypes.TableWidth) *Cell {
+	c.ensureProp()
 	c.ct.Property.Width = ctypes.NewTableWidth(width, widthType)
 	return c
 }
 
 func (c *Cell) Borders(top *ctypes.Border, left *ctypes.Border, bottom *ctypes.Border, right *ctypes.Border,
 	insideH *ctypes.Border, insideV *ctypes.Border, tl2br *ctypes.Border, tr2bl *ctypes.Border) *Cell {
+	c.ensureProp()
 	c.ct.Property.Borders = &ctypes.CellBorders{
 		Top:     top,
 		Left:    left,
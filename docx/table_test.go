@@ -0,0 +1,367 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTable returns a table with a fixed width and three 1000 twip columns:
+//
+//	| A     | B | C |
+//	| D (merged | E |
+//	|  down)    | F |
+func setupTable(t *testing.T) *Table {
+	t.Helper()
+
+	rd := setupRootDoc(t)
+	tbl := rd.AddTable()
+	tbl.Width(3000, stypes.TableWidthDxa)
+	tbl.Grid(1000, 1000, 1000)
+
+	row := tbl.AddRow()
+	row.AddCell().Width(1000, stypes.TableWidthDxa).AddParagraph("A")
+	row.AddCell().Width(1000, stypes.TableWidthDxa).AddParagraph("B")
+	row.AddCell().Width(1000, stypes.TableWidthDxa).AddParagraph("C")
+
+	row = tbl.AddRow()
+	merged := row.AddCell().Width(2000, stypes.TableWidthDxa).ColSpan(2)
+	merged.GetCT().Property.VMerge = ctypes.NewGenOptStrVal(stypes.MergeCellRestart)
+	merged.AddParagraph("D")
+	row.AddCell().Width(1000, stypes.TableWidthDxa).AddParagraph("E")
+
+	row = tbl.AddRow()
+	covered := row.AddCell().Width(2000, stypes.TableWidthDxa).ColSpan(2)
+	covered.GetCT().Property.VMerge = ctypes.NewGenOptStrVal(stypes.MergeCellContinue)
+	covered.AddEmptyPara()
+	row.AddCell().Width(1000, stypes.TableWidthDxa).AddParagraph("F")
+
+	return tbl
+}
+
+func cellText(c *Cell) string {
+	if c == nil {
+		return "<nil>"
+	}
+	var text string
+	for _, block := range c.GetCT().Contents {
+		if block.Paragraph == nil {
+			continue
+		}
+		for _, child := range block.Paragraph.Children {
+			if child.Run == nil {
+				continue
+			}
+			for _, rc := range child.Run.Children {
+				if rc.Text != nil {
+					text += rc.Text.Text
+				}
+			}
+		}
+	}
+	return text
+}
+
+// tableTexts returns the text of every grid position of the table.
+func tableTexts(tbl *Table) [][]string {
+	var texts [][]string
+	for r := 0; r < tbl.RowCount(); r++ {
+		var row []string
+		for c := 0; c < tbl.ColumnCount(); c++ {
+			row = append(row, cellText(tbl.Cell(r, c)))
+		}
+		texts = append(texts, row)
+	}
+	return texts
+}
+
+func TestTable_Access(t *testing.T) {
+	tbl := setupTable(t)
+
+	assert.Equal(t, 3, tbl.RowCount())
+	assert.Equal(t, 3, tbl.ColumnCount())
+	assert.Equal(t, [][]string{{"A", "B", "C"}, {"D", "D", "E"}, {"D", "D", "F"}}, tableTexts(tbl))
+
+	assert.Equal(t, "E", cellText(tbl.Row(1).Cell(1)))
+	assert.Nil(t, tbl.Row(3))
+	assert.Nil(t, tbl.Row(0).Cell(3))
+	assert.Nil(t, tbl.Cell(0, 3))
+}
+
+func TestTable_InsertRow(t *testing.T) {
+	tests := []struct {
+		name     string
+		at       int
+		expected [][]string
+	}{
+		{"Top", 0, [][]string{{"", "", ""}, {"A", "B", "C"}, {"D", "D", "E"}, {"D", "D", "F"}}},
+		{"InsideMerge", 2, [][]string{{"A", "B", "C"}, {"D", "D", "E"}, {"D", "D", ""}, {"D", "D", "F"}}},
+		{"End", 3, [][]string{{"A", "B", "C"}, {"D", "D", "E"}, {"D", "D", "F"}, {"", "", ""}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := setupTable(t)
+
+			row, err := tbl.InsertRow(tt.at)
+			require.NoError(t, err)
+			assert.Same(t, row.GetCT(), tbl.Row(tt.at).GetCT())
+			assert.Equal(t, tt.expected, tableTexts(tbl))
+
+			// The new cells keep the formatting of the reference row
+			assert.Equal(t, 1000, *row.Cell(row.CellCount() - 1).GetCT().Property.Width.Width)
+		})
+	}
+
+	_, err := setupTable(t).InsertRow(4)
+	assert.Error(t, err)
+}
+
+func TestTable_DuplicateRow(t *testing.T) {
+	tbl := setupTable(t)
+	tbl.Row(0).GetCT().Property.CantSplit = ctypes.OnOffFromBool(true)
+
+	rows, err := tbl.DuplicateRow(0, 2)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, 5, tbl.RowCount())
+	assert.Equal(t, []string{"A", "B", "C"}, tableTexts(tbl)[2])
+	assert.True(t, rows[1].GetCT().Property.CantSplit.Bool())
+
+	// Copies are independent of the row they were made from
+	rows[0].Cell(0).GetCT().Contents = nil
+	assert.Equal(t, "A", cellText(tbl.Cell(0, 0)))
+
+	// Duplicating a row inside a merged region extends the region
+	_, err = tbl.DuplicateRow(3, 1)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"D", "D", "E"}, {"D", "D", "E"}, {"D", "D", "F"}}, tableTexts(tbl)[3:])
+
+	_, err = tbl.DuplicateRow(10, 1)
+	assert.Error(t, err)
+}
+
+func TestTable_DeleteRow(t *testing.T) {
+	tbl := setupTable(t)
+
+	require.NoError(t, tbl.DeleteRow(1))
+	assert.Equal(t, [][]string{{"A", "B", "C"}, {"D", "D", "F"}}, tableTexts(tbl))
+	assert.Equal(t, stypes.MergeCellRestart, *tbl.Row(1).Cell(0).GetCT().Property.VMerge.Val)
+
+	assert.Error(t, tbl.DeleteRow(2))
+}
+
+func TestTable_InsertColumn(t *testing.T) {
+	tests := []struct {
+		name     string
+		at       int
+		expected [][]string
+		spans    []int
+	}{
+		{"First", 0, [][]string{{"", "A", "B", "C"}, {"", "D", "D", "E"}, {"", "D", "D", "F"}}, []int{1, 2, 1}},
+		{"InsideSpan", 1, [][]string{{"A", "", "B", "C"}, {"D", "D", "D", "E"}, {"D", "D", "D", "F"}}, []int{3, 1}},
+		{"Last", 3, [][]string{{"A", "B", "C", ""}, {"D", "D", "E", ""}, {"D", "D", "F", ""}}, []int{2, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := setupTable(t)
+
+			require.NoError(t, tbl.InsertColumn(tt.at))
+			assert.Equal(t, tt.expected, tableTexts(tbl))
+
+			ct := tbl.GetCT()
+			assert.Len(t, ct.Grid.Col, 4)
+			assert.Equal(t, 4000, *ct.TableProp.Width.Width)
+
+			var spans []int
+			for i := 0; i < tbl.Row(1).CellCount(); i++ {
+				spans = append(spans, cellSpan(tbl.Row(1).Cell(i).GetCT()))
+			}
+			assert.Equal(t, tt.spans, spans)
+			assert.Equal(t, 1000*tt.spans[0], *tbl.Row(1).Cell(0).GetCT().Property.Width.Width)
+
+			// The merged region is not extended into new cells
+			assert.Nil(t, tbl.Row(0).Cell(0).GetCT().Property.VMerge)
+		})
+	}
+
+	assert.Error(t, setupTable(t).InsertColumn(4))
+
+	// An empty table gets its first grid column
+	empty := setupRootDoc(t).AddTable()
+	require.NoError(t, empty.InsertColumn(0))
+	require.Len(t, empty.GetCT().Grid.Col, 1)
+	assert.Nil(t, empty.GetCT().Grid.Col[0].Width)
+	assert.Equal(t, 1, empty.columnCount())
+	assert.Error(t, empty.InsertColumn(2))
+}
+
+func TestTable_InsertColumn_LoadedRows(t *testing.T) {
+	// The second row is short and, like its cells, has no properties
+	data := `<w:tbl ` + mergeNS + `><w:tblGrid><w:gridCol w:w="1000"/><w:gridCol w:w="1000"/><w:gridCol w:w="1000"/></w:tblGrid>` +
+		`<w:tr><w:tc><w:p><w:r><w:t>A</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>B</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>C</w:t></w:r></w:p></w:tc></w:tr>` +
+		`<w:tr><w:tc><w:p><w:r><w:t>D</w:t></w:r></w:p></w:tc></w:tr></w:tbl>`
+
+	tests := []struct {
+		name     string
+		at       int
+		expected [][]string
+	}{
+		{"Last", 3, [][]string{{"A", "B", "C", ""}, {"D", "<nil>", "<nil>", "<nil>"}}},
+		{"InShortRow", 1, [][]string{{"A", "", "B", "C"}, {"D", "", "<nil>", "<nil>"}}},
+		{"First", 0, [][]string{{"", "A", "B", "C"}, {"", "D", "<nil>", "<nil>"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := &Table{root: setupRootDoc(t)}
+			require.NoError(t, xml.Unmarshal([]byte(data), &tbl.ct))
+			require.Nil(t, tbl.ct.RowContents[1].Row.Property)
+
+			require.NoError(t, tbl.InsertColumn(tt.at))
+			assert.Len(t, tbl.GetCT().Grid.Col, 4)
+			assert.Equal(t, tt.expected, tableTexts(tbl))
+		})
+	}
+}
+
+func TestTable_DeleteColumn(t *testing.T) {
+	tbl := setupTable(t)
+
+	require.NoError(t, tbl.DeleteColumn(0))
+	assert.Equal(t, [][]string{{"B", "C"}, {"D", "E"}, {"D", "F"}}, tableTexts(tbl))
+	assert.Equal(t, 2000, *tbl.GetCT().TableProp.Width.Width)
+	assert.Nil(t, tbl.Row(1).Cell(0).GetCT().Property.GridSpan)
+	assert.Equal(t, 1000, *tbl.Row(1).Cell(0).GetCT().Property.Width.Width)
+
+	require.NoError(t, tbl.DeleteColumn(1))
+	assert.Equal(t, [][]string{{"B"}, {"D"}, {"D"}}, tableTexts(tbl))
+	assert.Len(t, tbl.GetCT().Grid.Col, 1)
+
+	assert.Error(t, tbl.DeleteColumn(0))
+}
+
+func TestTable_LogicalGrid(t *testing.T) {
+	tbl := setupTable(t)
+
+	grid := tbl.LogicalGrid()
+	require.Len(t, grid, 3)
+
+	merged := grid[2][1]
+	assert.True(t, merged.Merged())
+	assert.Equal(t, GridCell{Cell: merged.Cell, Row: 1, Col: 0, RowSpan: 2, ColSpan: 2}, merged)
+	assert.Same(t, grid[1][0].Cell, merged.Cell)
+	assert.Equal(t, "D", cellText(merged.Cell))
+
+	assert.False(t, grid[0][2].Merged())
+	assert.Equal(t, "C", cellText(grid[0][2].Cell))
+}
+
+func TestTable_MergeCells(t *testing.T) {
+	tbl := setupTable(t)
+
+	require.NoError(t, tbl.MergeCells(0, 1, 0, 2))
+	require.NoError(t, tbl.MergeCells(2, 2, 1, 2))
+	assert.Equal(t, [][]string{{"A", "BC", "BC"}, {"D", "D", "EF"}, {"D", "D", "EF"}}, tableTexts(tbl))
+
+	top := tbl.Row(0)
+	require.Equal(t, 2, top.CellCount())
+	prop := top.Cell(1).GetCT().Property
+	assert.Equal(t, 2, prop.GridSpan.Val)
+	assert.Equal(t, 2000, *prop.Width.Width)
+	assert.Nil(t, prop.VMerge)
+
+	assert.Equal(t, stypes.MergeCellRestart, *tbl.Row(1).Cell(1).GetCT().Property.VMerge.Val)
+	assert.Equal(t, stypes.MergeCellContinue, *tbl.Row(2).Cell(1).GetCT().Property.VMerge.Val)
+	assert.Equal(t, "", cellText(tbl.Row(2).Cell(1)))
+
+	// Ranges cutting through a merged region are rejected
+	assert.Error(t, tbl.MergeCells(0, 0, 1, 0))
+	assert.Error(t, tbl.MergeCells(0, 0, 0, 1))
+	assert.Error(t, tbl.MergeCells(0, 0, 3, 0))
+}
+
+func TestTable_SplitCell(t *testing.T) {
+	tbl := setupTable(t)
+
+	require.NoError(t, tbl.SplitCell(2, 1))
+	assert.Equal(t, [][]string{{"A", "B", "C"}, {"D", "", "E"}, {"", "", "F"}}, tableTexts(tbl))
+
+	for r := 1; r < 3; r++ {
+		row := tbl.Row(r)
+		require.Equal(t, 3, row.CellCount())
+		for i := 0; i < 3; i++ {
+			prop := row.Cell(i).GetCT().Property
+			assert.Nil(t, prop.GridSpan)
+			assert.Nil(t, prop.VMerge)
+			assert.Equal(t, 1000, *prop.Width.Width)
+		}
+	}
+
+	require.NoError(t, tbl.SplitCell(0, 0))
+	assert.Error(t, tbl.SplitCell(5, 0))
+}
+
+func TestCell_Spans(t *testing.T) {
+	cell := &Cell{ct: &ctypes.Cell{}}
+
+	cell.ColSpan(3).RowSpan()
+	assert.Equal(t, 3, cell.GetCT().Property.GridSpan.Val)
+	assert.Equal(t, stypes.MergeCellRestart, *cell.GetCT().Property.VMerge.Val)
+	assert.Nil(t, cell.GetCT().Property.CellMerge)
+
+	cell.ColSpan(1).RowSpanContinue()
+	assert.Nil(t, cell.GetCT().Property.GridSpan)
+	assert.Equal(t, stypes.MergeCellContinue, *cell.GetCT().Property.VMerge.Val)
+}
+
+func TestRow_Properties(t *testing.T) {
+	tbl := setupTable(t)
+	row := tbl.Row(0)
+
+	// Loaded rows may have no properties
+	row.GetCT().Property = nil
+	assert.False(t, row.GetRepeatAsHeader())
+	assert.False(t, row.GetCantSplit())
+	assert.False(t, row.GetHidden())
+	assert.Equal(t, stypes.Justification(""), row.GetJustification())
+	height, rule := row.GetHeight()
+	assert.Equal(t, 0, height)
+	assert.Equal(t, stypes.HeightRuleAuto, rule)
+
+	row.RepeatAsHeader(true).
+		CantSplit(true).
+		Height(567, stypes.HeightRuleExact).
+		Justification(stypes.JustificationCenter).
+		Hidden(true)
+
+	assert.True(t, tbl.Row(0).GetRepeatAsHeader())
+	assert.True(t, tbl.Row(0).GetCantSplit())
+	assert.True(t, tbl.Row(0).GetHidden())
+	assert.Equal(t, stypes.JustificationCenter, tbl.Row(0).GetJustification())
+	height, rule = tbl.Row(0).GetHeight()
+	assert.Equal(t, 567, height)
+	assert.Equal(t, stypes.HeightRuleExact, rule)
+
+	output, err := xml.Marshal(row.GetCT().Property)
+	require.NoError(t, err)
+	assert.Equal(t, `<w:trPr><w:cantSplit></w:cantSplit><w:trHeight w:val="567" w:hRule="exact"></w:trHeight>`+
+		`<w:tblHeader></w:tblHeader><w:jc w:val="center"></w:jc><w:hidden></w:hidden></w:trPr>`, string(output))
+
+	row.RepeatAsHeader(false).CantSplit(false).Hidden(false)
+	assert.False(t, row.GetRepeatAsHeader())
+	assert.False(t, row.GetCantSplit())
+	assert.False(t, row.GetHidden())
+	assert.Nil(t, row.GetCT().Property.Header)
+
+	// A height without a rule is a minimum
+	row.GetCT().Property.Height.HRule = nil
+	_, rule = row.GetHeight()
+	assert.Equal(t, stypes.HeightRuleAtLeast, rule)
+}
@@ -0,0 +1,199 @@
+package docx
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bfoley13/godocx/wml/ctypes"
+)
+
+// CSVOptions configures the export of tables as records.
+type CSVOptions struct {
+	// Comma is the field delimiter, ',' when zero. Use '\t' for TSV.
+	Comma rune
+
+	// ParagraphSeparator joins the paragraphs of a cell, "\n" when empty
+	ParagraphSeparator string
+
+	// FirstParagraphOnly keeps only the first paragraph of each cell
+	FirstParagraphOnly bool
+
+	// RepeatMerged writes the content of a merged cell in every position it covers. By
+	// default only the top left position holds the content and the others are empty.
+	RepeatMerged bool
+}
+
+// CSVTableOptions configures the tables built by AddTableFromCSV.
+type CSVTableOptions struct {
+	// Comma is the field delimiter, ',' when zero. Use '\t' for TSV.
+	Comma rune
+
+	// Header makes the first record the header row of the table
+	Header bool
+
+	// Table formats the table. Values of columns with a Format that parse as numbers are
+	// formatted as numbers.
+	Table TableDataOptions
+}
+
+// ToRecords returns the text of the table as one record per row and one field per grid
+// column, flattening merged cells.
+func (t *Table) ToRecords(opts CSVOptions) [][]string {
+	sep := opts.ParagraphSeparator
+	if sep == "" {
+		sep = "\n"
+	}
+
+	texts := make(map[*Cell]string)
+	grid := t.LogicalGrid()
+	records := make([][]string, 0, len(grid))
+	for r, row := range grid {
+		record := make([]string, len(row))
+		for c, pos := range row {
+			if pos.Cell == nil || (!opts.RepeatMerged && (pos.Row != r || pos.Col != c)) {
+				continue
+			}
+			text, ok := texts[pos.Cell]
+			if !ok {
+				text = cellPlainText(pos.Cell.ct, sep, opts.FirstParagraphOnly)
+				texts[pos.Cell] = text
+			}
+			record[c] = text
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// ToCSV writes the text of the table to w as CSV, flattening merged cells.
+func (t *Table) ToCSV(w io.Writer, opts CSVOptions) error {
+	writer := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		writer.Comma = opts.Comma
+	}
+	return writer.WriteAll(t.ToRecords(opts))
+}
+
+// Tables returns the tables of the document body, in document order.
+func (rd *RootDoc) Tables() []*Table {
+	if rd.Document == nil || rd.Document.Body == nil {
+		return nil
+	}
+
+	var tables []*Table
+	for _, child := range rd.Document.Body.Children {
+		if child.Table != nil {
+			tables = append(tables, child.Table)
+		}
+	}
+	return tables
+}
+
+// TableRecords returns the records of every table of the document body, in document order.
+func (rd *RootDoc) TableRecords(opts CSVOptions) [][][]string {
+	tables := rd.Tables()
+	records := make([][][]string, 0, len(tables))
+	for _, tbl := range tables {
+		records = append(records, tbl.ToRecords(opts))
+	}
+	return records
+}
+
+// AddTableFromCSV appends a table built from CSV data to the document.
+func (rd *RootDoc) AddTableFromCSV(r io.Reader, opts CSVTableOptions) (*Table, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var headers []string
+	if opts.Header && len(records) > 0 {
+		headers, records = records[0], records[1:]
+	}
+
+	rows := make([][]any, len(records))
+	for i, record := range records {
+		rows[i] = make([]any, len(record))
+		for col, field := range record {
+			rows[i][col] = field
+			if col < len(opts.Table.Columns) && opts.Table.Columns[col].Format != "" {
+				if number, err := strconv.ParseFloat(strings.TrimSpace(field), 64); err == nil {
+					rows[i][col] = number
+				}
+			}
+		}
+	}
+
+	return rd.AddTableFromData(headers, rows, opts.Table)
+}
+
+// cellPlainText returns the text of the paragraphs of a cell joined with sep.
+func cellPlainText(cell *ctypes.Cell, sep string, firstOnly bool) string {
+	var paragraphs []string
+	for _, block := range cell.Contents {
+		if block.Paragraph == nil {
+			continue
+		}
+		paragraphs = append(paragraphs, paragraphPlainText(block.Paragraph))
+		if firstOnly {
+			break
+		}
+	}
+	return strings.Join(paragraphs, sep)
+}
+
+// paragraphPlainText returns the text of a paragraph without any formatting, including the
+// text of its links, content controls and simple field results.
+func paragraphPlainText(p *ctypes.Paragraph) string {
+	var sb strings.Builder
+
+	writeRun := func(run *ctypes.Run) {
+		for _, child := range run.Children {
+			switch {
+			case child.Text != nil:
+				sb.WriteString(child.Text.Text)
+			case child.Tab != nil:
+				sb.WriteString("\t")
+			case child.Break != nil, child.CarrRtn != nil:
+				sb.WriteString("\n")
+			case child.NoBreakHyphen != nil:
+				sb.WriteString("-")
+			}
+		}
+	}
+
+	var writeChildren func(children []ctypes.ParagraphChild)
+	writeChildren = func(children []ctypes.ParagraphChild) {
+		for _, child := range children {
+			if child.Run != nil {
+				writeRun(child.Run)
+			}
+			if child.Link != nil {
+				writeChildren(child.Link.Content())
+			}
+			if child.Sdt != nil && child.Sdt.Content != nil {
+				for _, content := range child.Sdt.Content.Children {
+					if content.Run != nil {
+						writeRun(content.Run)
+					}
+				}
+			}
+			if child.SimpleField != nil {
+				writeChildren(child.SimpleField.Children)
+			}
+		}
+	}
+	writeChildren(p.Children)
+
+	return sb.String()
+}
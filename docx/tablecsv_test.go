@@ -0,0 +1,63 @@
+package docx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_ToRecords(t *testing.T) {
+	tbl := setupTable(t)
+	tbl.Row(0).Cell(0).AddParagraph("second")
+
+	tests := []struct {
+		name     string
+		opts     CSVOptions
+		expected [][]string
+	}{
+		{"Default", CSVOptions{}, [][]string{{"A\nsecond", "B", "C"}, {"D", "", "E"}, {"", "", "F"}}},
+		{"RepeatMerged", CSVOptions{RepeatMerged: true, FirstParagraphOnly: true},
+			[][]string{{"A", "B", "C"}, {"D", "D", "E"}, {"D", "D", "F"}}},
+		{"Separator", CSVOptions{ParagraphSeparator: " / "}, [][]string{{"A / second", "B", "C"}, {"D", "", "E"}, {"", "", "F"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tbl.ToRecords(tt.opts))
+		})
+	}
+}
+
+func TestTable_ToCSV(t *testing.T) {
+	tbl := setupTable(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, tbl.ToCSV(&buf, CSVOptions{Comma: '\t'}))
+	assert.Equal(t, "A\tB\tC\nD\t\tE\n\t\tF\n", buf.String())
+}
+
+func TestAddTableFromCSV(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.AddParagraph("Intro")
+
+	input := "Account,Balance\nCash,\"1234.5\"\n\"Loans, net\",-80\n"
+	tbl, err := rd.AddTableFromCSV(strings.NewReader(input), CSVTableOptions{
+		Header: true,
+		Table:  TableDataOptions{Columns: []ColumnFormat{{}, {Format: "#,##0.00"}}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{{"Account", "Balance"}, {"Cash", "1,234.50"}, {"Loans, net", "-80.00"}}, tbl.ToRecords(CSVOptions{}))
+	assert.Equal(t, "0620", tbl.GetCT().TableProp.TableLook.Val)
+
+	require.Len(t, rd.Tables(), 1)
+	assert.Same(t, tbl, rd.Tables()[0])
+	assert.Equal(t, [][][]string{tbl.ToRecords(CSVOptions{})}, rd.TableRecords(CSVOptions{}))
+
+	_, err = rd.AddTableFromCSV(strings.NewReader("a\tb\n"), CSVTableOptions{Comma: '\t'})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "b"}}, rd.Tables()[1].ToRecords(CSVOptions{}))
+}
@@ -0,0 +1,396 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// TableDataOptions configures the tables built by AddTableFromData.
+type TableDataOptions struct {
+	// Style is the table style ID, such as "TableGrid". The table has no style when empty.
+	Style string
+
+	// HeaderStyle is the paragraph style of the header cells
+	HeaderStyle string
+
+	// HeaderBold makes the header text bold
+	HeaderBold bool
+
+	// HeaderShading is the fill color of the header cells, such as "D9E2F3"
+	HeaderShading string
+
+	// RepeatHeader repeats the header row at the top of every page the table spans
+	RepeatHeader bool
+
+	// Banded turns on the row banding defined by the table style through the table look
+	Banded bool
+
+	// BandShading is the fill color of every second body row, for table styles without
+	// banding
+	BandShading string
+
+	// Columns formats the columns by index
+	Columns []ColumnFormat
+
+	// Widths are the column widths in twentieths of a point. When empty, the widths are
+	// computed from the length of the content of each column and fill the width between the
+	// page margins.
+	Widths []int
+}
+
+// ColumnFormat formats the cells of a table column.
+type ColumnFormat struct {
+	// Align is the justification of the cell paragraphs
+	Align stypes.Justification
+
+	// Format formats numeric values with a numeric picture such as "#,##0.00", see
+	// FormatNumber, or with a fmt verb such as "%.1f%%". Time values are formatted with Format
+	// as a time layout, and as "2006-01-02" by default.
+	Format string
+}
+
+// defaultTextWidth is the text width of a Letter page with one inch margins, in twentieths of
+// a point.
+const defaultTextWidth = 9360
+
+// AddTableFromData appends a table built from structured data to the document.
+//
+// Rows must be a slice whose elements are either slices of cell values, such as [][]string or
+// [][]any, or structs or pointers to structs. The cells of a struct row are its exported
+// fields, in order. A field tag `docx:"Name"` sets the header of the field's column and
+// `docx:"-"` leaves the field out. When headers is nil, the headers of struct rows are taken
+// from the tags, or the field names, and other data has no header row.
+func (rd *RootDoc) AddTableFromData(headers []string, rows any, opts TableDataOptions) (*Table, error) {
+	cells, fieldHeaders, err := tableDataCells(rows, opts.Columns)
+	if err != nil {
+		return nil, err
+	}
+	if headers == nil {
+		headers = fieldHeaders
+	}
+
+	cols := len(headers)
+	for _, row := range cells {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	if cols == 0 {
+		return nil, errors.New("table data has no columns")
+	}
+
+	widths := opts.Widths
+	if len(widths) < cols {
+		widths = autoColumnWidths(headers, cells, cols, rd.textWidth())
+	}
+	total := 0
+	for _, w := range widths[:cols] {
+		total += w
+	}
+
+	tbl := rd.AddTable()
+	if opts.Style != "" {
+		tbl.Style(opts.Style)
+	}
+	tbl.Width(total, stypes.TableWidthDxa)
+	for _, w := range widths[:cols] {
+		tbl.Grid(uint64(w))
+	}
+
+	tbl.Look(TableLook{FirstRow: len(headers) > 0, BandedRows: opts.Banded})
+
+	format := func(col int) ColumnFormat {
+		if col < len(opts.Columns) {
+			return opts.Columns[col]
+		}
+		return ColumnFormat{}
+	}
+
+	if len(headers) > 0 {
+		row := tbl.AddRow()
+		row.RepeatAsHeader(opts.RepeatHeader)
+		for col := 0; col < cols; col++ {
+			text := ""
+			if col < len(headers) {
+				text = headers[col]
+			}
+			cell := row.AddCell().Width(widths[col], stypes.TableWidthDxa)
+			if opts.HeaderShading != "" {
+				cell.BackgroundColor(opts.HeaderShading)
+			}
+			p := addCellText(cell, text, format(col).Align)
+			if opts.HeaderStyle != "" {
+				p.Style(opts.HeaderStyle)
+			}
+			if opts.HeaderBold {
+				for _, child := range p.ct.Children {
+					if child.Run != nil {
+						newRun(rd, child.Run).Bold(true)
+					}
+				}
+			}
+		}
+	}
+
+	for i, values := range cells {
+		row := tbl.AddRow()
+		for col := 0; col < cols; col++ {
+			text := ""
+			if col < len(values) {
+				text = values[col]
+			}
+			cell := row.AddCell().Width(widths[col], stypes.TableWidthDxa)
+			if opts.BandShading != "" && i%2 == 1 {
+				cell.BackgroundColor(opts.BandShading)
+			}
+			addCellText(cell, text, format(col).Align)
+		}
+	}
+
+	return tbl, nil
+}
+
+// addCellText adds a paragraph with text to a cell, with a line break for every newline.
+func addCellText(cell *Cell, text string, align stypes.Justification) *Paragraph {
+	p := cell.AddEmptyPara()
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			brType := stypes.BreakTypeTextWrapping
+			p.AddRun().AddBreak(&brType)
+		}
+		if line != "" {
+			p.AddText(line)
+		}
+	}
+	if align != "" {
+		p.Justification(align)
+	}
+	return p
+}
+
+// tableDataCells converts table data to cell texts. It returns the headers of struct fields.
+func tableDataCells(rows any, columns []ColumnFormat) ([][]string, []string, error) {
+	value := reflect.ValueOf(rows)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, nil, fmt.Errorf("table data must be a slice, not %T", rows)
+	}
+
+	format := func(col int) string {
+		if col < len(columns) {
+			return columns[col].Format
+		}
+		return ""
+	}
+
+	var (
+		cells   [][]string
+		headers []string
+		fields  []int
+	)
+
+	elemType := value.Type().Elem()
+	for elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() == reflect.Struct {
+		for i := 0; i < elemType.NumField(); i++ {
+			field := elemType.Field(i)
+			tag := field.Tag.Get("docx")
+			if !field.IsExported() || tag == "-" {
+				continue
+			}
+			name := field.Name
+			if tag != "" {
+				name = tag
+			}
+			headers = append(headers, name)
+			fields = append(fields, i)
+		}
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		elem := value.Index(i)
+		for elem.Kind() == reflect.Pointer || elem.Kind() == reflect.Interface {
+			if elem.IsNil() {
+				break
+			}
+			elem = elem.Elem()
+		}
+
+		var row []string
+		switch elem.Kind() {
+		case reflect.Struct:
+			for col, field := range fields {
+				row = append(row, cellValueText(elem.Field(field), format(col)))
+			}
+		case reflect.Slice, reflect.Array:
+			for col := 0; col < elem.Len(); col++ {
+				row = append(row, cellValueText(elem.Index(col), format(col)))
+			}
+		case reflect.Pointer, reflect.Interface:
+			// A nil row is an empty row
+		default:
+			return nil, nil, fmt.Errorf("table row %d must be a slice or struct, not %s", i, elem.Type())
+		}
+		cells = append(cells, row)
+	}
+
+	return cells, headers, nil
+}
+
+// cellValueText returns the text of a cell value.
+func cellValueText(value reflect.Value, format string) string {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return ""
+	}
+
+	if value.Type() == reflect.TypeOf(time.Time{}) {
+		layout := format
+		if layout == "" {
+			layout = "2006-01-02"
+		}
+		return value.Interface().(time.Time).Format(layout)
+	}
+
+	var number float64
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		number = value.Float()
+	case reflect.String:
+		return value.String()
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	default:
+		if value.CanInterface() {
+			return fmt.Sprint(value.Interface())
+		}
+		return ""
+	}
+
+	switch {
+	case strings.Contains(format, "%"):
+		// Floating point verbs format integers too
+		if i := strings.LastIndexAny(format, "eEfFgG"); i > 0 && strings.LastIndexByte(format[:i], '%') >= 0 {
+			return fmt.Sprintf(format, number)
+		}
+		return fmt.Sprintf(format, value.Interface())
+	case format != "":
+		return FormatNumber(number, format)
+	default:
+		return fmt.Sprint(value.Interface())
+	}
+}
+
+// autoColumnWidths divides the available width between the columns in proportion to the
+// length of their longest line of text.
+func autoColumnWidths(headers []string, cells [][]string, cols, available int) []int {
+	lengths := make([]int, cols)
+	measure := func(col int, text string) {
+		for _, line := range strings.Split(text, "\n") {
+			if n := utf8.RuneCountInString(line); n > lengths[col] {
+				lengths[col] = n
+			}
+		}
+	}
+	for col, text := range headers {
+		measure(col, text)
+	}
+	for _, row := range cells {
+		for col, text := range row {
+			measure(col, text)
+		}
+	}
+
+	// Very short and very long columns are kept readable
+	sum := 0
+	for col, n := range lengths {
+		if n < 4 {
+			n = 4
+		}
+		if n > 50 {
+			n = 50
+		}
+		lengths[col] = n
+		sum += n
+	}
+
+	widths := make([]int, cols)
+	used := 0
+	for col, n := range lengths {
+		widths[col] = available * n / sum
+		used += widths[col]
+	}
+	widths[cols-1] += available - used
+
+	return widths
+}
+
+// textWidth returns the width between the page margins of the last section of the document,
+// in twentieths of a point.
+func (rd *RootDoc) textWidth() int {
+	if rd.Document == nil || rd.Document.Body == nil {
+		return defaultTextWidth
+	}
+	return sectionTextWidth(rd.Document.Body.SectPr)
+}
+
+// textWidth returns the width between the page margins of the section holding the paragraph,
+// in twentieths of a point. The last section is used for paragraphs that are not body
+// elements, such as those of table cells.
+func (p *Paragraph) textWidth() int {
+	rd := p.root
+	if rd == nil || rd.Document == nil || rd.Document.Body == nil {
+		return defaultTextWidth
+	}
+
+	for i, child := range rd.Document.Body.Children {
+		if child.Para == p {
+			return sectionTextWidth(rd.sectionAt(i))
+		}
+	}
+	return rd.textWidth()
+}
+
+// sectionTextWidth returns the width between the page margins of a section, in twentieths of
+// a point.
+func sectionTextWidth(sectPr *ctypes.SectionProp) int {
+	if sectPr == nil || sectPr.PageSize == nil || sectPr.PageSize.Width == nil {
+		return defaultTextWidth
+	}
+
+	width := int(*sectPr.PageSize.Width)
+	if margin := sectPr.PageMargin; margin != nil {
+		if margin.Left != nil {
+			width -= *margin.Left
+		}
+		if margin.Right != nil {
+			width -= *margin.Right
+		}
+		if margin.Gutter != nil {
+			width -= *margin.Gutter
+		}
+	}
+
+	if width <= 0 {
+		return defaultTextWidth
+	}
+	return width
+}
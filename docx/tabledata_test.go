@@ -0,0 +1,107 @@
+package docx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lineItem struct {
+	Description string    `docx:"Item"`
+	Amount      float64   `docx:"Amount"`
+	Due         time.Time `docx:"Due date"`
+	internal    string
+	Notes       string `docx:"-"`
+}
+
+func TestAddTableFromData_Structs(t *testing.T) {
+	rd := setupRootDoc(t)
+	width, left, right := uint64(12240), 1440, 1800
+	rd.Document.Body.SectPr = &ctypes.SectionProp{
+		PageSize:   &ctypes.PageSize{Width: &width},
+		PageMargin: &ctypes.PageMargin{Left: &left, Right: &right},
+	}
+
+	items := []*lineItem{
+		{Description: "Consulting services", Amount: 12500, Due: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Description: "Travel", Amount: 980.5, Due: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{Description: "Licenses", Amount: 45, Due: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tbl, err := rd.AddTableFromData(nil, items, TableDataOptions{
+		Style:         "TableGrid",
+		HeaderBold:    true,
+		HeaderShading: "D9E2F3",
+		RepeatHeader:  true,
+		Banded:        true,
+		Columns: []ColumnFormat{
+			{},
+			{Align: stypes.JustificationRight, Format: "#,##0.00"},
+			{Format: "02 Jan 2006"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{
+		{"Item", "Amount", "Due date"},
+		{"Consulting services", "12,500.00", "01 Mar 2024"},
+		{"Travel", "980.50", "15 Mar 2024"},
+		{"Licenses", "45.00", "01 Apr 2024"},
+	}, tableTexts(tbl))
+
+	ct := tbl.GetCT()
+	assert.Equal(t, "TableGrid", ct.TableProp.Style.Val)
+	assert.Equal(t, "0420", ct.TableProp.TableLook.Val)
+
+	// The widths fill the text width in proportion to the content
+	assert.Equal(t, 9000, *ct.TableProp.Width.Width)
+	var total uint64
+	for _, col := range ct.Grid.Col {
+		total += *col.Width
+	}
+	assert.Equal(t, uint64(9000), total)
+	assert.Greater(t, *ct.Grid.Col[0].Width, *ct.Grid.Col[1].Width)
+
+	header := tbl.Row(0)
+	assert.True(t, header.GetCT().Property.Header.Bool())
+	headerCell := header.Cell(0).GetCT()
+	assert.Equal(t, "D9E2F3", *headerCell.Property.Shading.Fill)
+	assert.True(t, headerCell.Contents[0].Paragraph.Children[0].Run.Property.Bold.Bool())
+
+	amount := tbl.Row(1).Cell(1).GetCT().Contents[0].Paragraph
+	assert.Equal(t, stypes.JustificationRight, amount.Property.Justification.Val)
+}
+
+func TestAddTableFromData_Slices(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	tbl, err := rd.AddTableFromData([]string{"Name", "Count"}, [][]any{
+		{"a", 1},
+		{"b", 2.5, "extra"},
+		nil,
+	}, TableDataOptions{
+		Widths:      []int{2000, 1000, 1000},
+		BandShading: "EEEEEE",
+		Columns:     []ColumnFormat{{}, {Format: "%.1f"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{{"Name", "Count", ""}, {"a", "1.0", ""}, {"b", "2.5", "extra"}, {"", "", ""}}, tableTexts(tbl))
+	assert.Equal(t, "0620", tbl.GetCT().TableProp.TableLook.Val)
+	assert.Equal(t, 4000, *tbl.GetCT().TableProp.Width.Width)
+	assert.Nil(t, tbl.GetCT().TableProp.Style)
+	assert.Nil(t, tbl.Row(0).GetCT().Property.Header)
+
+	// Every second body row is shaded
+	assert.NotEqual(t, "EEEEEE", *tbl.Row(1).Cell(0).GetCT().Property.Shading.Fill)
+	assert.Equal(t, "EEEEEE", *tbl.Row(2).Cell(0).GetCT().Property.Shading.Fill)
+
+	_, err = rd.AddTableFromData(nil, "not a slice", TableDataOptions{})
+	assert.Error(t, err)
+	_, err = rd.AddTableFromData(nil, []int{1, 2}, TableDataOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,508 @@
+package docx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// RowCount returns the number of rows in the table.
+func (t *Table) RowCount() int {
+	return len(t.rows())
+}
+
+// ColumnCount returns the number of grid columns of the table.
+func (t *Table) ColumnCount() int {
+	return t.columnCount()
+}
+
+// Row returns the row at index i, or nil if the index is out of range.
+func (t *Table) Row(i int) *Row {
+	rows := t.rows()
+	if i < 0 || i >= len(rows) {
+		return nil
+	}
+	return &Row{root: t.root, ct: rows[i]}
+}
+
+// Cell returns the cell covering row r and grid column c of the table, or nil if there is none.
+//
+// The lookup is aware of merged cells: every position of a merged region returns the top left
+// cell of the region, which holds its content.
+func (t *Table) Cell(r, c int) *Cell {
+	grid := t.layout()
+	if r < 0 || r >= len(grid) || c < 0 || c >= len(grid[r]) || grid[r][c].origin == nil {
+		return nil
+	}
+	return &Cell{root: t.root, ct: grid[r][c].origin}
+}
+
+// CellCount returns the number of cells in the row.
+func (r *Row) CellCount() int {
+	var n int
+	for _, content := range r.ct.Contents {
+		if content.Cell != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Cell returns the cell at index i of the row, or nil if the index is out of range.
+func (r *Row) Cell(i int) *Cell {
+	var n int
+	for _, content := range r.ct.Contents {
+		if content.Cell == nil {
+			continue
+		}
+		if n == i {
+			return &Cell{root: r.root, ct: content.Cell}
+		}
+		n++
+	}
+	return nil
+}
+
+// rowContentIndex returns the index in the row contents of the row at index i, or -1.
+func (t *Table) rowContentIndex(i int) int {
+	var n int
+	for index, content := range t.ct.RowContents {
+		if content.Row == nil {
+			continue
+		}
+		if n == i {
+			return index
+		}
+		n++
+	}
+	return -1
+}
+
+// insertRows inserts rows into the table before the row at index at.
+func (t *Table) insertRows(at int, rows ...*ctypes.Row) {
+	index := t.rowContentIndex(at)
+	if index < 0 {
+		index = len(t.ct.RowContents)
+	}
+
+	contents := make([]ctypes.RowContent, 0, len(t.ct.RowContents)+len(rows))
+	contents = append(contents, t.ct.RowContents[:index]...)
+	for _, row := range rows {
+		contents = append(contents, ctypes.RowContent{Row: row})
+	}
+	contents = append(contents, t.ct.RowContents[index:]...)
+	t.ct.RowContents = contents
+}
+
+// InsertRow inserts an empty row at index at, which may equal the number of rows to append
+// one, and returns it.
+//
+// The row takes the cell structure and formatting of the row before it, or of the first row
+// when inserted at the top. Cells inserted inside a vertically merged region continue it.
+func (t *Table) InsertRow(at int) (*Row, error) {
+	rows := t.rows()
+	if at < 0 || at > len(rows) {
+		return nil, fmt.Errorf("row index %d out of range", at)
+	}
+
+	if len(rows) == 0 {
+		return t.AddRow(), nil
+	}
+
+	ref := at - 1
+	if ref < 0 {
+		ref = 0
+	}
+
+	row, err := t.cloneRow(rows[ref])
+	if err != nil {
+		return nil, err
+	}
+
+	grid := t.layout()
+	for _, slot := range rowSlots(row) {
+		clearCell(slot.cell)
+		slot.cell.Property.VMerge = nil
+
+		// A row inserted between two rows of a merged region extends the region
+		if at > 0 && at < len(rows) && slot.start < len(grid[at]) {
+			below := grid[at][slot.start]
+			if below.cell != nil && below.start == slot.start && isVMergeContinue(below.cell) {
+				slot.cell.Property.VMerge = ctypes.NewGenOptStrVal(stypes.MergeCellContinue)
+			}
+		}
+	}
+
+	t.insertRows(at, row)
+	return &Row{root: t.root, ct: row}, nil
+}
+
+// DuplicateRow inserts n copies of the row at index i directly after it and returns them.
+//
+// The copies keep the formatting and content of the row, which makes a styled sample row a
+// template for any number of rows.
+func (t *Table) DuplicateRow(i, n int) ([]*Row, error) {
+	rows := t.rows()
+	if i < 0 || i >= len(rows) {
+		return nil, fmt.Errorf("row index %d out of range", i)
+	}
+	if n < 0 {
+		return nil, errors.New("number of copies must not be negative")
+	}
+
+	// Merged regions continuing past the row are extended over the copies, copies of other
+	// merged cells stand on their own
+	continues := make(map[int]bool)
+	if i+1 < len(rows) {
+		for _, slot := range rowSlots(rows[i+1]) {
+			if isVMergeContinue(slot.cell) {
+				continues[slot.start] = true
+			}
+		}
+	}
+
+	copies := make([]*ctypes.Row, 0, n)
+	duplicated := make([]*Row, 0, n)
+	for k := 0; k < n; k++ {
+		row, err := t.cloneRow(rows[i])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, slot := range rowSlots(row) {
+			if slot.cell.Property == nil || slot.cell.Property.VMerge == nil {
+				continue
+			}
+			if continues[slot.start] {
+				clearCell(slot.cell)
+				slot.cell.Property.VMerge = ctypes.NewGenOptStrVal(stypes.MergeCellContinue)
+			} else {
+				slot.cell.Property.VMerge = nil
+			}
+		}
+
+		copies = append(copies, row)
+		duplicated = append(duplicated, &Row{root: t.root, ct: row})
+	}
+
+	t.insertRows(i+1, copies...)
+	return duplicated, nil
+}
+
+// DeleteRow removes the row at index i from the table.
+//
+// When the row starts a vertically merged region that continues below it, the next row
+// takes over the start of the region and its content.
+func (t *Table) DeleteRow(i int) error {
+	rows := t.rows()
+	if i < 0 || i >= len(rows) {
+		return fmt.Errorf("row index %d out of range", i)
+	}
+
+	if i+1 < len(rows) {
+		next := rowSlots(rows[i+1])
+		for _, slot := range rowSlots(rows[i]) {
+			if !isVMergeRestart(slot.cell) {
+				continue
+			}
+			for _, below := range next {
+				if below.start == slot.start && isVMergeContinue(below.cell) {
+					below.cell.Property.VMerge = ctypes.NewGenOptStrVal(stypes.MergeCellRestart)
+					below.cell.Contents = slot.cell.Contents
+				}
+			}
+		}
+	}
+
+	index := t.rowContentIndex(i)
+	t.ct.RowContents = append(t.ct.RowContents[:index], t.ct.RowContents[index+1:]...)
+	return nil
+}
+
+// InsertColumn inserts an empty grid column at index at, which may equal the number of
+// columns to append one.
+//
+// The column takes the width of its neighbour, which is added to the table width when the
+// table has a fixed width. In each row a cell spanning the position is widened, otherwise a
+// cell formatted like its neighbour is inserted. A table without rows or grid columns gets its
+// first grid column, without a width.
+func (t *Table) InsertColumn(at int) error {
+	count := t.columnCount()
+	if at < 0 || at > count {
+		return fmt.Errorf("column index %d out of range", at)
+	}
+
+	var width uint64
+	if cols := t.ct.Grid.Col; len(cols) > 0 {
+		ref := at - 1
+		if ref < 0 {
+			ref = 0
+		}
+		if ref >= len(cols) {
+			ref = len(cols) - 1
+		}
+		if cols[ref].Width != nil {
+			width = *cols[ref].Width
+		}
+	}
+
+	for _, row := range t.rows() {
+		if err := t.insertColumnInRow(row, at, width); err != nil {
+			return err
+		}
+	}
+
+	if count == 0 {
+		t.ct.Grid.Col = append(t.ct.Grid.Col, ctypes.Column{})
+	} else if len(t.ct.Grid.Col) > 0 && at <= len(t.ct.Grid.Col) {
+		w := width
+		cols := make([]ctypes.Column, 0, len(t.ct.Grid.Col)+1)
+		cols = append(cols, t.ct.Grid.Col[:at]...)
+		cols = append(cols, ctypes.Column{Width: &w})
+		cols = append(cols, t.ct.Grid.Col[at:]...)
+		t.ct.Grid.Col = cols
+	}
+
+	adjustWidth(t.ct.TableProp.Width, int(width))
+	return nil
+}
+
+func (t *Table) insertColumnInRow(row *ctypes.Row, at int, width uint64) error {
+	before := gridBefore(row)
+	if at < before {
+		// A row skipping columns always has properties holding the count
+		row.Property.GridBefore.Val++
+		return nil
+	}
+
+	slots := rowSlots(row)
+	var ref *ctypes.Cell
+	index := len(row.Contents)
+	for _, slot := range slots {
+		span := cellSpan(slot.cell)
+		if slot.start < at && at < slot.start+span {
+			slot.cell.Property.GridSpan.Val++
+			adjustWidth(slot.cell.Property.Width, int(width))
+			return nil
+		}
+		if slot.start == at {
+			ref, index = slot.cell, slot.index
+			break
+		}
+	}
+
+	if ref == nil {
+		end := before
+		if n := len(slots); n > 0 {
+			last := slots[n-1]
+			ref, end = last.cell, last.start+cellSpan(last.cell)
+		}
+		if at > end {
+			// Positions past the last cell are skipped columns, or missing from a short row
+			if row.Property != nil && row.Property.GridAfter != nil {
+				row.Property.GridAfter.Val++
+			}
+			return nil
+		}
+	}
+
+	cell := ctypes.DefaultCell()
+	if ref != nil {
+		cloned, err := t.cloneCell(ref)
+		if err != nil {
+			return err
+		}
+		cell = cloned
+		clearCell(cell)
+		cell.Property.GridSpan = nil
+		cell.Property.HMerge = nil
+		cell.Property.VMerge = nil
+	} else {
+		cell.Contents = []ctypes.TCBlockContent{{Paragraph: &ctypes.Paragraph{}}}
+	}
+
+	if width > 0 {
+		cell.Property.Width = ctypes.NewTableWidth(int(width), stypes.TableWidthDxa)
+	}
+
+	contents := make([]ctypes.TRCellContent, 0, len(row.Contents)+1)
+	contents = append(contents, row.Contents[:index]...)
+	contents = append(contents, ctypes.TRCellContent{Cell: cell})
+	contents = append(contents, row.Contents[index:]...)
+	row.Contents = contents
+
+	return nil
+}
+
+// DeleteColumn removes the grid column at index at from the table.
+//
+// Cells spanning the column are narrowed and cells only covering it are removed, together
+// with rows left without cells. The width of the column is taken off the table width when the
+// table has a fixed width.
+func (t *Table) DeleteColumn(at int) error {
+	count := t.columnCount()
+	if at < 0 || at >= count {
+		return fmt.Errorf("column index %d out of range", at)
+	}
+	if count == 1 {
+		return errors.New("cannot delete the only column of a table")
+	}
+
+	var width uint64
+	if at < len(t.ct.Grid.Col) {
+		if w := t.ct.Grid.Col[at].Width; w != nil {
+			width = *w
+		}
+		t.ct.Grid.Col = append(t.ct.Grid.Col[:at], t.ct.Grid.Col[at+1:]...)
+	}
+
+	contents := t.ct.RowContents[:0]
+	for _, content := range t.ct.RowContents {
+		if row := content.Row; row != nil {
+			deleteColumnInRow(row, at, width)
+			if len(row.Contents) == 0 {
+				continue
+			}
+		}
+		contents = append(contents, content)
+	}
+	t.ct.RowContents = contents
+
+	adjustWidth(t.ct.TableProp.Width, -int(width))
+	return nil
+}
+
+func deleteColumnInRow(row *ctypes.Row, at int, width uint64) {
+	if at < gridBefore(row) {
+		decrementGrid(&row.Property.GridBefore, 0)
+		return
+	}
+
+	for _, slot := range rowSlots(row) {
+		span := cellSpan(slot.cell)
+		if at < slot.start || at >= slot.start+span {
+			continue
+		}
+
+		if span > 1 {
+			decrementGrid(&slot.cell.Property.GridSpan, 1)
+			adjustWidth(slot.cell.Property.Width, -int(width))
+		} else {
+			row.Contents = append(row.Contents[:slot.index], row.Contents[slot.index+1:]...)
+		}
+		return
+	}
+
+	if gridAfter(row) > 0 {
+		decrementGrid(&row.Property.GridAfter, 0)
+	}
+}
+
+// rowCell is a cell of a row with its position.
+type rowCell struct {
+	cell  *ctypes.Cell
+	index int // index in the row contents
+	start int // first grid column spanned by the cell
+}
+
+// rowSlots returns the cells of a row with their positions.
+func rowSlots(row *ctypes.Row) []rowCell {
+	var cells []rowCell
+	pos := gridBefore(row)
+	for index, content := range row.Contents {
+		if content.Cell == nil {
+			continue
+		}
+		cells = append(cells, rowCell{cell: content.Cell, index: index, start: pos})
+		pos += cellSpan(content.Cell)
+	}
+	return cells
+}
+
+// clearCell removes the content of a cell, keeping the formatting of its first paragraph.
+func clearCell(cell *ctypes.Cell) {
+	if cell.Property == nil {
+		cell.Property = &ctypes.CellProperty{}
+	}
+
+	p := &ctypes.Paragraph{}
+	if len(cell.Contents) > 0 && cell.Contents[0].Paragraph != nil {
+		p.Property = cell.Contents[0].Paragraph.Property
+	}
+	cell.Contents = []ctypes.TCBlockContent{{Paragraph: p}}
+}
+
+// adjustWidth adds delta to a width given in twentieths of a point.
+func adjustWidth(width *ctypes.TableWidth, delta int) {
+	if width == nil || width.Width == nil || width.WidthType == nil || *width.WidthType != stypes.TableWidthDxa {
+		return
+	}
+	if w := *width.Width + delta; w > 0 {
+		*width.Width = w
+	}
+}
+
+// decrementGrid decrements a grid column count, removing it when it drops to min or less.
+func decrementGrid(num **ctypes.DecimalNum, min int) {
+	if *num == nil {
+		return
+	}
+	(*num).Val--
+	if (*num).Val <= min {
+		*num = nil
+	}
+}
+
+// cloneRow returns a deep copy of a row.
+//
+// The row is copied by encoding it within a document, so that its content such as drawings
+// decodes as it does when loading a document.
+func (t *Table) cloneRow(row *ctypes.Row) (*ctypes.Row, error) {
+	tbl := &Table{root: t.root}
+	tbl.ct.RowContents = []ctypes.RowContent{{Row: row}}
+	src := &Document{Body: &Body{Children: []DocumentChild{{Table: tbl}}}}
+
+	content, err := xml.Marshal(src)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := LoadDocXml(t.root, "", content)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.Body == nil || len(doc.Body.Children) != 1 || doc.Body.Children[0].Table == nil {
+		return nil, errors.New("copying table row")
+	}
+	rows := doc.Body.Children[0].Table.rows()
+	if len(rows) != 1 {
+		return nil, errors.New("copying table row")
+	}
+
+	cloned := rows[0]
+	if cloned.Property == nil {
+		cloned.Property = &ctypes.RowProperty{}
+	}
+	return cloned, nil
+}
+
+// cloneCell returns a deep copy of a cell.
+func (t *Table) cloneCell(cell *ctypes.Cell) (*ctypes.Cell, error) {
+	row, err := t.cloneRow(&ctypes.Row{Contents: []ctypes.TRCellContent{{Cell: cell}}})
+	if err != nil {
+		return nil, err
+	}
+	if len(row.Contents) != 1 || row.Contents[0].Cell == nil {
+		return nil, errors.New("copying table cell")
+	}
+
+	cloned := row.Contents[0].Cell
+	if cloned.Property == nil {
+		cloned.Property = &ctypes.CellProperty{}
+	}
+	return cloned, nil
+}
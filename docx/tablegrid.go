@@ -0,0 +1,213 @@
+package docx
+
+import (
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// gridSlot is one position of the logical table grid.
+type gridSlot struct {
+	// cell is the cell of the row occupying the position, nil for positions skipped with
+	// gridBefore or gridAfter
+	cell *ctypes.Cell
+
+	// start is the first grid column spanned by cell
+	start int
+
+	// origin is the top left cell of the merged region the position belongs to, which holds
+	// its content, and originRow and originCol its position
+	origin               *ctypes.Cell
+	originRow, originCol int
+}
+
+// rows returns the rows of the table.
+func (t *Table) rows() []*ctypes.Row {
+	var rows []*ctypes.Row
+	for _, content := range t.ct.RowContents {
+		if content.Row != nil {
+			rows = append(rows, content.Row)
+		}
+	}
+	return rows
+}
+
+// layout lays the cells of the table out on the logical grid, resolving gridSpan, hMerge and
+// vMerge. Rows may have different lengths.
+func (t *Table) layout() [][]gridSlot {
+	rows := t.rows()
+	grid := make([][]gridSlot, len(rows))
+
+	for r, row := range rows {
+		var slots []gridSlot
+		for i := 0; i < gridBefore(row); i++ {
+			slots = append(slots, gridSlot{})
+		}
+
+		for _, content := range row.Contents {
+			cell := content.Cell
+			if cell == nil {
+				continue
+			}
+
+			start := len(slots)
+			slot := gridSlot{cell: cell, start: start, origin: cell, originRow: r, originCol: start}
+
+			switch {
+			case isVMergeContinue(cell) && r > 0 && start < len(grid[r-1]) && grid[r-1][start].origin != nil:
+				above := grid[r-1][start]
+				slot.origin, slot.originRow, slot.originCol = above.origin, above.originRow, above.originCol
+			case isHMergeContinue(cell) && start > 0 && slots[start-1].origin != nil:
+				left := slots[start-1]
+				slot.origin, slot.originRow, slot.originCol = left.origin, left.originRow, left.originCol
+			}
+
+			for i := 0; i < cellSpan(cell); i++ {
+				slots = append(slots, slot)
+			}
+		}
+
+		grid[r] = slots
+	}
+
+	return grid
+}
+
+// columnCount returns the number of grid columns of the table.
+func (t *Table) columnCount() int {
+	count := len(t.ct.Grid.Col)
+	for _, row := range t.rows() {
+		width := gridBefore(row) + gridAfter(row)
+		for _, content := range row.Contents {
+			if content.Cell != nil {
+				width += cellSpan(content.Cell)
+			}
+		}
+		if width > count {
+			count = width
+		}
+	}
+	return count
+}
+
+// cellSpan returns the number of grid columns spanned by a cell.
+func cellSpan(cell *ctypes.Cell) int {
+	if cell.Property != nil && cell.Property.GridSpan != nil && cell.Property.GridSpan.Val > 1 {
+		return cell.Property.GridSpan.Val
+	}
+	return 1
+}
+
+func isVMergeContinue(cell *ctypes.Cell) bool {
+	if cell.Property == nil || cell.Property.VMerge == nil {
+		return false
+	}
+	val := cell.Property.VMerge.Val
+	return val == nil || *val == stypes.MergeCellContinue
+}
+
+func isVMergeRestart(cell *ctypes.Cell) bool {
+	if cell.Property == nil || cell.Property.VMerge == nil {
+		return false
+	}
+	val := cell.Property.VMerge.Val
+	return val != nil && *val == stypes.MergeCellRestart
+}
+
+func isHMergeContinue(cell *ctypes.Cell) bool {
+	if cell.Property == nil || cell.Property.HMerge == nil {
+		return false
+	}
+	val := cell.Property.HMerge.Val
+	return val == nil || *val == stypes.MergeCellContinue
+}
+
+// gridBefore returns the number of grid columns skipped before the first cell of a row.
+func gridBefore(row *ctypes.Row) int {
+	if row.Property != nil && row.Property.GridBefore != nil {
+		return row.Property.GridBefore.Val
+	}
+	return 0
+}
+
+// gridAfter returns the number of grid columns skipped after the last cell of a row.
+func gridAfter(row *ctypes.Row) int {
+	if row.Property != nil && row.Property.GridAfter != nil {
+		return row.Property.GridAfter.Val
+	}
+	return 0
+}
+
+// GridCell is one position of the logical grid of a table.
+type GridCell struct {
+	// Cell is the cell holding the content of the position, which is the top left cell of
+	// its merged region. It is nil for positions without a cell.
+	Cell *Cell
+
+	// Row and Col are the position of the top left corner of the merged region
+	Row, Col int
+
+	// RowSpan and ColSpan are the number of rows and grid columns of the merged region
+	RowSpan, ColSpan int
+}
+
+// Merged reports whether the position belongs to a region of more than one grid position.
+func (g GridCell) Merged() bool {
+	return g.RowSpan > 1 || g.ColSpan > 1
+}
+
+// LogicalGrid returns the rectangular logical grid of the table, with one entry per row and
+// grid column. Every position of a merged region, whether merged with gridSpan, vMerge or
+// hMerge, refers to the region's top left cell, so that merged layouts can be read without
+// interpreting the merge markup.
+func (t *Table) LogicalGrid() [][]GridCell {
+	layout := t.layout()
+	cols := t.columnCount()
+
+	// The extent of each merged region
+	type extent struct{ lastRow, lastCol int }
+	extents := make(map[*ctypes.Cell]*extent)
+	for r, slots := range layout {
+		for c, slot := range slots {
+			if slot.origin == nil {
+				continue
+			}
+			ext, ok := extents[slot.origin]
+			if !ok {
+				ext = &extent{lastRow: r, lastCol: c}
+				extents[slot.origin] = ext
+			}
+			if r > ext.lastRow {
+				ext.lastRow = r
+			}
+			if c > ext.lastCol {
+				ext.lastCol = c
+			}
+		}
+	}
+
+	wrappers := make(map[*ctypes.Cell]*Cell)
+	grid := make([][]GridCell, len(layout))
+	for r, slots := range layout {
+		grid[r] = make([]GridCell, cols)
+		for c, slot := range slots {
+			if slot.origin == nil || c >= cols {
+				continue
+			}
+			cell, ok := wrappers[slot.origin]
+			if !ok {
+				cell = &Cell{root: t.root, ct: slot.origin}
+				wrappers[slot.origin] = cell
+			}
+			ext := extents[slot.origin]
+			grid[r][c] = GridCell{
+				Cell:    cell,
+				Row:     slot.originRow,
+				Col:     slot.originCol,
+				RowSpan: ext.lastRow - slot.originRow + 1,
+				ColSpan: ext.lastCol - slot.originCol + 1,
+			}
+		}
+	}
+
+	return grid
+}
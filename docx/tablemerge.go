@@ -0,0 +1,207 @@
+package docx
+
+import (
+	"fmt"
+
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// MergeCells merges the grid positions from row r1 and column c1 to row r2 and column c2,
+// both inclusive, into a single cell.
+//
+// Each row of the range keeps one cell spanning the columns with gridSpan. The cell of the
+// first row starts a vertically merged region with vMerge and the cells of the other rows
+// continue it. The content of the merged cells is gathered in the top left cell. Merged
+// regions crossing the border of the range are reported as an error.
+func (t *Table) MergeCells(r1, c1, r2, c2 int) error {
+	if r1 > r2 {
+		r1, r2 = r2, r1
+	}
+	if c1 > c2 {
+		c1, c2 = c2, c1
+	}
+
+	layout := t.layout()
+	grid := t.LogicalGrid()
+	if r1 < 0 || c1 < 0 || r2 >= len(grid) || c2 >= t.columnCount() {
+		return fmt.Errorf("cell range (%d, %d) to (%d, %d) out of range", r1, c1, r2, c2)
+	}
+
+	for r := r1; r <= r2; r++ {
+		for c := c1; c <= c2; c++ {
+			pos := grid[r][c]
+			if pos.Cell == nil || c >= len(layout[r]) {
+				return fmt.Errorf("no cell at (%d, %d)", r, c)
+			}
+			if pos.Row < r1 || pos.Col < c1 || pos.Row+pos.RowSpan-1 > r2 || pos.Col+pos.ColSpan-1 > c2 {
+				return fmt.Errorf("merged cell at (%d, %d) crosses the border of the range", r, c)
+			}
+			if slot := layout[r][c]; slot.start < c1 || slot.start+cellSpan(slot.cell)-1 > c2 {
+				return fmt.Errorf("cell at (%d, %d) crosses the border of the range", r, c)
+			}
+		}
+	}
+
+	// The content of the merged cells is gathered in reading order
+	var contents []ctypes.TCBlockContent
+	seen := make(map[*ctypes.Cell]bool)
+	for r := r1; r <= r2; r++ {
+		for c := c1; c <= c2; c++ {
+			origin := layout[r][c].origin
+			if seen[origin] {
+				continue
+			}
+			seen[origin] = true
+			for _, block := range origin.Contents {
+				if block.Paragraph != nil && len(block.Paragraph.Children) == 0 {
+					continue
+				}
+				contents = append(contents, block)
+			}
+		}
+	}
+
+	width := t.gridWidth(c1, c2)
+	rows := t.rows()
+	for r := r1; r <= r2; r++ {
+		row := rows[r]
+
+		var merged *ctypes.Cell
+		removed := make(map[int]bool)
+		for _, slot := range rowSlots(row) {
+			if slot.start < c1 || slot.start > c2 {
+				continue
+			}
+			if slot.start == c1 {
+				merged = slot.cell
+			} else {
+				removed[slot.index] = true
+			}
+		}
+
+		cells := row.Contents[:0]
+		for index, content := range row.Contents {
+			if !removed[index] {
+				cells = append(cells, content)
+			}
+		}
+		row.Contents = cells
+
+		cell := &Cell{root: t.root, ct: merged}
+		cell.ColSpan(c2 - c1 + 1)
+		merged.Property.HMerge = nil
+		if width > 0 {
+			merged.Property.Width = ctypes.NewTableWidth(width, stypes.TableWidthDxa)
+		}
+
+		switch {
+		case r > r1:
+			clearCell(merged)
+			cell.RowSpanContinue()
+		case r2 > r1:
+			cell.RowSpan()
+		default:
+			merged.Property.VMerge = nil
+		}
+
+		if r == r1 && len(contents) > 0 {
+			merged.Contents = contents
+		}
+	}
+
+	return nil
+}
+
+// SplitCell splits the merged cell covering row r and grid column c back into one cell per
+// row and grid column. The content stays in the top left cell and the other cells take its
+// formatting. A cell that is not merged is left unchanged.
+func (t *Table) SplitCell(r, c int) error {
+	grid := t.LogicalGrid()
+	if r < 0 || r >= len(grid) || c < 0 || c >= len(grid[r]) || grid[r][c].Cell == nil {
+		return fmt.Errorf("no cell at (%d, %d)", r, c)
+	}
+
+	pos := grid[r][c]
+	if !pos.Merged() {
+		return nil
+	}
+
+	rows := t.rows()
+	for rr := pos.Row; rr < pos.Row+pos.RowSpan; rr++ {
+		row := rows[rr]
+
+		cells := make([]ctypes.TRCellContent, 0, len(row.Contents)+pos.ColSpan)
+		slots := rowSlots(row)
+		next := 0
+		for index, content := range row.Contents {
+			if next < len(slots) && slots[next].index == index {
+				slot := slots[next]
+				next++
+
+				if slot.start >= pos.Col && slot.start < pos.Col+pos.ColSpan {
+					split, err := t.splitCell(slot.cell, slot.start)
+					if err != nil {
+						return err
+					}
+					for _, cell := range split {
+						cells = append(cells, ctypes.TRCellContent{Cell: cell})
+					}
+					continue
+				}
+			}
+			cells = append(cells, content)
+		}
+		row.Contents = cells
+	}
+
+	return nil
+}
+
+// splitCell returns a cell starting at grid column start as cells spanning one grid column.
+func (t *Table) splitCell(cell *ctypes.Cell, start int) ([]*ctypes.Cell, error) {
+	span := cellSpan(cell)
+
+	if cell.Property == nil {
+		cell.Property = &ctypes.CellProperty{}
+	}
+	cell.Property.GridSpan = nil
+	cell.Property.VMerge = nil
+	cell.Property.HMerge = nil
+	if width := t.gridWidth(start, start); width > 0 {
+		cell.Property.Width = ctypes.NewTableWidth(width, stypes.TableWidthDxa)
+	}
+
+	cells := []*ctypes.Cell{cell}
+	for i := 1; i < span; i++ {
+		added, err := t.cloneCell(cell)
+		if err != nil {
+			return nil, err
+		}
+		clearCell(added)
+		if width := t.gridWidth(start+i, start+i); width > 0 {
+			added.Property.Width = ctypes.NewTableWidth(width, stypes.TableWidthDxa)
+		}
+		cells = append(cells, added)
+	}
+
+	return cells, nil
+}
+
+// gridWidth returns the total width of the grid columns from c1 to c2, or 0 if the table grid
+// does not define it.
+func (t *Table) gridWidth(c1, c2 int) int {
+	cols := t.ct.Grid.Col
+	if c2 >= len(cols) {
+		return 0
+	}
+
+	var width int
+	for _, col := range cols[c1 : c2+1] {
+		if col.Width == nil {
+			return 0
+		}
+		width += int(*col.Width)
+	}
+	return width
+}
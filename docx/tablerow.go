@@ -0,0 +1,102 @@
+package docx
+
+import (
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+func (r *Row) ensureProp() {
+	if r.ct.Property == nil {
+		r.ct.Property = &ctypes.RowProperty{}
+	}
+}
+
+// onOffElem returns the on/off property for value, nil when value is false so that the
+// element is left out.
+func onOffElem(value bool) *ctypes.OnOff {
+	if !value {
+		return nil
+	}
+	return &ctypes.OnOff{}
+}
+
+// RepeatAsHeader sets whether the row is repeated at the top of every page the table spans.
+// Word repeats the header rows only when they are the first rows of the table.
+func (r *Row) RepeatAsHeader(value bool) *Row {
+	r.ensureProp()
+	r.ct.Property.Header = onOffElem(value)
+	return r
+}
+
+// GetRepeatAsHeader reports whether the row is repeated at the top of every page.
+func (r *Row) GetRepeatAsHeader() bool {
+	return r.ct.Property != nil && r.ct.Property.Header.Bool()
+}
+
+// Height sets the height of the row in twentieths of a point. The rule makes the height
+// exact or a minimum, or lets the content decide with stypes.HeightRuleAuto.
+func (r *Row) Height(value int, rule stypes.HeightRule) *Row {
+	r.ensureProp()
+	r.ct.Property.Height = ctypes.NewTableRowHeight(value, rule)
+	return r
+}
+
+// GetHeight returns the height of the row in twentieths of a point and its rule. A row
+// without a height returns 0 and stypes.HeightRuleAuto. A height without a rule is a minimum
+// height, as Word reads it.
+func (r *Row) GetHeight() (int, stypes.HeightRule) {
+	if r.ct.Property == nil || r.ct.Property.Height == nil {
+		return 0, stypes.HeightRuleAuto
+	}
+
+	height := r.ct.Property.Height
+	value, rule := 0, stypes.HeightRuleAtLeast
+	if height.Val != nil {
+		value = *height.Val
+	}
+	if height.HRule != nil {
+		rule = *height.HRule
+	}
+	return value, rule
+}
+
+// CantSplit sets whether the row is kept on a single page instead of breaking across pages.
+func (r *Row) CantSplit(value bool) *Row {
+	r.ensureProp()
+	r.ct.Property.CantSplit = onOffElem(value)
+	return r
+}
+
+// GetCantSplit reports whether the row is kept on a single page.
+func (r *Row) GetCantSplit() bool {
+	return r.ct.Property != nil && r.ct.Property.CantSplit.Bool()
+}
+
+// Justification sets the alignment of the row between the page margins.
+func (r *Row) Justification(value stypes.Justification) *Row {
+	r.ensureProp()
+	r.ct.Property.JC = ctypes.NewGenSingleStrVal(value)
+	return r
+}
+
+// GetJustification returns the alignment of the row, or an empty value if the row follows
+// the alignment of the table.
+func (r *Row) GetJustification() stypes.Justification {
+	if r.ct.Property == nil || r.ct.Property.JC == nil {
+		return ""
+	}
+	return r.ct.Property.JC.Val
+}
+
+// Hidden sets the hidden marker of the row. Word hides the row along with hidden text, when
+// the content of its cells is hidden too.
+func (r *Row) Hidden(value bool) *Row {
+	r.ensureProp()
+	r.ct.Property.Hidden = onOffElem(value)
+	return r
+}
+
+// GetHidden reports whether the row carries the hidden marker.
+func (r *Row) GetHidden() bool {
+	return r.ct.Property != nil && r.ct.Property.Hidden.Bool()
+}
@@ -0,0 +1,332 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// Table look bits of the val attribute of the tblLook element
+const (
+	tableLookFirstRow    = 0x0020
+	tableLookLastRow     = 0x0040
+	tableLookFirstColumn = 0x0080
+	tableLookLastColumn  = 0x0100
+	tableLookNoHBand     = 0x0200
+	tableLookNoVBand     = 0x0400
+)
+
+// TableLook selects the conditional formats of the table style that apply to a table, as the
+// Table Style Options of Word do.
+type TableLook struct {
+	FirstRow      bool
+	LastRow       bool
+	FirstColumn   bool
+	LastColumn    bool
+	BandedRows    bool
+	BandedColumns bool
+}
+
+// Look sets the conditional formats of the table style that apply to the table.
+func (t *Table) Look(look TableLook) *Table {
+	bits := 0
+	flag := func(value bool, bit int) *stypes.OnOff {
+		v := stypes.OnOffZero
+		if value {
+			bits |= bit
+			v = stypes.OnOffOne
+		}
+		return &v
+	}
+
+	ct := &ctypes.TableLook{
+		FirstRow:    flag(look.FirstRow, tableLookFirstRow),
+		LastRow:     flag(look.LastRow, tableLookLastRow),
+		FirstColumn: flag(look.FirstColumn, tableLookFirstColumn),
+		LastColumn:  flag(look.LastColumn, tableLookLastColumn),
+		NoHBand:     flag(!look.BandedRows, tableLookNoHBand),
+		NoVBand:     flag(!look.BandedColumns, tableLookNoVBand),
+	}
+	ct.Val = fmt.Sprintf("%04X", bits)
+
+	t.ct.TableProp.TableLook = ct
+	return t
+}
+
+// GetLook returns the conditional formats of the table style that apply to the table. The
+// attributes of the tblLook element take precedence over its bit mask. A table without a
+// tblLook uses the first row, first column and row banding formats, as Word does.
+func (t *Table) GetLook() TableLook {
+	ct := t.ct.TableProp.TableLook
+	if ct == nil {
+		return TableLook{FirstRow: true, FirstColumn: true, BandedRows: true}
+	}
+
+	var bits int64
+	fmt.Sscanf(ct.Val, "%x", &bits)
+
+	flag := func(value *stypes.OnOff, bit int64) bool {
+		if value != nil {
+			return (&ctypes.OnOff{Val: value}).Bool()
+		}
+		return bits&bit != 0
+	}
+
+	return TableLook{
+		FirstRow:      flag(ct.FirstRow, tableLookFirstRow),
+		LastRow:       flag(ct.LastRow, tableLookLastRow),
+		FirstColumn:   flag(ct.FirstColumn, tableLookFirstColumn),
+		LastColumn:    flag(ct.LastColumn, tableLookLastColumn),
+		BandedRows:    !flag(ct.NoHBand, tableLookNoHBand),
+		BandedColumns: !flag(ct.NoVBand, tableLookNoVBand),
+	}
+}
+
+// GetStyle returns the ID of the table style, or an empty string if the table has none.
+func (t *Table) GetStyle() string {
+	if t.ct.TableProp.Style == nil {
+		return ""
+	}
+	return t.ct.TableProp.Style.Val
+}
+
+// TableStyle builds a table style definition. Add it to a document with AddTableStyle and
+// apply it to tables with Table.Style.
+//
+// Example usage:
+//
+//	style := docx.NewTableStyle("Brand", "Brand Table").
+//		Borders(border, border, border, border, border, nil).
+//		CellMargins(0, 108, 0, 108)
+//	style.Conditional(stypes.TblStyleOverrideFirstRow).Bold(true).Color("FFFFFF").Shading("1F3864")
+//	style.Conditional(stypes.TblStyleOverrideBand1Horz).Shading("D9E2F3")
+//	err := document.AddTableStyle(style)
+//
+//	table.Style("Brand")
+type TableStyle struct {
+	ct ctypes.Style
+
+	// The whole table formatting, folded into the style properties when the style is built
+	whole *TableStyleRegion
+
+	regions []*TableStyleRegion
+}
+
+// NewTableStyle returns a table style builder with the given style ID and display name.
+func NewTableStyle(id, name string) *TableStyle {
+	styleType := stypes.StyleTypeTable
+	custom := stypes.OnOffOne
+	return &TableStyle{
+		ct: ctypes.Style{
+			Type:        &styleType,
+			ID:          &id,
+			Name:        ctypes.NewCTString(name),
+			CustomStyle: &custom,
+			TableProp:   &ctypes.TableProp{},
+		},
+		whole: &TableStyleRegion{ct: ctypes.TableStyleProp{Type: stypes.TblStyleOverrideWholeTable}},
+	}
+}
+
+// ID returns the style ID.
+func (s *TableStyle) ID() string {
+	return *s.ct.ID
+}
+
+// BasedOn sets the table style the style inherits from, such as "TableNormal".
+func (s *TableStyle) BasedOn(styleID string) *TableStyle {
+	s.ct.BasedOn = ctypes.NewCTString(styleID)
+	return s
+}
+
+// Borders sets the borders of the whole table. The inside borders are drawn between the
+// rows and columns. A nil border is left unset.
+func (s *TableStyle) Borders(top, left, bottom, right, insideH, insideV *ctypes.Border) *TableStyle {
+	s.ct.TableProp.Borders = &ctypes.TableBorders{
+		Top:     top,
+		Left:    left,
+		Bottom:  bottom,
+		Right:   right,
+		InsideH: insideH,
+		InsideV: insideV,
+	}
+	return s
+}
+
+// Shading sets the fill color of every cell of the table, such as "F2F2F2".
+func (s *TableStyle) Shading(fill string) *TableStyle {
+	s.whole.Shading(fill)
+	return s
+}
+
+// CellMargins sets the default cell margins of the table, in twentieths of a point.
+func (s *TableStyle) CellMargins(top, left, bottom, right int) *TableStyle {
+	margins := ctypes.DefaultCellMargins().Margin(top, left, bottom, right)
+	s.ct.TableProp.CellMargin = &margins
+	return s
+}
+
+// BandSize sets the number of rows of each row band and the number of columns of each
+// column band.
+func (s *TableStyle) BandSize(rows, cols int) *TableStyle {
+	s.ct.TableProp.RowCountInRowBand = ctypes.NewDecimalNum(rows)
+	s.ct.TableProp.RowCountInColBand = ctypes.NewDecimalNum(cols)
+	return s
+}
+
+// WholeTable returns the formatting of every cell of the table, which the conditional
+// formats override.
+func (s *TableStyle) WholeTable() *TableStyleRegion {
+	return s.whole
+}
+
+// Conditional returns the formatting of a region of the table, such as the first row, the
+// odd row bands or the top left cell, creating it if needed. The region applies to tables
+// whose look enables it, see Table.Look. Corner cells apply whatever the look.
+func (s *TableStyle) Conditional(region stypes.TblStyleOverrideType) *TableStyleRegion {
+	if region == stypes.TblStyleOverrideWholeTable {
+		return s.whole
+	}
+	for _, r := range s.regions {
+		if r.ct.Type == region {
+			return r
+		}
+	}
+
+	r := &TableStyleRegion{ct: ctypes.TableStyleProp{Type: region}}
+	s.regions = append(s.regions, r)
+	return r
+}
+
+// GetCT returns the style definition built from the settings of the builder.
+func (s *TableStyle) GetCT() *ctypes.Style {
+	style := s.ct
+	style.ParaProp = s.whole.ct.ParaProp
+	style.RunProp = s.whole.ct.RunProp
+	style.TableCellProp = s.whole.ct.CellProp
+
+	style.TableStylePr = make([]ctypes.TableStyleProp, 0, len(s.regions))
+	for _, r := range s.regions {
+		style.TableStylePr = append(style.TableStylePr, r.ct)
+	}
+	return &style
+}
+
+// AddTableStyle adds the table style built by s to the document styles, replacing the
+// table style with the same ID if there is one. Later changes to s are not applied.
+func (rd *RootDoc) AddTableStyle(s *TableStyle) error {
+	if rd.DocStyles == nil {
+		return errors.New("document has no styles part")
+	}
+
+	style := *s.GetCT()
+	for i, existing := range rd.DocStyles.StyleList {
+		if existing.ID == nil || *existing.ID != *style.ID {
+			continue
+		}
+		if existing.Type == nil || *existing.Type != stypes.StyleTypeTable {
+			return fmt.Errorf("style ID %q is used by a style that is not a table style", *style.ID)
+		}
+		rd.DocStyles.StyleList[i] = style
+		return nil
+	}
+
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, style)
+	return nil
+}
+
+// TableStyleRegion is the formatting a table style applies to a region of the table.
+type TableStyleRegion struct {
+	ct ctypes.TableStyleProp
+}
+
+// GetCT returns a pointer to the underlying conditional formatting properties.
+func (r *TableStyleRegion) GetCT() *ctypes.TableStyleProp {
+	return &r.ct
+}
+
+func (r *TableStyleRegion) runProp() *ctypes.RunProperty {
+	if r.ct.RunProp == nil {
+		r.ct.RunProp = &ctypes.RunProperty{}
+	}
+	return r.ct.RunProp
+}
+
+func (r *TableStyleRegion) cellProp() *ctypes.CellProperty {
+	if r.ct.CellProp == nil {
+		r.ct.CellProp = &ctypes.CellProperty{}
+	}
+	return r.ct.CellProp
+}
+
+// Bold sets the bold formatting of the text of the region.
+func (r *TableStyleRegion) Bold(value bool) *TableStyleRegion {
+	r.runProp().Bold = ctypes.OnOffFromBool(value)
+	return r
+}
+
+// Italic sets the italic formatting of the text of the region.
+func (r *TableStyleRegion) Italic(value bool) *TableStyleRegion {
+	r.runProp().Italic = ctypes.OnOffFromBool(value)
+	return r
+}
+
+// Color sets the color of the text of the region, such as "FFFFFF".
+func (r *TableStyleRegion) Color(colorCode string) *TableStyleRegion {
+	r.runProp().Color = ctypes.NewColor(colorCode)
+	return r
+}
+
+// Size sets the font size of the text of the region, in points.
+func (r *TableStyleRegion) Size(size uint64) *TableStyleRegion {
+	r.runProp().Size = ctypes.NewFontSize(size * 2)
+	return r
+}
+
+// Font sets the font of the text of the region.
+func (r *TableStyleRegion) Font(font string) *TableStyleRegion {
+	prop := r.runProp()
+	if prop.Fonts == nil {
+		prop.Fonts = &ctypes.RunFonts{}
+	}
+	prop.Fonts.Ascii = font
+	prop.Fonts.HAnsi = font
+	return r
+}
+
+// Justification sets the alignment of the paragraphs of the region.
+func (r *TableStyleRegion) Justification(value stypes.Justification) *TableStyleRegion {
+	if r.ct.ParaProp == nil {
+		r.ct.ParaProp = &ctypes.ParagraphProp{}
+	}
+	r.ct.ParaProp.Justification = ctypes.NewGenSingleStrVal(value)
+	return r
+}
+
+// Shading sets the fill color of the cells of the region, such as "1F3864".
+func (r *TableStyleRegion) Shading(fill string) *TableStyleRegion {
+	r.cellProp().Shading = ctypes.NewShading().SetFill(fill)
+	return r
+}
+
+// VerticalAlign sets the vertical alignment of the cells of the region.
+func (r *TableStyleRegion) VerticalAlign(value stypes.VerticalJc) *TableStyleRegion {
+	r.cellProp().VAlign = ctypes.NewGenSingleStrVal(value)
+	return r
+}
+
+// Borders sets the cell borders of the region. The inside borders are drawn between the
+// cells of the region. A nil border is left unset.
+func (r *TableStyleRegion) Borders(top, left, bottom, right, insideH, insideV *ctypes.Border) *TableStyleRegion {
+	r.cellProp().Borders = &ctypes.CellBorders{
+		Top:     top,
+		Left:    left,
+		Bottom:  bottom,
+		Right:   right,
+		InsideH: insideH,
+		InsideV: insideV,
+	}
+	return r
+}
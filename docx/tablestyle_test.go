@@ -0,0 +1,99 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_Look(t *testing.T) {
+	tests := []struct {
+		name string
+		look TableLook
+		val  string
+	}{
+		{"None", TableLook{}, "0600"},
+		{"Word default", TableLook{FirstRow: true, FirstColumn: true, BandedRows: true}, "04A0"},
+		{"All", TableLook{true, true, true, true, true, true}, "01E0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rd := setupRootDoc(t)
+			tbl := rd.AddTable().Look(tt.look)
+			assert.Equal(t, tt.val, tbl.GetCT().TableProp.TableLook.Val)
+			assert.Equal(t, tt.look, tbl.GetLook())
+		})
+	}
+}
+
+func TestTable_GetLook(t *testing.T) {
+	rd := setupRootDoc(t)
+	tbl := rd.AddTable()
+	assert.Equal(t, TableLook{FirstRow: true, FirstColumn: true, BandedRows: true}, tbl.GetLook())
+
+	// The bit mask alone, as written by older versions of Word
+	tbl.GetCT().TableProp.TableLook = &ctypes.TableLook{Val: "0260"}
+	assert.Equal(t, TableLook{FirstRow: true, LastRow: true, BandedColumns: true}, tbl.GetLook())
+
+	// The attributes override the bit mask
+	off := stypes.OnOffFalse
+	tbl.GetCT().TableProp.TableLook.FirstRow = &off
+	assert.False(t, tbl.GetLook().FirstRow)
+}
+
+func TestAddTableStyle(t *testing.T) {
+	rd := setupRootDoc(t)
+	count := len(rd.DocStyles.StyleList)
+
+	border := ctypes.NewCellBorder(stypes.BorderStyleSingle, "1F3864", "0", 4)
+	style := NewTableStyle("Brand", "Brand Table").
+		BasedOn("TableNormal").
+		Borders(border, border, border, border, border, nil).
+		Shading("F2F2F2").
+		CellMargins(0, 108, 0, 108).
+		BandSize(1, 1)
+	style.WholeTable().Size(10)
+	style.Conditional(stypes.TblStyleOverrideFirstRow).Bold(true).Color("FFFFFF").Shading("1F3864")
+	style.Conditional(stypes.TblStyleOverrideBand1Horz).Shading("D9E2F3")
+	style.Conditional(stypes.TblStyleOverrideNwCell).Italic(true)
+	style.Conditional(stypes.TblStyleOverrideFirstRow).Justification(stypes.JustificationCenter)
+
+	require.NoError(t, rd.AddTableStyle(style))
+	require.Len(t, rd.DocStyles.StyleList, count+1)
+
+	ct := rd.GetStyleByID("Brand", stypes.StyleTypeTable)
+	require.NotNil(t, ct)
+	assert.Equal(t, "Brand Table", ct.Name.Val)
+	assert.Equal(t, "F2F2F2", *ct.TableCellProp.Shading.Fill)
+	assert.Equal(t, uint64(20), ct.RunProp.Size.Value)
+	assert.Equal(t, 108, *ct.TableProp.CellMargin.Left.Width)
+	require.Len(t, ct.TableStylePr, 3)
+	assert.Equal(t, stypes.TblStyleOverrideFirstRow, ct.TableStylePr[0].Type)
+	assert.Equal(t, "1F3864", *ct.TableStylePr[0].CellProp.Shading.Fill)
+	assert.Equal(t, stypes.JustificationCenter, ct.TableStylePr[0].ParaProp.Justification.Val)
+	assert.Equal(t, stypes.TblStyleOverrideBand1Horz, ct.TableStylePr[1].Type)
+	assert.Equal(t, stypes.TblStyleOverrideNwCell, ct.TableStylePr[2].Type)
+
+	output, err := xml.Marshal(ct)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), `<w:style w:type="table" w:styleId="Brand" w:customStyle="1">`)
+	assert.Contains(t, string(output), `<w:tblStylePr w:type="firstRow"><w:pPr><w:jc w:val="center"></w:jc></w:pPr><w:rPr>`)
+
+	// Adding the style again replaces it
+	style.Conditional(stypes.TblStyleOverrideLastRow).Bold(true)
+	require.NoError(t, rd.AddTableStyle(style))
+	require.Len(t, rd.DocStyles.StyleList, count+1)
+	assert.Len(t, rd.GetStyleByID("Brand", stypes.StyleTypeTable).TableStylePr, 4)
+
+	tbl := rd.AddTable()
+	tbl.Style(style.ID())
+	assert.Equal(t, "Brand", tbl.GetStyle())
+
+	err = setupStyledRootDoc(t).AddTableStyle(NewTableStyle("Normal", "Clash"))
+	assert.Error(t, err)
+}
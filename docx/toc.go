@@ -0,0 +1,290 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bfoley13/godocx/internal"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+const (
+	tocHeadingStyle   = "TOCHeading"
+	tocBookmarkPrefix = "_Toc"
+	tocNoEntriesText  = "No table of contents entries found."
+)
+
+// TOCOptions configures the table of contents inserted by InsertTOC.
+type TOCOptions struct {
+	// MinLevel and MaxLevel are the first and last heading levels listed, from 1 to 9. They
+	// default to 1 and 3.
+	MinLevel int
+	MaxLevel int
+
+	// Title is the text of the heading shown above the entries. No heading is added when it is
+	// empty.
+	Title string
+}
+
+// tocEntry is a heading listed in a table of contents.
+type tocEntry struct {
+	level    int // level is the one based heading level
+	text     string
+	bookmark string
+	page     int
+}
+
+// InsertTOC inserts a table of contents before the anchor paragraph, or at the end of the
+// body when anchor is nil. The table is a TOC field held in a table of contents content
+// control.
+//
+// The field result is filled in with an entry for every heading of the document in the
+// level range, styled TOC1 to TOC9, linking to a _Toc bookmark added to the heading. Page
+// numbers are estimated from the explicit page and section breaks of the document, and the
+// document is set to update its fields when it is opened so that Word recomputes them.
+func (rd *RootDoc) InsertTOC(anchor *Paragraph, opts TOCOptions) error {
+	minLevel, maxLevel := opts.MinLevel, opts.MaxLevel
+	if minLevel == 0 {
+		minLevel = 1
+	}
+	if maxLevel == 0 {
+		maxLevel = 3
+	}
+	if minLevel < 1 || maxLevel > 9 || minLevel > maxLevel {
+		return fmt.Errorf("invalid table of contents levels %d-%d", minLevel, maxLevel)
+	}
+
+	index, err := rd.anchorIndex(anchor)
+	if err != nil {
+		return err
+	}
+
+	entries := rd.tocEntries(func(para *ctypes.Paragraph) int {
+		if level := rd.OutlineLevel(para) + 1; level >= minLevel && level <= maxLevel {
+			return level
+		}
+		return 0
+	})
+	rd.ensureTOCStyles(minLevel, maxLevel, opts.Title != "")
+
+	sdt := &ctypes.StructuredDocumentTag{
+		Properties: &ctypes.SdtProperties{
+			ID: ctypes.NewDecimalNum(rd.generateContentControlID()),
+			DocPartObj: &ctypes.SdtDocPart{
+				Gallery: ctypes.NewCTString("Table of Contents"),
+				Unique:  onOffElem(true),
+			},
+		},
+		Content: &ctypes.SdtContent{},
+	}
+	addPara := func(p *Paragraph) {
+		sdt.Content.Children = append(sdt.Content.Children, ctypes.SdtContentChild{Paragraph: &p.ct})
+	}
+
+	if opts.Title != "" {
+		title := newParagraph(rd, paraWithText(opts.Title))
+		title.Style(tocHeadingStyle)
+		addPara(title)
+	}
+
+	instr := fmt.Sprintf(`TOC \o "%d-%d" \h \z \u`, minLevel, maxLevel)
+	for _, p := range rd.tocFieldParagraphs(instr, entries, tocNoEntriesText, func(entry tocEntry) string {
+		return fmt.Sprintf("TOC%d", entry.level)
+	}) {
+		addPara(p)
+	}
+
+	rd.insertBodyChildren(index, DocumentChild{Sdt: sdt})
+
+	return rd.SetUpdateFieldsOnOpen(true)
+}
+
+// anchorIndex returns the index in the body of the anchor paragraph, or the number of body
+// children when anchor is nil.
+func (rd *RootDoc) anchorIndex(anchor *Paragraph) (int, error) {
+	body := rd.Document.Body
+	if anchor == nil {
+		return len(body.Children), nil
+	}
+	for i, child := range body.Children {
+		if child.Para != nil && child.Para == anchor {
+			return i, nil
+		}
+	}
+	return -1, errors.New("anchor paragraph not found in the document body")
+}
+
+// insertBodyChildren inserts children in the body before the child at index.
+func (rd *RootDoc) insertBodyChildren(index int, children ...DocumentChild) {
+	body := rd.Document.Body
+	inserted := make([]DocumentChild, 0, len(body.Children)+len(children))
+	inserted = append(inserted, body.Children[:index]...)
+	inserted = append(inserted, children...)
+	body.Children = append(inserted, body.Children[index:]...)
+}
+
+// tocFieldParagraphs returns the paragraphs of a TOC field listing entries, each styled by
+// style. The field begins in the first entry and ends in a paragraph of its own. Without
+// entries, the field result is the empty text.
+func (rd *RootDoc) tocFieldParagraphs(instr string, entries []tocEntry, empty string, style func(tocEntry) string) []*Paragraph {
+	begin := ctypes.ParagraphChild{Run: &ctypes.Run{Children: []ctypes.RunChild{
+		{FldChar: newFieldChar(stypes.FldCharTypeBegin)},
+		{InstrText: ctypes.TextFromString(" " + instr + " ")},
+		{FldChar: newFieldChar(stypes.FldCharTypeSeparate)},
+	}}}
+	end := ctypes.ParagraphChild{Run: &ctypes.Run{Children: []ctypes.RunChild{
+		{FldChar: newFieldChar(stypes.FldCharTypeEnd)},
+	}}}
+
+	if len(entries) == 0 {
+		p := newParagraph(rd)
+		p.ct.Children = append(p.ct.Children, begin, ctypes.ParagraphChild{
+			Run: &ctypes.Run{Children: []ctypes.RunChild{{Text: ctypes.TextFromString(empty)}}},
+		}, end)
+		return []*Paragraph{p}
+	}
+
+	paras := make([]*Paragraph, 0, len(entries)+1)
+	width := rd.textWidth()
+	for i, entry := range entries {
+		p := rd.tocEntryParagraph(entry, style(entry), width)
+		if i == 0 {
+			p.ct.Children = append([]ctypes.ParagraphChild{begin}, p.ct.Children...)
+		}
+		paras = append(paras, p)
+	}
+	p := newParagraph(rd)
+	p.ct.Children = append(p.ct.Children, end)
+	return append(paras, p)
+}
+
+// tocEntries returns the paragraphs of the document listed in a table of contents, in
+// document order, with their level given by level, which returns 0 for paragraphs that are
+// not listed. A listed paragraph without a _Toc bookmark is given one.
+func (rd *RootDoc) tocEntries(level func(para *ctypes.Paragraph) int) []tocEntry {
+	used := make(map[string]bool)
+	for _, name := range rd.Bookmarks() {
+		used[name] = true
+	}
+	nextID := rd.nextBookmarkID()
+	nextName := 1
+
+	var entries []tocEntry
+	rd.walkBodyParagraphs(func(para *ctypes.Paragraph, page int) {
+		level := level(para)
+		if level == 0 {
+			return
+		}
+		text := strings.TrimSpace(paragraphPlainText(para))
+		if text == "" {
+			return
+		}
+
+		bookmark := ""
+		for _, child := range para.Children {
+			if child.BookmarkStart != nil && child.BookmarkStart.Name != nil &&
+				strings.HasPrefix(child.BookmarkStart.Name.Val, tocBookmarkPrefix) {
+				bookmark = child.BookmarkStart.Name.Val
+				break
+			}
+		}
+		if bookmark == "" {
+			for used[tocBookmarkPrefix+strconv.Itoa(nextName)] {
+				nextName++
+			}
+			bookmark = tocBookmarkPrefix + strconv.Itoa(nextName)
+			used[bookmark] = true
+			addBookmark(para, nextID, bookmark)
+			nextID++
+		}
+
+		entries = append(entries, tocEntry{level: level, text: text, bookmark: bookmark, page: page})
+	})
+	return entries
+}
+
+// tocEntryParagraph returns the paragraph of a table of contents entry: a link to the listed
+// paragraph holding its text and a PAGEREF field, separated by a right aligned tab with a dot
+// leader.
+func (rd *RootDoc) tocEntryParagraph(entry tocEntry, style string, width int) *Paragraph {
+	p := newParagraph(rd)
+	p.Style(style)
+	p.ct.Property.Tabs.Tab = append(p.ct.Property.Tabs.Tab, ctypes.Tab{
+		Val:        stypes.CustTabStopRight,
+		Position:   width,
+		LeaderChar: internal.ToPtr(stypes.CustLeadCharDot),
+	})
+
+	page := newRun(rd, &ctypes.Run{})
+	page.AddField(fmt.Sprintf(`PAGEREF %s \h`, entry.bookmark), strconv.Itoa(entry.page))
+
+	link := &ctypes.Hyperlink{
+		Anchor:  entry.bookmark,
+		History: ctypes.OnOffFromBool(true),
+		Children: []ctypes.ParagraphChild{
+			{Run: &ctypes.Run{Children: []ctypes.RunChild{{Text: ctypes.TextFromString(entry.text)}}}},
+			{Run: &ctypes.Run{Children: []ctypes.RunChild{{Tab: &ctypes.Empty{}}}}},
+			{Run: page.ct},
+		},
+	}
+	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Link: link})
+
+	return p
+}
+
+// ensureTOCStyles adds the styles of the table of contents entries in the level range, and of
+// its heading when heading is true, that are missing from the document styles.
+func (rd *RootDoc) ensureTOCStyles(minLevel, maxLevel int, heading bool) {
+	for level := minLevel; level <= maxLevel; level++ {
+		props := &ctypes.ParagraphProp{Spacing: &ctypes.Spacing{After: internal.ToPtr(uint64(100))}}
+		if level > 1 {
+			props.Indent = &ctypes.Indent{Left: internal.ToPtr((level - 1) * 220)}
+		}
+		rd.addMissingStyle(ctypes.Style{
+			Type:           internal.ToPtr(stypes.StyleTypeParagraph),
+			ID:             internal.ToPtr(fmt.Sprintf("TOC%d", level)),
+			Name:           ctypes.NewCTString(fmt.Sprintf("toc %d", level)),
+			BasedOn:        rd.styleIfDefined("Normal"),
+			Next:           rd.styleIfDefined("Normal"),
+			UIPriority:     ctypes.NewDecimalNum(39),
+			UnhideWhenUsed: onOffElem(true),
+			ParaProp:       props,
+		})
+	}
+
+	if heading {
+		// Outline level 9 keeps the heading out of the table of contents
+		rd.addMissingStyle(ctypes.Style{
+			Type:           internal.ToPtr(stypes.StyleTypeParagraph),
+			ID:             internal.ToPtr(tocHeadingStyle),
+			Name:           ctypes.NewCTString("TOC Heading"),
+			BasedOn:        rd.styleIfDefined("Heading1"),
+			Next:           rd.styleIfDefined("Normal"),
+			UIPriority:     ctypes.NewDecimalNum(39),
+			UnhideWhenUsed: onOffElem(true),
+			QFormat:        onOffElem(true),
+			ParaProp:       &ctypes.ParagraphProp{OutlineLvl: ctypes.NewDecimalNum(9)},
+		})
+	}
+}
+
+// styleIfDefined returns a reference to the paragraph style with the ID, or nil when the
+// document does not define it.
+func (rd *RootDoc) styleIfDefined(id string) *ctypes.CTString {
+	if rd.GetStyleByID(id, stypes.StyleTypeParagraph) == nil {
+		return nil
+	}
+	return ctypes.NewCTString(id)
+}
+
+// addMissingStyle adds a style to the document styles unless a style of the same type and ID
+// is defined.
+func (rd *RootDoc) addMissingStyle(style ctypes.Style) {
+	if rd.DocStyles == nil || rd.GetStyleByID(*style.ID, *style.Type) != nil {
+		return
+	}
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, style)
+}
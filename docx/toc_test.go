@@ -0,0 +1,183 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertTOC(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	heading := func(text, style string) {
+		rd.AddParagraph(text).Style(style)
+	}
+	rd.AddParagraph("Quarterly Report")
+	intro := rd.AddParagraph("Introduction text")
+	heading("Overview", "Heading1")
+	heading("Scope", "Heading2")
+	rd.AddPageBreak()
+	heading("Results", "Heading1")
+	heading("Detail", "Heading3")
+	heading("Too deep", "Heading4")
+	heading("", "Heading1")
+
+	require.NoError(t, rd.InsertTOC(intro, TOCOptions{Title: "Contents"}))
+
+	body := rd.Document.Body
+	sdt := body.Children[1].Sdt
+	require.NotNil(t, sdt)
+	assert.Same(t, intro, body.Children[2].Para)
+	assert.Equal(t, "Table of Contents", sdt.Properties.DocPartObj.Gallery.Val)
+
+	content := sdt.Content.Children
+	require.Len(t, content, 6)
+	assert.Equal(t, tocHeadingStyle, content[0].Paragraph.Property.Style.Val)
+
+	expected := []struct {
+		style    string
+		text     string
+		bookmark string
+		page     string
+	}{
+		{"TOC1", "Overview", "_Toc1", "1"},
+		{"TOC2", "Scope", "_Toc2", "1"},
+		{"TOC1", "Results", "_Toc3", "2"},
+		{"TOC3", "Detail", "_Toc4", "2"},
+	}
+	for i, entry := range expected {
+		para := content[i+1].Paragraph
+		assert.Equal(t, entry.style, para.Property.Style.Val)
+		assert.Equal(t, stypes.CustTabStopRight, para.Property.Tabs.Tab[0].Val)
+		assert.Equal(t, defaultTextWidth, para.Property.Tabs.Tab[0].Position)
+
+		link := para.Children[len(para.Children)-1].Link
+		require.NotNil(t, link)
+		assert.Equal(t, entry.bookmark, link.Anchor)
+		assert.Equal(t, entry.text, link.Children[0].Run.Children[0].Text.Text)
+
+		fields := scanFieldsAcrossRuns(paragraphRuns(&ctypes.Paragraph{Children: link.Children}))
+		require.Len(t, fields, 1)
+		assert.Equal(t, ` PAGEREF `+entry.bookmark+` \h `, fields[0].code)
+		assert.Equal(t, entry.page, link.Children[2].Run.Children[3].Text.Text)
+	}
+	assert.Equal(t, []string{"_Toc1", "_Toc2", "_Toc3", "_Toc4"}, rd.Bookmarks())
+
+	output, err := xml.Marshal(body)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), `<w:instrText xml:space="preserve"> TOC \o &#34;1-3&#34; \h \z \u </w:instrText>`)
+	assert.Contains(t, string(output), `<w:hyperlink w:anchor="_Toc1" w:history="true"><w:r><w:t>Overview</w:t></w:r>`)
+	assert.Contains(t, string(output), `<w:fldChar w:fldCharType="end"></w:fldChar></w:r></w:p></w:sdtContent></w:sdt>`)
+
+	var loaded Body
+	require.NoError(t, xml.Unmarshal(output, &loaded))
+	require.NotNil(t, loaded.Children[1].Sdt)
+	assert.Equal(t, "_Toc1", loaded.Children[1].Sdt.Content.Children[1].Paragraph.Children[1].Link.Anchor)
+
+	for _, id := range []string{"TOC1", "TOC2", "TOC3", tocHeadingStyle} {
+		assert.NotNil(t, rd.GetStyleByID(id, stypes.StyleTypeParagraph), id)
+	}
+	assert.Nil(t, rd.GetStyleByID("TOC4", stypes.StyleTypeParagraph))
+	assert.Equal(t, -1, rd.OutlineLevel(content[0].Paragraph))
+
+	settings, ok := rd.settingsPart()
+	require.True(t, ok)
+	assert.Contains(t, string(settings), `<w:updateFields w:val="true"/>`)
+	contentType, ok := rd.ContentType.overrideFor("/word/settings.xml")
+	assert.True(t, ok)
+	assert.Equal(t, settingsContentType, contentType)
+}
+
+func TestInsertTOC_ReusesBookmarks(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.AddParagraph("Overview").Style("Heading1")
+	rd.AddParagraph("Scope").Style("Heading2")
+
+	require.NoError(t, rd.InsertTOC(nil, TOCOptions{MinLevel: 2, MaxLevel: 2}))
+	require.NoError(t, rd.InsertTOC(nil, TOCOptions{}))
+
+	// The first table lists the second level heading only, which is bookmarked first
+	assert.Equal(t, []string{"_Toc2", "_Toc1"}, rd.Bookmarks())
+	first := rd.Document.Body.Children[2].Sdt.Content.Children
+	require.Len(t, first, 2)
+	assert.Equal(t, "_Toc1", first[0].Paragraph.Children[1].Link.Anchor)
+	second := rd.Document.Body.Children[3].Sdt.Content.Children
+	require.Len(t, second, 3)
+	assert.Equal(t, "_Toc2", second[0].Paragraph.Children[1].Link.Anchor)
+	assert.Equal(t, "_Toc1", second[1].Paragraph.Children[0].Link.Anchor)
+}
+
+func TestInsertTOC_NoHeadings(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.AddParagraph("Body text")
+
+	require.NoError(t, rd.InsertTOC(nil, TOCOptions{}))
+
+	content := rd.Document.Body.Children[1].Sdt.Content.Children
+	require.Len(t, content, 1)
+	assert.Equal(t, tocNoEntriesText, paragraphPlainText(content[0].Paragraph))
+	assert.Empty(t, rd.Bookmarks())
+}
+
+func TestInsertTOC_Errors(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	assert.Error(t, rd.InsertTOC(nil, TOCOptions{MinLevel: 4, MaxLevel: 2}))
+	assert.Error(t, rd.InsertTOC(nil, TOCOptions{MaxLevel: 10}))
+	assert.Error(t, rd.InsertTOC(newParagraph(rd), TOCOptions{}))
+	assert.Empty(t, rd.Document.Body.Children)
+}
+
+func TestSetUpdateFieldsOnOpen(t *testing.T) {
+	const ns = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"`
+
+	tests := []struct {
+		name     string
+		settings string
+		expected string
+	}{
+		{
+			name:     "Appended",
+			settings: `<w:settings ` + ns + `><w:zoom w:percent="100"/></w:settings>`,
+			expected: `<w:settings ` + ns + `><w:zoom w:percent="100"/><w:updateFields w:val="true"/></w:settings>`,
+		},
+		{
+			name:     "Before later settings",
+			settings: `<w:settings ` + ns + `><w:zoom/><w:docVars><w:docVar w:name="a" w:val="b"/></w:docVars></w:settings>`,
+			expected: `<w:settings ` + ns + `><w:zoom/><w:updateFields w:val="true"/><w:docVars><w:docVar w:name="a" w:val="b"/></w:docVars></w:settings>`,
+		},
+		{
+			name:     "Before extensions",
+			settings: `<w:settings ` + ns + ` xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml"><w:zoom/><w14:docId w14:val="1"/></w:settings>`,
+			expected: `<w:settings ` + ns + ` xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml"><w:zoom/><w:updateFields w:val="true"/><w14:docId w14:val="1"/></w:settings>`,
+		},
+		{
+			name:     "Replaced",
+			settings: `<w:settings ` + ns + `><w:updateFields w:val="false"></w:updateFields><w:compat/></w:settings>`,
+			expected: `<w:settings ` + ns + `><w:updateFields w:val="true"/><w:compat/></w:settings>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rd := setupRootDoc(t)
+			rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &Relationship{
+				ID:     "rId3",
+				Type:   constants.SettingsType,
+				Target: "settings.xml",
+			})
+			rd.FileMap.Store("word/settings.xml", []byte(tt.settings))
+
+			require.NoError(t, rd.SetUpdateFieldsOnOpen(true))
+			settings, ok := rd.settingsPart()
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, string(settings))
+			assert.Len(t, rd.Document.DocRels.Relationships, 1)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package docx
 
 import (
 	"archive/zip"
+	"bytes"
+	"encoding/xml"
 	"errors"
 	"io"
 	"os"
@@ -9,6 +11,7 @@ import (
 	"sort"
 
 	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/ctypes"
 )
 
 // Close method is used to close the RootDoc. Currently, it does not perform any specific actions.
@@ -81,6 +84,25 @@ func (rd *RootDoc) writeToZip(zw *zip.Writer) error {
 	}
 	rd.FileMap.Store(rd.DocStyles.RelativePath, docStyleBytes)
 
+	if rd.Numbering != nil {
+		numberingBytes, err := rd.modelContent(rd.Numbering.RelativePath, rd.Numbering, &ctypes.Numbering{})
+		if err != nil {
+			return err
+		}
+		rd.FileMap.Store(rd.Numbering.RelativePath, numberingBytes)
+	}
+
+	for _, notes := range []*ctypes.Footnotes{rd.footnotes, rd.endnotes} {
+		if notes == nil {
+			continue
+		}
+		notesBytes, err := rd.modelContent(notes.RelativePath, notes, &ctypes.Footnotes{})
+		if err != nil {
+			return err
+		}
+		rd.FileMap.Store(notes.RelativePath, notesBytes)
+	}
+
 	rd.FileMap.Range(func(path, content any) bool {
 		files = append(files, path.(string))
 		return true
@@ -99,6 +121,29 @@ func (rd *RootDoc) writeToZip(zw *zip.Writer) error {
 	return err
 }
 
+// modelContent returns the encoding of the model of a stored part, or the stored part itself
+// when the model encodes as the stored part decoded into decoded does, that is when the model
+// was not changed. Decoding drops the markup the model does not cover, such as unknown elements
+// and attributes, so unchanged parts are kept as they are.
+func (rd *RootDoc) modelContent(partName string, model any, decoded any) ([]byte, error) {
+	content, err := marshal(model)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, ok := rd.FileMap.Load(partName)
+	if !ok {
+		return content, nil
+	}
+	if err := xml.Unmarshal(stored.([]byte), decoded); err != nil {
+		return content, nil
+	}
+	if original, err := marshal(decoded); err != nil || !bytes.Equal(original, content) {
+		return content, nil
+	}
+	return stored.([]byte), nil
+}
+
 // Save method saves the RootDoc to the specified file path.
 func (rd *RootDoc) Save() error {
 	return rd.SaveTo(rd.Path)
@@ -0,0 +1,67 @@
+package docx
+
+import (
+	"io"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupStoredPartsDoc returns a document whose numbering and footnotes parts hold markup their
+// models do not cover.
+func setupStoredPartsDoc(t *testing.T) *RootDoc {
+	t.Helper()
+
+	rd := setupRootDoc(t)
+	rd.RootRels.RelativePath = "_rels/.rels"
+	rd.Document.relativePath = "word/document.xml"
+	rd.Document.DocRels.RelativePath = "word/_rels/document.xml.rels"
+	rd.DocStyles.RelativePath = "word/styles.xml"
+
+	numbering := []byte(`<w:numbering ` + mergeNS + ` xmlns:w15="http://schemas.microsoft.com/office/word/2012/wordml">` +
+		`<w:abstractNum w:abstractNumId="0" w15:restartNumberingAfterBreak="0"><w:lvl w:ilvl="0"><w:numFmt w:val="decimal"/></w:lvl></w:abstractNum>` +
+		`<w:num w:numId="1" w:durableId="1234"><w:abstractNumId w:val="0"/></w:num></w:numbering>`)
+	rd.FileMap.Store("word/numbering.xml", numbering)
+	var err error
+	rd.Numbering, err = LoadNumbering("word/numbering.xml", numbering)
+	require.NoError(t, err)
+
+	rd.FileMap.Store("word/footnotes.xml", []byte(`<w:footnotes `+mergeNS+`>`+
+		`<w:footnote w:id="1"><w:p><w:r><w:t>Note</w:t></w:r><w:customXml w:element="tag"/></w:p></w:footnote></w:footnotes>`))
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships,
+		&Relationship{ID: "rIdNotes", Type: constants.FootnotesType, Target: "footnotes.xml"})
+
+	return rd
+}
+
+func TestWrite_KeepsUnchangedParts(t *testing.T) {
+	rd := setupStoredPartsDoc(t)
+	numbering, _ := rd.FileMap.Load("word/numbering.xml")
+	footnotes, _ := rd.FileMap.Load("word/footnotes.xml")
+
+	// Reading the notes does not write them through their model
+	notes, err := rd.Footnotes()
+	require.NoError(t, err)
+	require.Len(t, notes.Notes, 1)
+
+	require.NoError(t, rd.Write(io.Discard))
+	written, _ := rd.FileMap.Load("word/numbering.xml")
+	assert.Equal(t, numbering, written)
+	written, _ = rd.FileMap.Load("word/footnotes.xml")
+	assert.Equal(t, footnotes, written)
+
+	// Changed parts are encoded from their model
+	rd.AddListDefinition(ListLevel{Format: stypes.NumFmtBullet})
+	notes.Notes = append(notes.Notes, notes.Notes[0])
+	notes.Notes[1].ID = 2
+
+	require.NoError(t, rd.Write(io.Discard))
+	written, _ = rd.FileMap.Load("word/numbering.xml")
+	assert.NotEqual(t, numbering, written)
+	assert.Contains(t, string(written.([]byte)), `w:numId="2"`)
+	written, _ = rd.FileMap.Load("word/footnotes.xml")
+	assert.Contains(t, string(written.([]byte)), `w:id="2"`)
+}
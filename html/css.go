@@ -0,0 +1,434 @@
+package html
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// declarations is an ordered list of CSS declarations such as "color: #FF0000". When a
+// property appears more than once the last declaration wins, as in CSS.
+type declarations []string
+
+func (d *declarations) add(property, value string) {
+	*d = append(*d, property+": "+value)
+}
+
+func (d declarations) String() string {
+	return strings.Join(d, "; ")
+}
+
+// highlightColors maps the highlight names of WordprocessingML to CSS colors.
+var highlightColors = map[string]string{
+	"black":       "black",
+	"blue":        "blue",
+	"cyan":        "cyan",
+	"green":       "green",
+	"magenta":     "magenta",
+	"red":         "red",
+	"yellow":      "yellow",
+	"white":       "white",
+	"darkBlue":    "darkblue",
+	"darkCyan":    "darkcyan",
+	"darkGreen":   "darkgreen",
+	"darkMagenta": "darkmagenta",
+	"darkRed":     "darkred",
+	"darkYellow":  "olive",
+	"darkGray":    "darkgray",
+	"lightGray":   "lightgray",
+}
+
+// twipsToPt formats a measurement in twentieths of a point as CSS points.
+func twipsToPt(twips float64) string {
+	return strconv.FormatFloat(twips/20, 'f', -1, 64) + "pt"
+}
+
+// cssColor converts a hexadecimal color to CSS, returning "" for automatic colors and for
+// values that are not six hexadecimal digits.
+func cssColor(val string) string {
+	if len(val) != 6 {
+		return ""
+	}
+	for _, r := range val {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return ""
+		}
+	}
+	return "#" + val
+}
+
+// cssFontFamily quotes a font name for the font-family property, returning "" for names with
+// characters other than letters, digits, spaces and "-_.&'()", which could end the string or
+// the style block.
+func cssFontFamily(font string) string {
+	for _, r := range font {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(" -_.&'()", r) {
+			return ""
+		}
+	}
+	return `"` + font + `"`
+}
+
+// shadingColor returns the CSS background color of a shading, or "" if it has none.
+func shadingColor(shd *ctypes.Shading) string {
+	if shd == nil || shd.Fill == nil {
+		return ""
+	}
+	return cssColor(*shd.Fill)
+}
+
+// borderCSS converts a border to a CSS border value, returning "" for missing borders.
+func borderCSS(b *ctypes.Border) string {
+	if b == nil {
+		return ""
+	}
+
+	style := "solid"
+	switch b.Val {
+	case stypes.BorderStyleNil, stypes.BorderStyleNone:
+		return "none"
+	case stypes.BorderStyleDouble:
+		style = "double"
+	case stypes.BorderStyleDotted:
+		style = "dotted"
+	case stypes.BorderStyleDashed:
+		style = "dashed"
+	}
+
+	// Border sizes are given in eighths of a point
+	width := "0.5pt"
+	if b.Size != nil && *b.Size > 0 {
+		width = strconv.FormatFloat(float64(*b.Size)/8, 'f', -1, 64) + "pt"
+	}
+
+	color := "#000000"
+	if b.Color != nil {
+		if c := cssColor(*b.Color); c != "" {
+			color = c
+		}
+	}
+
+	return width + " " + style + " " + color
+}
+
+// widthCSS converts a table or cell width to CSS, returning "" for automatic widths.
+func widthCSS(w *ctypes.TableWidth) string {
+	if w == nil || w.Width == nil || *w.Width == 0 {
+		return ""
+	}
+
+	widthType := stypes.TableWidthDxa
+	if w.WidthType != nil {
+		widthType = *w.WidthType
+	}
+
+	switch widthType {
+	case stypes.TableWidthDxa:
+		return twipsToPt(float64(*w.Width))
+	case stypes.TableWidthPct:
+		// Percentages are given in fiftieths of a percent
+		return strconv.FormatFloat(float64(*w.Width)/50, 'f', -1, 64) + "%"
+	}
+	return ""
+}
+
+// paraCSS converts paragraph properties to CSS declarations.
+func paraCSS(pp *ctypes.ParagraphProp) declarations {
+	var d declarations
+	if pp == nil {
+		return d
+	}
+
+	if pp.Justification != nil {
+		switch pp.Justification.Val {
+		case stypes.JustificationLeft:
+			d.add("text-align", "left")
+		case stypes.JustificationCenter:
+			d.add("text-align", "center")
+		case stypes.JustificationRight:
+			d.add("text-align", "right")
+		case stypes.JustificationBoth, stypes.JustificationDistribute:
+			d.add("text-align", "justify")
+		}
+	}
+
+	if sp := pp.Spacing; sp != nil {
+		if sp.Before != nil {
+			d.add("margin-top", twipsToPt(float64(*sp.Before)))
+		}
+		if sp.After != nil {
+			d.add("margin-bottom", twipsToPt(float64(*sp.After)))
+		}
+		if sp.Line != nil && *sp.Line > 0 {
+			if sp.LineRule == nil || *sp.LineRule == stypes.LineSpacingRuleAuto {
+				// Automatic line spacing is given in 240ths of a line
+				d.add("line-height", strconv.FormatFloat(float64(*sp.Line)/240, 'f', -1, 64))
+			} else {
+				d.add("line-height", twipsToPt(float64(*sp.Line)))
+			}
+		}
+	}
+
+	if ind := pp.Indent; ind != nil {
+		if ind.Left != nil {
+			d.add("margin-left", twipsToPt(float64(*ind.Left)))
+		}
+		if ind.Right != nil {
+			d.add("margin-right", twipsToPt(float64(*ind.Right)))
+		}
+		if ind.Hanging != nil {
+			d.add("text-indent", "-"+twipsToPt(float64(*ind.Hanging)))
+		} else if ind.FirstLine != nil {
+			d.add("text-indent", twipsToPt(float64(*ind.FirstLine)))
+		}
+	}
+
+	if bg := shadingColor(pp.Shading); bg != "" {
+		d.add("background-color", bg)
+	}
+
+	if pp.Border != nil {
+		for _, side := range []struct {
+			name   string
+			border *ctypes.Border
+		}{
+			{"top", pp.Border.Top},
+			{"right", pp.Border.Right},
+			{"bottom", pp.Border.Bottom},
+			{"left", pp.Border.Left},
+		} {
+			if css := borderCSS(side.border); css != "" {
+				d.add("border-"+side.name, css)
+			}
+		}
+	}
+
+	if pp.PageBreakBefore.Bool() {
+		d.add("break-before", "page")
+	}
+
+	return d
+}
+
+// runCSS converts run properties to CSS declarations. When semantic is set, bold, italic,
+// underline, strikethrough and vertical alignment are left to the semantic elements wrapping
+// the run, and only their explicit removal is written as CSS.
+func runCSS(rp *ctypes.RunProperty, semantic bool) declarations {
+	var d declarations
+	if rp == nil {
+		return d
+	}
+
+	if rp.Fonts != nil {
+		font := rp.Fonts.Ascii
+		if font == "" {
+			font = rp.Fonts.HAnsi
+		}
+		if font != "" {
+			if family := cssFontFamily(font); family != "" {
+				d.add("font-family", family)
+			}
+		}
+	}
+
+	if rp.Size != nil && rp.Size.Value > 0 {
+		// Font sizes are given in half points
+		d.add("font-size", strconv.FormatFloat(float64(rp.Size.Value)/2, 'f', -1, 64)+"pt")
+	}
+
+	if rp.Color != nil {
+		if c := cssColor(rp.Color.Val); c != "" {
+			d.add("color", c)
+		}
+	}
+
+	if rp.Highlight != nil && rp.Highlight.Val != "" && rp.Highlight.Val != "none" {
+		color, ok := highlightColors[rp.Highlight.Val]
+		if !ok {
+			color = cssColor(rp.Highlight.Val)
+		}
+		if color != "" {
+			d.add("background-color", color)
+		}
+	} else if bg := shadingColor(rp.Shading); bg != "" {
+		d.add("background-color", bg)
+	}
+
+	if rp.Bold != nil {
+		if !rp.Bold.Bool() {
+			d.add("font-weight", "normal")
+		} else if !semantic {
+			d.add("font-weight", "bold")
+		}
+	}
+
+	if rp.Italic != nil {
+		if !rp.Italic.Bool() {
+			d.add("font-style", "normal")
+		} else if !semantic {
+			d.add("font-style", "italic")
+		}
+	}
+
+	if !semantic {
+		var decorations []string
+		if underlined(rp) {
+			decorations = append(decorations, "underline")
+		}
+		if rp.Strike.Bool() || rp.DoubleStrike.Bool() {
+			decorations = append(decorations, "line-through")
+		}
+		if len(decorations) > 0 {
+			d.add("text-decoration", strings.Join(decorations, " "))
+		}
+
+		if rp.VertAlign != nil {
+			switch rp.VertAlign.Val {
+			case stypes.VerticalAlignRunSuperscript:
+				d.add("vertical-align", "super")
+			case stypes.VerticalAlignRunSubscript:
+				d.add("vertical-align", "sub")
+			}
+		}
+	}
+
+	if rp.Caps.Bool() {
+		d.add("text-transform", "uppercase")
+	}
+	if rp.SmallCaps.Bool() {
+		d.add("font-variant", "small-caps")
+	}
+	if rp.Spacing != nil && rp.Spacing.Val != 0 {
+		d.add("letter-spacing", twipsToPt(float64(rp.Spacing.Val)))
+	}
+	if rp.Vanish.Bool() {
+		d.add("display", "none")
+	}
+
+	return d
+}
+
+// underlined reports whether the run properties underline the text.
+func underlined(rp *ctypes.RunProperty) bool {
+	return rp != nil && rp.Underline != nil && rp.Underline.Val != "" && rp.Underline.Val != stypes.UnderlineNone
+}
+
+// tableCSS converts table properties to declarations for the table element and for its cells.
+func tableCSS(tp *ctypes.TableProp) (table, cell declarations) {
+	if tp == nil {
+		return nil, nil
+	}
+
+	if w := widthCSS(tp.Width); w != "" {
+		table.add("width", w)
+	}
+
+	if tp.Justification != nil {
+		switch tp.Justification.Val {
+		case stypes.JustificationCenter:
+			table.add("margin-left", "auto")
+			table.add("margin-right", "auto")
+		case stypes.JustificationRight:
+			table.add("margin-left", "auto")
+		}
+	}
+
+	if bg := shadingColor(tp.Shading); bg != "" {
+		table.add("background-color", bg)
+	}
+
+	if b := tp.Borders; b != nil {
+		for _, side := range []struct {
+			name   string
+			border *ctypes.Border
+		}{
+			{"top", b.Top},
+			{"right", b.Right},
+			{"bottom", b.Bottom},
+			{"left", b.Left},
+		} {
+			if css := borderCSS(side.border); css != "" {
+				table.add("border-"+side.name, css)
+			}
+		}
+
+		// Inside borders are drawn by the cells
+		if css := borderCSS(b.InsideH); css != "" {
+			cell.add("border-top", css)
+			cell.add("border-bottom", css)
+		}
+		if css := borderCSS(b.InsideV); css != "" {
+			cell.add("border-left", css)
+			cell.add("border-right", css)
+		}
+	}
+
+	return table, cell
+}
+
+// cellCSS converts table cell properties to CSS declarations.
+func cellCSS(cp *ctypes.CellProperty) declarations {
+	var d declarations
+	if cp == nil {
+		return d
+	}
+
+	if w := widthCSS(cp.Width); w != "" {
+		d.add("width", w)
+	}
+
+	if bg := shadingColor(cp.Shading); bg != "" {
+		d.add("background-color", bg)
+	}
+
+	if cp.VAlign != nil {
+		switch cp.VAlign.Val {
+		case stypes.VerticalJcTop:
+			d.add("vertical-align", "top")
+		case stypes.VerticalJcCenter:
+			d.add("vertical-align", "middle")
+		case stypes.VerticalJcBottom:
+			d.add("vertical-align", "bottom")
+		}
+	}
+
+	if b := cp.Borders; b != nil {
+		for _, side := range []struct {
+			name   string
+			border *ctypes.Border
+		}{
+			{"top", b.Top},
+			{"right", b.Right},
+			{"bottom", b.Bottom},
+			{"left", b.Left},
+		} {
+			if css := borderCSS(side.border); css != "" {
+				d.add("border-"+side.name, css)
+			}
+		}
+	}
+
+	return d
+}
+
+// className converts a style ID to a CSS class name.
+func className(styleID string) string {
+	var sb strings.Builder
+	for _, r := range styleID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('-')
+		}
+	}
+
+	name := sb.String()
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "s" + name
+	}
+	return name
+}
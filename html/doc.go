@@ -0,0 +1,7 @@
+// Package html converts documents to HTML.
+//
+// Export writes the body of a document as a standalone HTML page. Paragraphs, headings, lists,
+// tables, hyperlinks, images, headers, footers and footnotes are mapped to semantic HTML
+// elements, and a stylesheet is generated from the document styles and the direct formatting
+// of the content.
+package html
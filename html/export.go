@@ -0,0 +1,854 @@
+package html
+
+import (
+	"encoding/base64"
+	"fmt"
+	stdhtml "html"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bfoley13/godocx/dml"
+	"github.com/bfoley13/godocx/docx"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// emuPerPixel is the number of English Metric Units in a pixel at 96 DPI.
+const emuPerPixel = 9525
+
+// ExportOptions configures the HTML exporter.
+type ExportOptions struct {
+	// InlineStyles writes the effective formatting of every element in a style attribute
+	// instead of generating a stylesheet with classes.
+	InlineStyles bool
+
+	// ImageDir is the directory images are written to. Images are embedded as data URIs
+	// when it is empty.
+	ImageDir string
+
+	// ImageLinkPrefix is prepended to image file names in src attributes. It defaults to ImageDir.
+	ImageLinkPrefix string
+
+	// Title is written to the title element of the page.
+	Title string
+}
+
+// note is a footnote or endnote referenced from the body, numbered in order of appearance.
+type note struct {
+	label   int
+	content *ctypes.Footnote
+	part    string
+}
+
+// listFrame is an open ul or ol element.
+type listFrame struct {
+	numID, ilvl int
+	tag         string
+}
+
+type exporter struct {
+	rd   *docx.RootDoc
+	opts ExportOptions
+	out  strings.Builder
+
+	// rels resolves relationship IDs of the part being exported
+	rels *docx.Relationships
+
+	styleRules    []string
+	formatRules   []string
+	styleClasses  map[string]string
+	formatClasses map[string]string
+
+	lists     []listFrame
+	counters  map[int][]int
+	notes     []note
+	footnotes *ctypes.Footnotes
+	endnotes  *ctypes.Footnotes
+}
+
+// Export writes a document as a standalone HTML page.
+//
+// Headings are derived from the outline level of each paragraph, lists from the numbering
+// definitions, and bold, italic, underline, strikethrough and vertical alignment from the run
+// formatting. Tables keep their merged cells as colspan and rowspan, the default header and
+// footer of the last section are written as header and footer elements, and footnotes and
+// endnotes are collected in a closing footnotes section.
+//
+// Unless InlineStyles is set, the page carries a stylesheet with a class for every style used
+// in the document and a generated class for every distinct set of direct formatting.
+//
+// Parameters:
+//   - rd: The document to export.
+//   - w: The writer receiving the HTML output.
+//   - opts: Options controlling the export.
+//
+// Returns:
+//   - error: An error if a part cannot be decoded or an image or the output cannot be written.
+func Export(rd *docx.RootDoc, w io.Writer, opts ExportOptions) error {
+	if opts.ImageLinkPrefix == "" {
+		opts.ImageLinkPrefix = filepath.ToSlash(opts.ImageDir)
+	}
+
+	e := &exporter{
+		rd:            rd,
+		opts:          opts,
+		rels:          &rd.Document.DocRels,
+		styleClasses:  make(map[string]string),
+		formatClasses: make(map[string]string),
+		counters:      make(map[int][]int),
+	}
+
+	var err error
+	if e.footnotes, err = rd.Footnotes(); err != nil {
+		return err
+	}
+	if e.endnotes, err = rd.Endnotes(); err != nil {
+		return err
+	}
+
+	var (
+		blocks []ctypes.TCBlockContent
+		sectPr *ctypes.SectionProp
+	)
+	if body := rd.Document.Body; body != nil {
+		for _, child := range body.Children {
+			if child.Para != nil {
+				blocks = append(blocks, ctypes.TCBlockContent{Paragraph: child.Para.GetCT()})
+			}
+			if child.Table != nil {
+				blocks = append(blocks, ctypes.TCBlockContent{Table: child.Table.GetCT()})
+			}
+		}
+		sectPr = body.SectPr
+	}
+
+	if sectPr != nil && sectPr.HeaderReference != nil {
+		if err := e.hdrFtr("header", sectPr.HeaderReference.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := e.blocks(blocks); err != nil {
+		return err
+	}
+
+	if sectPr != nil && sectPr.FooterReference != nil {
+		if err := e.hdrFtr("footer", sectPr.FooterReference.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := e.writeNotes(); err != nil {
+		return err
+	}
+
+	return e.writePage(w)
+}
+
+// writePage writes the page around the exported body.
+func (e *exporter) writePage(w io.Writer) error {
+	var sb strings.Builder
+
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString("<title>" + stdhtml.EscapeString(e.opts.Title) + "</title>\n")
+
+	bodyCSS := runCSS(e.rd.EffectiveRunProp(nil, nil), false)
+
+	if !e.opts.InlineStyles {
+		sb.WriteString("<style>\n")
+		if len(bodyCSS) > 0 {
+			sb.WriteString("body { " + bodyCSS.String() + " }\n")
+		}
+
+		var blockCSS declarations
+		blockCSS.add("margin", "0")
+		blockCSS = append(blockCSS, paraCSS(e.rd.EffectiveParaProp(nil))...)
+		sb.WriteString("p, li, h1, h2, h3, h4, h5, h6 { " + blockCSS.String() + " }\n")
+		sb.WriteString("h1, h2, h3, h4, h5, h6 { font-size: inherit; font-weight: inherit }\n")
+		sb.WriteString("table { border-collapse: collapse }\n")
+		sb.WriteString("td, th { padding: 0 5.4pt; vertical-align: top; text-align: left; font-weight: inherit }\n")
+
+		for _, rule := range e.styleRules {
+			sb.WriteString(rule + "\n")
+		}
+		for _, rule := range e.formatRules {
+			sb.WriteString(rule + "\n")
+		}
+		sb.WriteString("</style>\n")
+	}
+
+	sb.WriteString("</head>\n<body")
+	if e.opts.InlineStyles && len(bodyCSS) > 0 {
+		sb.WriteString(` style="` + stdhtml.EscapeString(bodyCSS.String()) + `"`)
+	}
+	sb.WriteString(">\n")
+	sb.WriteString(e.out.String())
+	sb.WriteString("</body>\n</html>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// attrs returns the class or style attribute of an element. In inline mode the declarations
+// are written as a style attribute; otherwise they are turned into a generated class that is
+// listed after the style classes.
+func (e *exporter) attrs(classes []string, d declarations) string {
+	if e.opts.InlineStyles {
+		if len(d) == 0 {
+			return ""
+		}
+		return ` style="` + stdhtml.EscapeString(d.String()) + `"`
+	}
+
+	if len(d) > 0 {
+		classes = append(classes, e.formatClass(d))
+	}
+	if len(classes) == 0 {
+		return ""
+	}
+	return ` class="` + stdhtml.EscapeString(strings.Join(classes, " ")) + `"`
+}
+
+// formatClass returns the generated class for a set of direct formatting declarations.
+func (e *exporter) formatClass(d declarations) string {
+	key := d.String()
+	if name, ok := e.formatClasses[key]; ok {
+		return name
+	}
+
+	name := fmt.Sprintf("fmt-%d", len(e.formatClasses)+1)
+	e.formatClasses[key] = name
+	e.formatRules = append(e.formatRules, "."+name+" { "+key+" }")
+
+	return name
+}
+
+// styleClass returns the class of a document style, adding its rules to the stylesheet on
+// first use.
+func (e *exporter) styleClass(styleID string, styleType stypes.StyleType) string {
+	key := string(styleType) + ":" + styleID
+	if name, ok := e.styleClasses[key]; ok {
+		return name
+	}
+
+	name := className(styleID)
+	if styleType == stypes.StyleTypeCharacter {
+		// Paragraph and character styles may share an ID, as with linked styles
+		name += "-char"
+	}
+	e.styleClasses[key] = name
+
+	paraProp, runProp := e.rd.ResolveStyle(styleID, styleType)
+
+	switch styleType {
+	case stypes.StyleTypeParagraph:
+		if d := append(paraCSS(paraProp), runCSS(runProp, false)...); len(d) > 0 {
+			e.styleRules = append(e.styleRules, "."+name+" { "+d.String()+" }")
+		}
+	case stypes.StyleTypeCharacter:
+		if d := runCSS(runProp, false); len(d) > 0 {
+			e.styleRules = append(e.styleRules, "."+name+" { "+d.String()+" }")
+		}
+	case stypes.StyleTypeTable:
+		var tableProp *ctypes.TableProp
+		if style := e.rd.GetStyleByID(styleID, styleType); style != nil {
+			tableProp = style.TableProp
+		}
+		table, cell := tableCSS(tableProp)
+		cell = append(cell, runCSS(runProp, false)...)
+		if len(table) > 0 {
+			e.styleRules = append(e.styleRules, "table."+name+" { "+table.String()+" }")
+		}
+		if len(cell) > 0 {
+			// :where keeps the specificity of the rule below that of the direct formatting classes
+			e.styleRules = append(e.styleRules, "."+name+" :where(td, th) { "+cell.String()+" }")
+		}
+	}
+
+	return name
+}
+
+func (e *exporter) blocks(contents []ctypes.TCBlockContent) error {
+	for _, block := range contents {
+		if block.Paragraph != nil {
+			if err := e.paragraph(block.Paragraph); err != nil {
+				return err
+			}
+		}
+		if block.Table != nil {
+			e.closeLists()
+			if err := e.table(block.Table); err != nil {
+				return err
+			}
+		}
+	}
+	e.closeLists()
+
+	return nil
+}
+
+func (e *exporter) paragraph(p *ctypes.Paragraph) error {
+	props := e.rd.EffectiveParaProp(p)
+	level := e.rd.OutlineLevel(p)
+
+	var (
+		classes []string
+		css     declarations
+	)
+	if e.opts.InlineStyles {
+		css = append(paraCSS(props), runCSS(e.rd.EffectiveRunProp(p, nil), false)...)
+	} else {
+		if p.Property != nil && p.Property.Style != nil {
+			classes = append(classes, e.styleClass(p.Property.Style.Val, stypes.StyleTypeParagraph))
+		}
+		css = paraCSS(p.Property)
+	}
+
+	content, err := e.inline(p, p.Children)
+	if err != nil {
+		return err
+	}
+
+	if level < 0 && props.NumProp != nil && props.NumProp.NumID != nil && props.NumProp.NumID.Val > 0 {
+		ilvl := 0
+		if props.NumProp.ILvl != nil {
+			ilvl = props.NumProp.ILvl.Val
+		}
+		e.openListItem(props.NumProp.NumID.Val, ilvl)
+		e.out.WriteString("<li" + e.attrs(classes, css) + ">" + content)
+		return nil
+	}
+
+	e.closeLists()
+
+	tag := "p"
+	if level >= 0 {
+		if level > 5 {
+			level = 5
+		}
+		tag = fmt.Sprintf("h%d", level+1)
+	}
+
+	// Empty paragraphs still take up a line
+	if content == "" {
+		content = "<br>"
+	}
+
+	e.out.WriteString("<" + tag + e.attrs(classes, css) + ">" + content + "</" + tag + ">\n")
+	return nil
+}
+
+// openListItem opens and closes the list elements needed before the next item of the given
+// list level. The item itself is left to the caller and closed by the next list item or by
+// closeLists.
+func (e *exporter) openListItem(numID, ilvl int) {
+	for n := len(e.lists); n > 0; n = len(e.lists) {
+		top := e.lists[n-1]
+		if top.ilvl < ilvl || (top.ilvl == ilvl && top.numID == numID) {
+			break
+		}
+		e.popList()
+	}
+
+	number := e.advance(numID, ilvl)
+
+	if n := len(e.lists); n > 0 && e.lists[n-1].ilvl == ilvl {
+		e.out.WriteString("</li>\n")
+		return
+	}
+
+	var lvl *ctypes.NumLevel
+	if e.rd.Numbering != nil {
+		lvl = e.rd.Numbering.Level(numID, ilvl)
+	}
+
+	tag, attrs := "ul", ""
+	if lvl != nil && lvl.NumFmt != nil && lvl.NumFmt.Val != stypes.NumFmtBullet && lvl.NumFmt.Val != stypes.NumFmtNone {
+		tag = "ol"
+		switch lvl.NumFmt.Val {
+		case stypes.NumFmtLowerLetter:
+			attrs += ` type="a"`
+		case stypes.NumFmtUpperLetter:
+			attrs += ` type="A"`
+		case stypes.NumFmtLowerRoman:
+			attrs += ` type="i"`
+		case stypes.NumFmtUpperRoman:
+			attrs += ` type="I"`
+		}
+		if number != 1 {
+			attrs += fmt.Sprintf(` start="%d"`, number)
+		}
+	}
+
+	if len(e.lists) > 0 {
+		e.out.WriteString("\n")
+	}
+	e.out.WriteString("<" + tag + attrs + ">\n")
+	e.lists = append(e.lists, listFrame{numID: numID, ilvl: ilvl, tag: tag})
+}
+
+// advance returns the number of the next item of a list level and advances the item counter.
+func (e *exporter) advance(numID, ilvl int) int {
+	counters := e.counters[numID]
+	for len(counters) <= ilvl {
+		counters = append(counters, 0)
+	}
+	// Items at a shallower level restart the numbering of deeper levels
+	counters = counters[:ilvl+1]
+
+	if counters[ilvl] == 0 {
+		counters[ilvl] = 1
+		if e.rd.Numbering != nil {
+			if lvl := e.rd.Numbering.Level(numID, ilvl); lvl != nil && lvl.Start != nil {
+				counters[ilvl] = lvl.Start.Val
+			}
+		}
+	} else {
+		counters[ilvl]++
+	}
+	e.counters[numID] = counters
+
+	return counters[ilvl]
+}
+
+func (e *exporter) popList() {
+	top := e.lists[len(e.lists)-1]
+	e.lists = e.lists[:len(e.lists)-1]
+	e.out.WriteString("</li>\n</" + top.tag + ">\n")
+}
+
+func (e *exporter) closeLists() {
+	for len(e.lists) > 0 {
+		e.popList()
+	}
+}
+
+// inline renders the content of a paragraph.
+func (e *exporter) inline(p *ctypes.Paragraph, children []ctypes.ParagraphChild) (string, error) {
+	var sb strings.Builder
+
+	for _, child := range children {
+		switch {
+		case child.Run != nil:
+			text, err := e.run(p, child.Run)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(text)
+		case child.Link != nil:
+			text, err := e.link(p, child.Link)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(text)
+		case child.Sdt != nil && child.Sdt.Content != nil:
+			for _, sdtChild := range child.Sdt.Content.Children {
+				var inner []ctypes.ParagraphChild
+				if sdtChild.Run != nil {
+					inner = append(inner, ctypes.ParagraphChild{Run: sdtChild.Run})
+				}
+				if sdtChild.Paragraph != nil {
+					inner = append(inner, sdtChild.Paragraph.Children...)
+				}
+				text, err := e.inline(p, inner)
+				if err != nil {
+					return "", err
+				}
+				sb.WriteString(text)
+			}
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func (e *exporter) link(p *ctypes.Paragraph, link *ctypes.Hyperlink) (string, error) {
+	var children []ctypes.ParagraphChild
+	if link.Run != nil {
+		children = append(children, ctypes.ParagraphChild{Run: link.Run})
+	}
+	children = append(children, link.Children...)
+
+	text, err := e.inline(p, children)
+	if err != nil {
+		return "", err
+	}
+
+	rel := e.rels.GetRelationByID(link.ID)
+	if rel == nil || !safeHref(rel.Target) {
+		return text, nil
+	}
+
+	return `<a href="` + stdhtml.EscapeString(rel.Target) + `">` + text + "</a>", nil
+}
+
+// safeHref reports whether a link target can be written as an href: an http, https or mailto
+// URL, or a fragment of the page. Other schemes such as javascript: are not written.
+func safeHref(target string) bool {
+	if strings.HasPrefix(target, "#") {
+		return len(target) > 1
+	}
+	u, err := url.Parse(strings.TrimSpace(target))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+func (e *exporter) run(p *ctypes.Paragraph, run *ctypes.Run) (string, error) {
+	if e.rd.EffectiveRunProp(p, run).Vanish.Bool() {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	for _, child := range run.Children {
+		switch {
+		case child.Text != nil:
+			sb.WriteString(stdhtml.EscapeString(child.Text.Text))
+		case child.Tab != nil:
+			sb.WriteString("\t")
+		case child.NoBreakHyphen != nil:
+			sb.WriteString("&#8209;")
+		case child.SoftHyphen != nil:
+			sb.WriteString("&shy;")
+		case child.Break != nil:
+			if child.Break.BreakType == nil || *child.Break.BreakType == stypes.BreakTypeTextWrapping {
+				sb.WriteString("<br>")
+			}
+		case child.CarrRtn != nil:
+			sb.WriteString("<br>")
+		case child.Drawing != nil:
+			img, err := e.image(child.Drawing)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(img)
+		case child.FootnoteReference != nil:
+			sb.WriteString(e.noteRef(e.footnotes, child.FootnoteReference.ID))
+		case child.EndnoteReference != nil:
+			sb.WriteString(e.noteRef(e.endnotes, child.EndnoteReference.ID))
+		}
+	}
+
+	text := sb.String()
+	if text == "" {
+		return "", nil
+	}
+
+	direct := run.Property
+	if direct == nil {
+		direct = &ctypes.RunProperty{}
+	}
+	style := &ctypes.RunProperty{}
+
+	var classes []string
+	if direct.Style != nil {
+		_, style = e.rd.ResolveStyle(direct.Style.Val, stypes.StyleTypeCharacter)
+		if !e.opts.InlineStyles {
+			classes = append(classes, e.styleClass(direct.Style.Val, stypes.StyleTypeCharacter))
+		}
+	}
+
+	// Direct formatting overrides the character style
+	flag := func(direct, style *ctypes.OnOff) bool {
+		if direct != nil {
+			return direct.Bool()
+		}
+		return style.Bool()
+	}
+
+	underline := underlined(style)
+	if direct.Underline != nil {
+		underline = underlined(direct)
+	}
+
+	vertAlign := style.VertAlign
+	if direct.VertAlign != nil {
+		vertAlign = direct.VertAlign
+	}
+
+	var tags []string
+	if flag(direct.Bold, style.Bold) {
+		tags = append(tags, "strong")
+	}
+	if flag(direct.Italic, style.Italic) {
+		tags = append(tags, "em")
+	}
+	if underline {
+		tags = append(tags, "u")
+	}
+	if flag(direct.Strike, style.Strike) || flag(direct.DoubleStrike, style.DoubleStrike) {
+		tags = append(tags, "s")
+	}
+	if vertAlign != nil {
+		switch vertAlign.Val {
+		case stypes.VerticalAlignRunSuperscript:
+			tags = append(tags, "sup")
+		case stypes.VerticalAlignRunSubscript:
+			tags = append(tags, "sub")
+		}
+	}
+
+	for i := len(tags) - 1; i >= 0; i-- {
+		text = "<" + tags[i] + ">" + text + "</" + tags[i] + ">"
+	}
+
+	css := runCSS(direct, true)
+	if e.opts.InlineStyles {
+		css = append(runCSS(style, true), css...)
+	}
+	if attrs := e.attrs(classes, css); attrs != "" {
+		text = "<span" + attrs + ">" + text + "</span>"
+	}
+
+	return text, nil
+}
+
+// noteRef registers a referenced note and returns its reference mark.
+func (e *exporter) noteRef(notes *ctypes.Footnotes, id int) string {
+	if notes == nil {
+		return ""
+	}
+
+	content := notes.NoteByID(id)
+	if content == nil {
+		return ""
+	}
+
+	label := len(e.notes) + 1
+	e.notes = append(e.notes, note{label: label, content: content, part: notes.RelativePath})
+
+	return fmt.Sprintf(`<sup class="footnote-ref"><a href="#fn%d" id="fnref%d">%d</a></sup>`, label, label, label)
+}
+
+// writeNotes appends the notes referenced from the document as an ordered list.
+func (e *exporter) writeNotes() error {
+	if len(e.notes) == 0 {
+		return nil
+	}
+
+	e.out.WriteString("<section class=\"footnotes\">\n<hr>\n<ol>\n")
+
+	// Notes referenced from other notes are appended to e.notes while the list is written
+	for i := 0; i < len(e.notes); i++ {
+		n := e.notes[i]
+
+		rels, err := e.rd.PartRelations(n.part)
+		if err != nil {
+			return err
+		}
+		e.rels = rels
+
+		fmt.Fprintf(&e.out, "<li id=\"fn%d\">\n", n.label)
+		if err := e.blocks(n.content.Contents); err != nil {
+			return err
+		}
+		fmt.Fprintf(&e.out, "<a href=\"#fnref%d\" class=\"footnote-back\">&#8617;</a>\n</li>\n", n.label)
+	}
+
+	e.out.WriteString("</ol>\n</section>\n")
+	e.rels = &e.rd.Document.DocRels
+
+	return nil
+}
+
+// hdrFtr writes a header or footer part as a header or footer element.
+func (e *exporter) hdrFtr(tag, rID string) error {
+	part, err := e.rd.HeaderFooter(rID)
+	if err != nil {
+		return err
+	}
+
+	rels, err := e.rd.PartRelations(part.RelativePath)
+	if err != nil {
+		return err
+	}
+
+	e.rels = rels
+	defer func() { e.rels = &e.rd.Document.DocRels }()
+
+	e.out.WriteString("<" + tag + ">\n")
+	if err := e.blocks(part.Contents); err != nil {
+		return err
+	}
+	e.out.WriteString("</" + tag + ">\n")
+
+	return nil
+}
+
+// image writes the pictures of a drawing as img elements. The pictures are embedded as data
+// URIs, or written to the image directory when one is configured.
+func (e *exporter) image(drawing *dml.Drawing) (string, error) {
+	type picture struct {
+		graphic *dml.Graphic
+		docProp dml.DocProp
+		width   uint64
+		height  uint64
+	}
+
+	var pictures []picture
+	for i := range drawing.Inline {
+		inline := &drawing.Inline[i]
+		pictures = append(pictures, picture{&inline.Graphic, inline.DocProp, inline.Extent.Width, inline.Extent.Height})
+	}
+	for _, anchor := range drawing.Anchor {
+		pictures = append(pictures, picture{&anchor.Graphic, anchor.DocProp, anchor.Extent.Width, anchor.Extent.Height})
+	}
+
+	var sb strings.Builder
+	for _, pic := range pictures {
+		if pic.graphic.Data == nil || pic.graphic.Data.Pic == nil || pic.graphic.Data.Pic.BlipFill.Blip == nil {
+			continue
+		}
+
+		rel := e.rels.GetRelationByID(pic.graphic.Data.Pic.BlipFill.Blip.EmbedID)
+		if rel == nil {
+			continue
+		}
+
+		content, ok := e.rd.ReadPart(rel.Target)
+		if !ok {
+			return "", fmt.Errorf("image %s not found in package", rel.Target)
+		}
+
+		fileName := path.Base(rel.Target)
+		var src string
+		if e.opts.ImageDir != "" {
+			if err := os.MkdirAll(e.opts.ImageDir, 0o755); err != nil {
+				return "", err
+			}
+			if err := os.WriteFile(filepath.Join(e.opts.ImageDir, fileName), content, 0o644); err != nil {
+				return "", err
+			}
+			src = path.Join(e.opts.ImageLinkPrefix, fileName)
+		} else {
+			mime, err := docx.MIMEFromExt(path.Ext(fileName))
+			if err != nil {
+				mime = "application/octet-stream"
+			}
+			src = "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(content)
+		}
+
+		alt := pic.docProp.Description
+		if alt == "" {
+			alt = pic.docProp.Name
+		}
+
+		fmt.Fprintf(&sb, `<img src="%s" alt="%s"`, stdhtml.EscapeString(src), stdhtml.EscapeString(alt))
+		if pic.width > 0 && pic.height > 0 {
+			fmt.Fprintf(&sb, ` width="%d" height="%d"`, (pic.width+emuPerPixel/2)/emuPerPixel, (pic.height+emuPerPixel/2)/emuPerPixel)
+		}
+		sb.WriteString(">")
+	}
+
+	return sb.String(), nil
+}
+
+// logicalGrid returns the logical grid of a table of the document, trimmed after the last
+// cell of each row.
+func (e *exporter) logicalGrid(tbl *ctypes.Table) [][]docx.GridCell {
+	table := docx.NewTable(e.rd)
+	*table.GetCT() = *tbl
+
+	grid := table.LogicalGrid()
+	for r, row := range grid {
+		for len(row) > 0 && row[len(row)-1].Cell == nil {
+			row = row[:len(row)-1]
+		}
+		grid[r] = row
+	}
+	return grid
+}
+
+func (e *exporter) table(tbl *ctypes.Table) error {
+	var classes []string
+	table, cell := tableCSS(&tbl.TableProp)
+	if tbl.TableProp.Style != nil {
+		if e.opts.InlineStyles {
+			var style *ctypes.TableProp
+			if s := e.rd.GetStyleByID(tbl.TableProp.Style.Val, stypes.StyleTypeTable); s != nil {
+				style = s.TableProp
+			}
+			styleTable, styleCell := tableCSS(style)
+			table = append(styleTable, table...)
+			cell = append(styleCell, cell...)
+		} else {
+			classes = append(classes, e.styleClass(tbl.TableProp.Style.Val, stypes.StyleTypeTable))
+		}
+	}
+
+	e.out.WriteString("<table" + e.attrs(classes, table) + ">\n")
+
+	// Leading rows marked as header rows are repeated on each page and form the table head
+	headerRows := 0
+	for _, rowContent := range tbl.RowContents {
+		if rowContent.Row == nil || rowContent.Row.Property == nil || !rowContent.Row.Property.Header.Bool() {
+			break
+		}
+		headerRows++
+	}
+
+	grid := e.logicalGrid(tbl)
+	for r, row := range grid {
+		switch {
+		case r == 0 && headerRows > 0:
+			e.out.WriteString("<thead>\n")
+		case r == headerRows:
+			e.out.WriteString("<tbody>\n")
+		}
+
+		tag := "td"
+		if r < headerRows {
+			tag = "th"
+		}
+
+		e.out.WriteString("<tr>\n")
+		for c, gc := range row {
+			switch {
+			case gc.Cell == nil:
+				// Grid columns skipped by the row are kept as empty cells to align the next ones
+				e.out.WriteString("<" + tag + "></" + tag + ">\n")
+				continue
+			case gc.Row != r || gc.Col != c:
+				continue
+			}
+
+			ct := gc.Cell.GetCT()
+			css := append(append(declarations{}, cell...), cellCSS(ct.Property)...)
+
+			e.out.WriteString("<" + tag)
+			if gc.ColSpan > 1 {
+				fmt.Fprintf(&e.out, ` colspan="%d"`, gc.ColSpan)
+			}
+			if gc.RowSpan > 1 {
+				fmt.Fprintf(&e.out, ` rowspan="%d"`, gc.RowSpan)
+			}
+			e.out.WriteString(e.attrs(nil, css) + ">\n")
+
+			if err := e.blocks(ct.Contents); err != nil {
+				return err
+			}
+			e.out.WriteString("</" + tag + ">\n")
+		}
+		e.out.WriteString("</tr>\n")
+
+		if r == headerRows-1 {
+			e.out.WriteString("</thead>\n")
+		}
+	}
+
+	if len(grid) > headerRows {
+		e.out.WriteString("</tbody>\n")
+	}
+	e.out.WriteString("</table>\n")
+
+	return nil
+}
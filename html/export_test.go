@@ -0,0 +1,284 @@
+package html
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/docx"
+	"github.com/bfoley13/godocx/packager"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTemplate(t *testing.T) *docx.RootDoc {
+	t.Helper()
+
+	content, err := os.ReadFile("../templates/default.docx")
+	require.NoError(t, err)
+
+	rd, err := packager.Unpack(&content)
+	require.NoError(t, err)
+
+	rd.Document.Body.Children = nil
+	return rd
+}
+
+func export(t *testing.T, rd *docx.RootDoc, opts ExportOptions) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, Export(rd, &buf, opts))
+	return buf.String()
+}
+
+func TestExport_HeadingsAndInline(t *testing.T) {
+	rd := loadTemplate(t)
+
+	_, err := rd.AddHeading("Report", 0)
+	require.NoError(t, err)
+	_, err = rd.AddHeading("Details", 2)
+	require.NoError(t, err)
+
+	p := rd.AddParagraph("Plain ")
+	p.AddText("bold").Bold(true)
+	p.AddText(" & ")
+	p.AddText("italic").Italic(true)
+	p.AddText(" <red>").Color("FF0000")
+	p.Justification(stypes.JustificationCenter)
+
+	output := export(t, rd, ExportOptions{Title: "Report"})
+
+	assert.Contains(t, output, "<title>Report</title>")
+	assert.Contains(t, output, `<h1 class="Title">Report</h1>`)
+	assert.Contains(t, output, `<h2 class="Heading2">Details</h2>`)
+	assert.Contains(t, output, `<p class="fmt-2">Plain <strong>bold</strong> &amp; <em>italic</em><span class="fmt-1"> &lt;red&gt;</span></p>`)
+
+	// The stylesheet holds the used styles followed by the direct formatting
+	assert.Contains(t, output, "\n.Title { ")
+	assert.Contains(t, output, "\n.fmt-1 { color: #FF0000 }\n.fmt-2 { text-align: center }\n")
+	assert.NotContains(t, output, ".ListBullet")
+}
+
+func TestExport_InlineStyles(t *testing.T) {
+	rd := loadTemplate(t)
+
+	_, err := rd.AddHeading("Report", 1)
+	require.NoError(t, err)
+	rd.AddParagraph("").AddText("big").Size(20)
+
+	output := export(t, rd, ExportOptions{InlineStyles: true})
+
+	assert.NotContains(t, output, "<style>")
+	assert.NotContains(t, output, "class=")
+	assert.Regexp(t, `<h1 style="[^"]*font-weight: bold[^"]*">Report</h1>`, output)
+	assert.Contains(t, output, `<span style="font-size: 20pt">big</span>`)
+}
+
+func TestExport_UnsafeContent(t *testing.T) {
+	rd := loadTemplate(t)
+
+	p := rd.AddParagraph("")
+	p.AddText("font").Font(`x</style><script>alert(1)</script>`)
+	p.AddText("serif").Font("Times New Roman")
+	p.AddText("mark").Highlight(`red;}</style><script>`)
+	p.AddText("dark").Highlight("darkBlue")
+	p.AddText("hex").Highlight("00FF00")
+	rd.AddParagraph("").AddLink("run", "javascript:alert(2)")
+	rd.AddParagraph("").AddLink("site", "https://example.com/?a=1&b=2")
+	rd.AddParagraph("").AddLink("mail", "mailto:team@example.com")
+	rd.AddParagraph("").AddLink("data", " data:text/html,<script>alert(3)</script>")
+
+	output := export(t, rd, ExportOptions{})
+
+	assert.NotContains(t, output, "<script>")
+	assert.NotContains(t, output, "javascript:")
+	assert.NotContains(t, output, "data:text")
+	assert.Contains(t, output, `font-family: "Times New Roman"`)
+	assert.Contains(t, output, "background-color: darkblue")
+	assert.Contains(t, output, "background-color: #00FF00")
+	assert.Contains(t, output, `<p><span class="Hyperlink-char"><u>run</u></span></p>`)
+	assert.Contains(t, output, `<a href="https://example.com/?a=1&amp;b=2">`)
+	assert.Contains(t, output, `<a href="mailto:team@example.com">`)
+}
+
+func TestSafeHref(t *testing.T) {
+	tests := []struct {
+		target   string
+		expected bool
+	}{
+		{"https://example.com", true},
+		{"HTTP://example.com", true},
+		{"mailto:a@example.com", true},
+		{"#intro", true},
+		{"#", false},
+		{"javascript:alert(1)", false},
+		{" JavaScript:alert(1)", false},
+		{"java\tscript:alert(1)", false},
+		{"vbscript:msgbox", false},
+		{"file:///etc/passwd", false},
+		{"docs/index.html", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			assert.Equal(t, tt.expected, safeHref(tt.target))
+		})
+	}
+}
+
+func TestExport_Lists(t *testing.T) {
+	rd := loadTemplate(t)
+
+	rd.AddParagraph("First").Style("List Bullet")
+	rd.AddParagraph("Second").Style("List Bullet")
+	rd.AddParagraph("Between")
+
+	numID := rd.AddListDefinition(
+		docx.ListLevel{Format: stypes.NumFmtDecimal, Start: 3},
+		docx.ListLevel{Format: stypes.NumFmtLowerRoman},
+	)
+	rd.AddParagraph("Three").Numbering(numID, 0)
+	rd.AddParagraph("Nested").Numbering(numID, 1)
+	rd.AddParagraph("Four").Numbering(numID, 0)
+
+	output := export(t, rd, ExportOptions{})
+
+	assert.Contains(t, output, "<ul>\n"+
+		`<li class="List-Bullet">First</li>`+"\n"+
+		`<li class="List-Bullet">Second</li>`+"\n"+
+		"</ul>\n<p>Between</p>\n")
+	assert.Contains(t, output, "<ol start=\"3\">\n"+
+		"<li>Three\n"+
+		"<ol type=\"i\">\n<li>Nested</li>\n</ol>\n"+
+		"</li>\n<li>Four</li>\n"+
+		"</ol>\n")
+}
+
+func TestExport_Tables(t *testing.T) {
+	rd := loadTemplate(t)
+
+	tbl := rd.AddTable()
+	tbl.Style("TableGrid")
+
+	header := tbl.AddRow()
+	header.AddCell().AddParagraph("Name")
+	header.AddCell().AddParagraph("Notes")
+	isHeader := stypes.OnOffTrue
+	tbl.GetCT().RowContents[0].Row.Property = &ctypes.RowProperty{Header: &ctypes.OnOff{Val: &isHeader}}
+
+	row := tbl.AddRow()
+	row.AddCell().AddParagraph("a")
+	row.AddCell().AddParagraph("b")
+
+	last := tbl.AddRow()
+	last.AddCell().AddParagraph("ignored")
+	last.AddCell().AddParagraph("c")
+
+	wide := tbl.AddRow()
+	wide.AddCell().AddParagraph("both")
+
+	rows := tbl.GetCT().RowContents
+	restart := stypes.MergeCellRestart
+	rows[1].Row.Contents[0].Cell.Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{Val: &restart}
+	rows[2].Row.Contents[0].Cell.Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{}
+	rows[3].Row.Contents[0].Cell.Property.GridSpan = ctypes.NewDecimalNum(2)
+	fill := "D9D9D9"
+	rows[3].Row.Contents[0].Cell.Property.Shading = &ctypes.Shading{Val: stypes.ShdClear, Fill: &fill}
+
+	output := export(t, rd, ExportOptions{})
+
+	// New cells carry a white shading, written as the first generated class
+	assert.Contains(t, output, `<table class="TableGrid">`+"\n"+
+		"<thead>\n<tr>\n<th class=\"fmt-1\">\n<p>Name</p>\n</th>\n<th class=\"fmt-1\">\n<p>Notes</p>\n</th>\n</tr>\n</thead>\n"+
+		"<tbody>\n<tr>\n<td rowspan=\"2\" class=\"fmt-1\">\n<p>a</p>\n</td>\n<td class=\"fmt-1\">\n<p>b</p>\n</td>\n</tr>\n"+
+		"<tr>\n<td class=\"fmt-1\">\n<p>c</p>\n</td>\n</tr>\n"+
+		"<tr>\n<td colspan=\"2\" class=\"fmt-2\">\n<p>both</p>\n</td>\n</tr>\n"+
+		"</tbody>\n</table>\n")
+	assert.Contains(t, output, "\n.TableGrid :where(td, th) { border-top: ")
+	assert.Contains(t, output, "\n.fmt-2 { background-color: #D9D9D9 }\n")
+}
+
+func TestExport_TableSkippedColumns(t *testing.T) {
+	rd := loadTemplate(t)
+	tbl := rd.AddTable()
+	first := tbl.AddRow()
+	for _, text := range []string{"a", "b", "c"} {
+		first.AddCell().AddParagraph(text)
+	}
+
+	// The second row starts at the second grid column, the third merges two cells with hMerge
+	skipped := tbl.AddRow()
+	skipped.GetCT().Property = &ctypes.RowProperty{GridBefore: ctypes.NewDecimalNum(1)}
+	skipped.AddCell().AddParagraph("d")
+	skipped.AddCell().AddParagraph("e")
+
+	merged := tbl.AddRow()
+	restart := stypes.MergeCellRestart
+	merged.AddCell().AddParagraph("f")
+	merged.AddCell().AddEmptyPara()
+	merged.AddCell().AddParagraph("g")
+	cells := merged.GetCT().Contents
+	cells[0].Cell.Property.HMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{Val: &restart}
+	cells[1].Cell.Property.HMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{}
+
+	output := export(t, rd, ExportOptions{})
+	assert.Contains(t, output, "<tr>\n<td></td>\n<td class=\"fmt-1\">\n<p>d</p>\n</td>\n<td class=\"fmt-1\">\n<p>e</p>\n</td>\n</tr>\n")
+	assert.Contains(t, output, "<tr>\n<td colspan=\"2\" class=\"fmt-1\">\n<p>f</p>\n</td>\n<td class=\"fmt-1\">\n<p>g</p>\n</td>\n</tr>\n")
+}
+
+func TestExport_ImagesNotesAndHeaders(t *testing.T) {
+	rd := loadTemplate(t)
+
+	imgPath := filepath.Join(t.TempDir(), "pic.png")
+	require.NoError(t, os.WriteFile(imgPath, []byte("png-bytes"), 0o644))
+
+	p := rd.AddParagraph("Figure ")
+	_, err := p.AddPicture(imgPath, 1, 1)
+	require.NoError(t, err)
+	rd.AddParagraph("See ").AddLink("the docs", "https://example.com/?a=1&b=2")
+
+	notes := `<w:footnotes xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:footnote w:type="separator" w:id="-1"><w:p><w:r><w:separator/></w:r></w:p></w:footnote>` +
+		`<w:footnote w:id="1"><w:p><w:r><w:footnoteRef/></w:r><w:r><w:t xml:space="preserve"> A note.</w:t></w:r></w:p></w:footnote>` +
+		`</w:footnotes>`
+	rd.FileMap.Store("word/footnotes.xml", []byte(notes))
+
+	header := `<w:hdr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:p><w:r><w:t>Page header</w:t></w:r></w:p></w:hdr>`
+	rd.FileMap.Store("word/header1.xml", []byte(header))
+
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships,
+		&docx.Relationship{ID: "rIdNotes", Type: constants.FootnotesType, Target: "footnotes.xml"},
+		&docx.Relationship{ID: "rIdHeader", Type: constants.HeaderType, Target: "header1.xml"},
+	)
+	rd.Document.Body.SectPr = &ctypes.SectionProp{HeaderReference: &ctypes.HeaderReference{Type: stypes.HdrFtrDefault, ID: "rIdHeader"}}
+
+	noted := rd.AddParagraph("Noted")
+	noted.GetCT().Children = append(noted.GetCT().Children, ctypes.ParagraphChild{Run: &ctypes.Run{
+		Children: []ctypes.RunChild{{FootnoteReference: &ctypes.FootnoteReference{ID: 1}}},
+	}})
+
+	output := export(t, rd, ExportOptions{})
+
+	assert.Contains(t, output, "<body>\n<header>\n<p>Page header</p>\n</header>\n")
+	assert.Contains(t, output, `<p>Figure <img src="data:image/png;base64,`+base64.StdEncoding.EncodeToString([]byte("png-bytes"))+`" alt="`)
+	assert.Contains(t, output, `width="96" height="96">`)
+	assert.Contains(t, output, `<a href="https://example.com/?a=1&amp;b=2">`)
+	assert.Contains(t, output, `<p>Noted<sup class="footnote-ref"><a href="#fn1" id="fnref1">1</a></sup></p>`)
+	assert.Contains(t, output, "<section class=\"footnotes\">\n<hr>\n<ol>\n<li id=\"fn1\">\n<p> A note.</p>\n")
+
+	imageDir := filepath.Join(t.TempDir(), "images")
+	output = export(t, rd, ExportOptions{ImageDir: imageDir, ImageLinkPrefix: "images"})
+	assert.Regexp(t, `<img src="images/image\d+\.png"`, output)
+
+	entries, err := os.ReadDir(imageDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
@@ -0,0 +1,1010 @@
+package html
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF decoding for image sizes
+	_ "image/jpeg" // register JPEG decoding for image sizes
+	_ "image/png"  // register PNG decoding for image sizes
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/common/units"
+	"github.com/bfoley13/godocx/docx"
+	"github.com/bfoley13/godocx/internal"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// StyleMap maps HTML elements to the document styles used for them. Empty fields fall back to
+// the values of DefaultStyleMap.
+type StyleMap struct {
+	// Paragraph is the style of body paragraphs; the document default style is used when empty.
+	Paragraph string
+
+	// Headings are the styles of the h1 to h6 elements.
+	Headings [6]string
+
+	// Quote is the style of paragraphs within blockquote elements.
+	Quote string
+
+	// Table is the style of tables.
+	Table string
+}
+
+// DefaultStyleMap returns the style mapping matching the styles of the default template.
+func DefaultStyleMap() StyleMap {
+	return StyleMap{
+		Headings: [6]string{"Heading1", "Heading2", "Heading3", "Heading4", "Heading5", "Heading6"},
+		Quote:    "Quote",
+		Table:    "TableGrid",
+	}
+}
+
+// ImportOptions configures the HTML importer.
+type ImportOptions struct {
+	// Styles maps HTML elements to document styles.
+	Styles StyleMap
+
+	// AllowLocalFiles embeds images whose source is a local file path. Paths are resolved
+	// against BaseDir and must stay within it; absolute paths are rejected. When it is not set,
+	// such images are replaced by their alternative text, so that untrusted HTML cannot read
+	// local files into the document.
+	AllowLocalFiles bool
+
+	// BaseDir is the directory relative image paths are resolved against.
+	BaseDir string
+
+	// MaxImageWidth limits the width of images; larger images are scaled down keeping their
+	// aspect ratio. It defaults to 6 inches.
+	MaxImageWidth units.Inch
+}
+
+// defaultImageDPI is the resolution assumed when converting image pixels to inches.
+const defaultImageDPI = 96
+
+// codeFont is the font of code, pre and similar elements.
+const codeFont = "Courier New"
+
+// imageExtensions maps image content types of data URIs to file extensions.
+var imageExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/jpg":  ".jpg",
+	"image/gif":  ".gif",
+	"image/bmp":  ".bmp",
+	"image/tiff": ".tiff",
+}
+
+// listItem is a list item whose first paragraph carries the list number.
+type listItem struct {
+	numbered bool
+}
+
+// blockContext carries the container, style and list state of the content being imported.
+type blockContext struct {
+	// cell is the table cell receiving paragraphs; paragraphs are added to the body when nil
+	cell *docx.Cell
+
+	style    string
+	paraProp ctypes.ParagraphProp
+
+	// numID and depth locate the enclosing list item; numID is 0 outside of lists
+	numID int
+	depth int
+	item  *listItem
+
+	// pre keeps white space and line breaks as written
+	pre bool
+}
+
+type importer struct {
+	rd   *docx.RootDoc
+	opts ImportOptions
+
+	// para is the paragraph receiving inline content, created on first use
+	para *docx.Paragraph
+
+	// space is set when the open paragraph is empty or its text ends with a space, so that
+	// collapsed white space is not repeated
+	space    bool
+	lastText *ctypes.Text
+
+	// links holds the relationship ID created for each link target
+	links map[string]string
+
+	// baseSize is the default font size in points, used for relative font sizes
+	baseSize float64
+}
+
+// Import parses an HTML fragment and appends its content to the body of the document.
+//
+// Headings, paragraphs, block quotes, lists, tables, links, images and line breaks are
+// converted, together with the bold, italic, underline and strikethrough elements and the
+// color, font size and font family of inline style attributes. Lists are backed by numbering
+// definitions, merged table cells keep their column and row spans, and images may be data
+// URIs or, with AllowLocalFiles, local files.
+//
+// Parameters:
+//   - rd: The document receiving the content.
+//   - r: The reader providing the HTML fragment.
+//   - opts: Options controlling the import.
+//
+// Returns:
+//   - error: An error if the input cannot be parsed or an image cannot be added.
+func Import(rd *docx.RootDoc, r io.Reader, opts ImportOptions) error {
+	return Insert(rd, len(rd.Document.Body.Children), r, opts)
+}
+
+// Insert parses an HTML fragment and inserts its content into the body of the document before
+// the body element at the given index. See Import for the supported HTML.
+//
+// Parameters:
+//   - rd: The document receiving the content.
+//   - index: The index of the body element the content is inserted before; the number of body
+//     elements appends the content.
+//   - r: The reader providing the HTML fragment.
+//   - opts: Options controlling the import.
+//
+// Returns:
+//   - error: An error if the index is out of range, the input cannot be parsed or an image
+//     cannot be added. Content, numbering definitions, relationships and parts added before
+//     the error are removed again, leaving the document unchanged.
+func Insert(rd *docx.RootDoc, index int, r io.Reader, opts ImportOptions) error {
+	body := rd.Document.Body
+	start := len(body.Children)
+	if index < 0 || index > start {
+		return fmt.Errorf("index %d out of range [0, %d]", index, start)
+	}
+
+	defaults := DefaultStyleMap()
+	for i, style := range opts.Styles.Headings {
+		if style == "" {
+			opts.Styles.Headings[i] = defaults.Headings[i]
+		}
+	}
+	if opts.Styles.Quote == "" {
+		opts.Styles.Quote = defaults.Quote
+	}
+	if opts.Styles.Table == "" {
+		opts.Styles.Table = defaults.Table
+	}
+	if opts.MaxImageWidth == 0 {
+		opts.MaxImageWidth = 6
+	}
+
+	root, err := parseHTML(r)
+	if err != nil {
+		return err
+	}
+
+	cp := rd.Checkpoint()
+	im := &importer{
+		rd:       rd,
+		opts:     opts,
+		links:    make(map[string]string),
+		baseSize: 11,
+	}
+	if size := rd.EffectiveRunProp(nil, nil).Size; size != nil {
+		im.baseSize = float64(size.Value) / 2
+	}
+
+	if err := im.content(root.children, blockContext{}, &ctypes.RunProperty{}, ""); err != nil {
+		cp.Restore()
+		return err
+	}
+	im.closeParagraph()
+
+	// The content was appended to the body; move it to the requested position
+	if index < start {
+		children := make([]docx.DocumentChild, 0, len(body.Children))
+		children = append(children, body.Children[:index]...)
+		children = append(children, body.Children[start:]...)
+		children = append(children, body.Children[index:start]...)
+		body.Children = children
+	}
+
+	return nil
+}
+
+func (im *importer) content(nodes []*node, ctx blockContext, rp *ctypes.RunProperty, href string) error {
+	for _, n := range nodes {
+		if err := im.node(n, ctx, rp, href); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (im *importer) node(n *node, ctx blockContext, rp *ctypes.RunProperty, href string) error {
+	if n.tag == "" {
+		im.text(n.text, ctx, rp, href)
+		return nil
+	}
+
+	switch n.tag {
+	case "head", "title", "script", "style", "template":
+		return nil
+
+	case "br":
+		im.lineBreak(ctx)
+		return nil
+
+	case "img":
+		return im.image(n, ctx, rp, href)
+
+	case "hr":
+		im.closeParagraph()
+		im.rule(ctx)
+		return nil
+
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		ctx.style = im.opts.Styles.Headings[n.tag[1]-'1']
+		return im.block(n, ctx, rp)
+
+	case "blockquote":
+		ctx.style = im.opts.Styles.Quote
+		return im.block(n, ctx, rp)
+
+	case "pre":
+		ctx.pre = true
+		return im.block(n, ctx, rp)
+
+	case "p", "div", "section", "article", "header", "footer", "main", "nav", "aside", "address",
+		"figure", "figcaption", "li", "dl", "dt", "dd", "html", "body", "center":
+		if n.tag == "center" {
+			ctx.paraProp.Justification = ctypes.NewGenSingleStrVal(stypes.JustificationCenter)
+		}
+		return im.block(n, ctx, rp)
+
+	case "ul", "ol":
+		im.closeParagraph()
+		err := im.list(n, ctx, rp)
+		im.closeParagraph()
+		return err
+
+	case "table":
+		im.closeParagraph()
+		err := im.table(n, ctx, rp)
+		im.closeParagraph()
+		return err
+
+	case "a":
+		target := strings.TrimSpace(n.attrs["href"])
+		// Fragment links and scripts have no external target
+		if target != "" && !strings.HasPrefix(target, "#") && !strings.HasPrefix(strings.ToLower(target), "javascript:") {
+			href = target
+		}
+		return im.content(n.children, ctx, im.runFormat(n, rp), href)
+	}
+
+	return im.content(n.children, ctx, im.runFormat(n, rp), href)
+}
+
+// block imports a block element, whose content starts and ends a paragraph.
+func (im *importer) block(n *node, ctx blockContext, rp *ctypes.RunProperty) error {
+	im.closeParagraph()
+	err := im.content(n.children, im.blockFormat(n, ctx), im.runFormat(n, rp), "")
+	im.closeParagraph()
+	return err
+}
+
+// paragraph returns the open paragraph, adding a paragraph formatted for the context when no
+// paragraph is open.
+func (im *importer) paragraph(ctx blockContext) *docx.Paragraph {
+	if im.para != nil {
+		return im.para
+	}
+
+	var p *docx.Paragraph
+	if ctx.cell != nil {
+		p = ctx.cell.AddEmptyPara()
+	} else {
+		p = im.rd.AddEmptyParagraph()
+	}
+
+	switch {
+	case ctx.style != "":
+		p.Style(ctx.style)
+	case im.opts.Styles.Paragraph != "":
+		p.Style(im.opts.Styles.Paragraph)
+	}
+
+	ct := p.GetCT()
+	if ct.Property == nil {
+		ct.Property = ctypes.DefaultParaProperty()
+	}
+	if ctx.paraProp.Justification != nil {
+		ct.Property.Justification = ctx.paraProp.Justification
+	}
+	if ctx.paraProp.Spacing != nil {
+		ct.Property.Spacing = ctx.paraProp.Spacing
+	}
+	if ctx.paraProp.Indent != nil {
+		ct.Property.Indent = ctx.paraProp.Indent
+	}
+
+	if ctx.item != nil {
+		// The first paragraph of a list item carries the number, later ones are indented to match
+		if !ctx.item.numbered {
+			p.Numbering(ctx.numID, min(ctx.depth, 8))
+			ctx.item.numbered = true
+		} else {
+			p.Indent(&ctypes.Indent{Left: internal.ToPtr(docx.ListIndentStep * (ctx.depth + 1))})
+		}
+	}
+
+	im.para = p
+	im.space = true
+	im.lastText = nil
+
+	return p
+}
+
+// closeParagraph ends the open paragraph, dropping trailing collapsed white space.
+func (im *importer) closeParagraph() {
+	if im.para != nil && im.lastText != nil && im.space {
+		*im.lastText = *ctypes.TextFromString(strings.TrimRight(im.lastText.Text, " "))
+	}
+
+	im.para = nil
+	im.lastText = nil
+}
+
+func (im *importer) text(text string, ctx blockContext, rp *ctypes.RunProperty, href string) {
+	if ctx.pre {
+		// A newline directly after the pre start tag is not part of the content
+		if im.para == nil {
+			text = strings.TrimPrefix(text, "\n")
+		}
+		for i, line := range strings.Split(text, "\n") {
+			if i > 0 {
+				im.lineBreak(ctx)
+			}
+			if line != "" {
+				im.addRun(im.paragraph(ctx), rp, href, ctypes.RunChild{Text: ctypes.TextFromString(line)})
+				im.space = false
+			}
+		}
+		return
+	}
+
+	collapsed := strings.Join(strings.FieldsFunc(text, isHTMLSpace), " ")
+	if collapsed == "" {
+		collapsed = " "
+	} else {
+		collapsed = leadingSpace(text) + collapsed + trailingSpace(text)
+	}
+	text = collapsed
+	if im.para == nil || im.space {
+		text = strings.TrimLeft(text, " ")
+	}
+	if text == "" {
+		return
+	}
+
+	p := im.paragraph(ctx)
+	t := ctypes.TextFromString(text)
+	im.addRun(p, rp, href, ctypes.RunChild{Text: t})
+	im.lastText = t
+	im.space = strings.HasSuffix(text, " ")
+}
+
+func (im *importer) lineBreak(ctx blockContext) {
+	p := im.paragraph(ctx)
+	p.GetCT().Children = append(p.GetCT().Children, ctypes.ParagraphChild{Run: &ctypes.Run{
+		Children: []ctypes.RunChild{{Break: &ctypes.Break{}}},
+	}})
+	im.space = true
+	im.lastText = nil
+}
+
+// addRun adds a run with the given formatting and content to a paragraph. Runs within links
+// are wrapped in hyperlinks; the pieces of a link share one relationship.
+func (im *importer) addRun(p *docx.Paragraph, rp *ctypes.RunProperty, href string, child ctypes.RunChild) {
+	run := &ctypes.Run{Children: []ctypes.RunChild{child}}
+	if !reflect.ValueOf(*rp).IsZero() {
+		props := *rp
+		run.Property = &props
+	}
+
+	ct := p.GetCT()
+	if href == "" {
+		ct.Children = append(ct.Children, ctypes.ParagraphChild{Run: run})
+		return
+	}
+
+	if run.Property == nil {
+		run.Property = &ctypes.RunProperty{}
+	}
+	run.Property.Style = ctypes.NewCTString(constants.HyperLinkStyle)
+
+	if id, ok := im.links[href]; ok {
+		ct.Children = append(ct.Children, ctypes.ParagraphChild{Link: &ctypes.Hyperlink{ID: id, Run: run}})
+		return
+	}
+
+	p.AddLink("", href)
+	link := ct.Children[len(ct.Children)-1].Link
+	link.Run = run
+	im.links[href] = link.ID
+}
+
+// rule adds an empty paragraph with a bottom border for a horizontal rule.
+func (im *importer) rule(ctx blockContext) {
+	ctx.item = nil
+	p := im.paragraph(ctx)
+	p.GetCT().Property.Border = &ctypes.ParaBorder{
+		Bottom: &ctypes.Border{Val: stypes.BorderStyleSingle, Size: internal.ToPtr(6), Space: internal.ToPtr("1"), Color: internal.ToPtr("auto")},
+	}
+	im.closeParagraph()
+}
+
+// runFormat returns the run properties of the content of an element, derived from the
+// element and its style attribute.
+func (im *importer) runFormat(n *node, rp *ctypes.RunProperty) *ctypes.RunProperty {
+	props := *rp
+
+	switch n.tag {
+	case "strong", "b":
+		props.Bold = ctypes.OnOffFromBool(true)
+	case "em", "i", "cite", "var", "dfn":
+		props.Italic = ctypes.OnOffFromBool(true)
+	case "u", "ins":
+		props.Underline = ctypes.NewGenSingleStrVal(stypes.UnderlineSingle)
+	case "s", "strike", "del":
+		props.Strike = ctypes.OnOffFromBool(true)
+	case "sup":
+		props.VertAlign = ctypes.NewGenSingleStrVal(stypes.VerticalAlignRunSuperscript)
+	case "sub":
+		props.VertAlign = ctypes.NewGenSingleStrVal(stypes.VerticalAlignRunSubscript)
+	case "code", "kbd", "samp", "tt", "pre":
+		props.Fonts = &ctypes.RunFonts{Ascii: codeFont, HAnsi: codeFont}
+	case "mark":
+		props.Highlight = ctypes.NewCTString("yellow")
+	case "th":
+		props.Bold = ctypes.OnOffFromBool(true)
+	}
+
+	style := parseStyle(n.attrs["style"])
+
+	if value, ok := style["color"]; ok {
+		if color, ok := parseColor(value); ok {
+			props.Color = ctypes.NewColor(color)
+		}
+	}
+
+	if value, ok := style["background-color"]; ok {
+		if color, ok := parseColor(value); ok {
+			props.Shading = &ctypes.Shading{Val: stypes.ShdClear, Color: internal.ToPtr("auto"), Fill: internal.ToPtr(color)}
+		}
+	}
+
+	if value, ok := style["font-size"]; ok {
+		current := im.baseSize
+		if props.Size != nil {
+			current = float64(props.Size.Value) / 2
+		}
+		if pt, ok := parseFontSize(value, current); ok && pt > 0 {
+			props.Size = ctypes.NewFontSize(uint64(pt*2 + 0.5))
+		}
+	}
+
+	if value, ok := style["font-family"]; ok {
+		if family := firstFontFamily(value); family != "" {
+			props.Fonts = &ctypes.RunFonts{Ascii: family, HAnsi: family}
+		}
+	}
+
+	if value, ok := style["font-weight"]; ok {
+		switch weight, err := strconv.Atoi(value); {
+		case value == "bold" || value == "bolder" || (err == nil && weight >= 600):
+			props.Bold = ctypes.OnOffFromBool(true)
+		case value == "normal" || value == "lighter" || err == nil:
+			props.Bold = nil
+		}
+	}
+
+	if value, ok := style["font-style"]; ok {
+		switch value {
+		case "italic", "oblique":
+			props.Italic = ctypes.OnOffFromBool(true)
+		case "normal":
+			props.Italic = nil
+		}
+	}
+
+	decoration, ok := style["text-decoration-line"]
+	if !ok {
+		decoration, ok = style["text-decoration"]
+	}
+	if ok {
+		if decoration == "none" {
+			props.Underline = nil
+			props.Strike = nil
+		}
+		for _, value := range strings.Fields(decoration) {
+			switch value {
+			case "underline":
+				props.Underline = ctypes.NewGenSingleStrVal(stypes.UnderlineSingle)
+			case "line-through":
+				props.Strike = ctypes.OnOffFromBool(true)
+			}
+		}
+	}
+
+	switch style["vertical-align"] {
+	case "super":
+		props.VertAlign = ctypes.NewGenSingleStrVal(stypes.VerticalAlignRunSuperscript)
+	case "sub":
+		props.VertAlign = ctypes.NewGenSingleStrVal(stypes.VerticalAlignRunSubscript)
+	}
+
+	return &props
+}
+
+// blockFormat returns the context of the content of a block element, with the paragraph
+// properties of its style and align attributes applied.
+func (im *importer) blockFormat(n *node, ctx blockContext) blockContext {
+	style := parseStyle(n.attrs["style"])
+
+	align, ok := style["text-align"]
+	if !ok {
+		align = n.attrs["align"]
+	}
+	switch strings.ToLower(align) {
+	case "left", "start":
+		ctx.paraProp.Justification = ctypes.NewGenSingleStrVal(stypes.JustificationLeft)
+	case "center":
+		ctx.paraProp.Justification = ctypes.NewGenSingleStrVal(stypes.JustificationCenter)
+	case "right", "end":
+		ctx.paraProp.Justification = ctypes.NewGenSingleStrVal(stypes.JustificationRight)
+	case "justify":
+		ctx.paraProp.Justification = ctypes.NewGenSingleStrVal(stypes.JustificationBoth)
+	}
+
+	before, hasBefore := parseLength(style["margin-top"])
+	after, hasAfter := parseLength(style["margin-bottom"])
+	if hasBefore || hasAfter {
+		spacing := &ctypes.Spacing{}
+		if ctx.paraProp.Spacing != nil {
+			*spacing = *ctx.paraProp.Spacing
+		}
+		if hasBefore && before >= 0 {
+			spacing.Before = internal.ToPtr(uint64(before))
+		}
+		if hasAfter && after >= 0 {
+			spacing.After = internal.ToPtr(uint64(after))
+		}
+		ctx.paraProp.Spacing = spacing
+	}
+
+	left, hasLeft := parseLength(style["margin-left"])
+	indent, hasIndent := parseLength(style["text-indent"])
+	if hasLeft || hasIndent {
+		ind := &ctypes.Indent{}
+		if ctx.paraProp.Indent != nil {
+			*ind = *ctx.paraProp.Indent
+		}
+		if hasLeft {
+			ind.Left = internal.ToPtr(left)
+		}
+		if hasIndent {
+			ind.FirstLine, ind.Hanging = nil, nil
+			if indent >= 0 {
+				ind.FirstLine = internal.ToPtr(uint64(indent))
+			} else {
+				ind.Hanging = internal.ToPtr(uint64(-indent))
+			}
+		}
+		ctx.paraProp.Indent = ind
+	}
+
+	return ctx
+}
+
+// list imports a top-level or nested list. Top-level lists get their own numbering definition
+// whose levels follow the list types found at each nesting depth.
+func (im *importer) list(n *node, ctx blockContext, rp *ctypes.RunProperty) error {
+	if ctx.numID == 0 {
+		var levels []docx.ListLevel
+		collectListLevels(n, 0, &levels)
+		ctx.numID = im.rd.AddListDefinition(levels...)
+		ctx.depth = 0
+	} else {
+		ctx.depth++
+	}
+
+	for _, child := range n.children {
+		if child.tag == "" {
+			if strings.TrimFunc(child.text, isHTMLSpace) == "" {
+				continue
+			}
+		}
+
+		if child.tag == "ul" || child.tag == "ol" {
+			// Lists nested directly in a list belong to the previous item
+			if err := im.list(child, ctx, rp); err != nil {
+				return err
+			}
+			continue
+		}
+
+		item := &listItem{}
+		itemCtx := ctx
+		itemCtx.item = item
+
+		var err error
+		if child.tag == "li" {
+			err = im.block(child, itemCtx, rp)
+		} else {
+			err = im.node(child, itemCtx, rp, "")
+			im.closeParagraph()
+		}
+		if err != nil {
+			return err
+		}
+
+		// Items without content still need their list number
+		if !item.numbered {
+			im.paragraph(itemCtx)
+			im.closeParagraph()
+		}
+	}
+
+	return nil
+}
+
+// collectListLevels records the list type found first at each nesting depth.
+func collectListLevels(n *node, depth int, levels *[]docx.ListLevel) {
+	if depth >= 9 {
+		return
+	}
+
+	if len(*levels) <= depth {
+		level := docx.ListLevel{Format: stypes.NumFmtBullet}
+		if n.tag == "ol" {
+			level.Format = stypes.NumFmtDecimal
+			switch n.attrs["type"] {
+			case "a":
+				level.Format = stypes.NumFmtLowerLetter
+			case "A":
+				level.Format = stypes.NumFmtUpperLetter
+			case "i":
+				level.Format = stypes.NumFmtLowerRoman
+			case "I":
+				level.Format = stypes.NumFmtUpperRoman
+			}
+			if start, err := strconv.Atoi(n.attrs["start"]); err == nil && start > 0 {
+				level.Start = start
+			}
+		}
+		*levels = append(*levels, level)
+	}
+
+	var walk func(children []*node)
+	walk = func(children []*node) {
+		for _, child := range children {
+			switch child.tag {
+			case "ul", "ol":
+				collectListLevels(child, depth+1, levels)
+			case "table":
+			default:
+				walk(child.children)
+			}
+		}
+	}
+	walk(n.children)
+}
+
+// tableRow is a table row element and whether it belongs to the table head.
+type tableRow struct {
+	node   *node
+	header bool
+}
+
+// tableRows returns the rows of a table element in document order.
+func tableRows(n *node) []tableRow {
+	var rows []tableRow
+	for _, child := range n.children {
+		switch child.tag {
+		case "tr":
+			rows = append(rows, tableRow{node: child})
+		case "thead", "tbody", "tfoot":
+			for _, tr := range child.children {
+				if tr.tag == "tr" {
+					rows = append(rows, tableRow{node: tr, header: child.tag == "thead"})
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// span tracks a cell spanning several rows while the rows below it are imported.
+type span struct {
+	rows, cols int
+}
+
+func (im *importer) table(n *node, ctx blockContext, rp *ctypes.RunProperty) error {
+	rows := tableRows(n)
+
+	// Tables cannot be added to cells yet; the content of nested tables is kept as paragraphs
+	if ctx.cell != nil {
+		for _, row := range rows {
+			for _, cell := range row.node.children {
+				if cell.tag == "td" || cell.tag == "th" {
+					if err := im.block(cell, ctx, rp); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	tbl := im.rd.AddTable()
+	tbl.Style(im.opts.Styles.Table)
+	tbl.Width(5000, stypes.TableWidthPct)
+
+	var pending []span
+	for _, tr := range rows {
+		row := tbl.AddRow()
+		rowCT := tbl.GetCT().RowContents[len(tbl.GetCT().RowContents)-1].Row
+		if tr.header {
+			rowCT.Property = &ctypes.RowProperty{Header: ctypes.OnOffFromBool(true)}
+		}
+
+		var cells []*node
+		for _, child := range tr.node.children {
+			if child.tag == "td" || child.tag == "th" {
+				cells = append(cells, child)
+			}
+		}
+
+		col := 0
+		for next := 0; ; {
+			if col < len(pending) && pending[col].rows > 0 {
+				// The position is covered by a cell spanning from a row above
+				cell := row.AddCell()
+				cellCT := rowCT.Contents[len(rowCT.Contents)-1].Cell
+				cellCT.Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{Val: internal.ToPtr(stypes.MergeCellContinue)}
+				if pending[col].cols > 1 {
+					cellCT.Property.GridSpan = ctypes.NewDecimalNum(pending[col].cols)
+				}
+				cell.AddEmptyPara()
+
+				pending[col].rows--
+				col += pending[col].cols
+				continue
+			}
+
+			if next >= len(cells) {
+				if !spansAfter(pending, col) {
+					break
+				}
+				col++
+				continue
+			}
+
+			cellNode := cells[next]
+			next++
+
+			colSpan, rowSpan := 1, 1
+			if v, err := strconv.Atoi(cellNode.attrs["colspan"]); err == nil && v > 1 {
+				colSpan = v
+			}
+			if v, err := strconv.Atoi(cellNode.attrs["rowspan"]); err == nil && v > 1 {
+				rowSpan = v
+			}
+
+			cell := row.AddCell()
+			cellCT := rowCT.Contents[len(rowCT.Contents)-1].Cell
+			if colSpan > 1 {
+				cellCT.Property.GridSpan = ctypes.NewDecimalNum(colSpan)
+			}
+			if rowSpan > 1 {
+				cellCT.Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{Val: internal.ToPtr(stypes.MergeCellRestart)}
+			}
+
+			for len(pending) < col+colSpan {
+				pending = append(pending, span{})
+			}
+			pending[col] = span{rows: rowSpan - 1, cols: colSpan}
+
+			style := parseStyle(cellNode.attrs["style"])
+			if color, ok := parseColor(style["background-color"]); ok {
+				cell.BackgroundColor(color)
+			}
+			valign, ok := style["vertical-align"]
+			if !ok {
+				valign = cellNode.attrs["valign"]
+			}
+			cell.VerticalAlign(strings.ToLower(valign))
+
+			if err := im.block(cellNode, blockContext{cell: cell}, im.runFormat(cellNode, rp)); err != nil {
+				return err
+			}
+
+			// A cell must end with a paragraph
+			if len(cellCT.Contents) == 0 || cellCT.Contents[len(cellCT.Contents)-1].Paragraph == nil {
+				cell.AddEmptyPara()
+			}
+
+			col += colSpan
+		}
+	}
+
+	return nil
+}
+
+// spansAfter reports whether a cell spanning from a row above covers a column at or after col.
+func spansAfter(pending []span, col int) bool {
+	for i := col; i < len(pending); i++ {
+		if pending[i].rows > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// image adds an img element as a picture. Data URIs and local files are embedded; remote
+// images are added as links labelled with their alternative text.
+func (im *importer) image(n *node, ctx blockContext, rp *ctypes.RunProperty, href string) error {
+	src := strings.TrimSpace(n.attrs["src"])
+	alt := n.attrs["alt"]
+
+	var (
+		data []byte
+		ext  string
+		err  error
+	)
+	switch {
+	case src == "":
+		return nil
+	case strings.HasPrefix(src, "data:"):
+		if data, ext, err = decodeDataURI(src); err != nil {
+			return err
+		}
+	case strings.Contains(src, "://"):
+		label := alt
+		if label == "" {
+			label = src
+		}
+		im.text(label, ctx, rp, src)
+		return nil
+	case !im.opts.AllowLocalFiles:
+		if alt != "" {
+			im.text(alt, ctx, rp, href)
+		}
+		return nil
+	default:
+		path, err := internal.LocalPath(im.opts.BaseDir, src)
+		if err != nil {
+			return err
+		}
+		if data, err = os.ReadFile(path); err != nil {
+			return err
+		}
+		ext = filepath.Ext(path)
+	}
+
+	width, height := im.imageSize(n, data)
+
+	p := im.paragraph(ctx)
+	pic, err := p.AddPictureBytes(data, ext, width, height)
+	if err != nil {
+		return err
+	}
+	pic.Inline.DocProp.Description = alt
+
+	im.space = false
+	im.lastText = nil
+
+	return nil
+}
+
+// imageSize returns the size of an image in inches. The size given by the width and height
+// attributes or style properties is used, completed from the aspect ratio of the image when
+// only one is given; otherwise the pixel size of the image is used at the default resolution.
+func (im *importer) imageSize(n *node, data []byte) (units.Inch, units.Inch) {
+	width, height := units.Inch(4), units.Inch(3)
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil && cfg.Width > 0 && cfg.Height > 0 {
+		width = units.Inch(cfg.Width) / defaultImageDPI
+		height = units.Inch(cfg.Height) / defaultImageDPI
+	}
+
+	style := parseStyle(n.attrs["style"])
+	size := func(name string) (units.Inch, bool) {
+		value, ok := style[name]
+		if !ok {
+			value = n.attrs[name]
+		}
+		twips, ok := parseLength(value)
+		if !ok || twips <= 0 {
+			return 0, false
+		}
+		return units.Inch(twips) / 1440, true
+	}
+
+	w, hasWidth := size("width")
+	h, hasHeight := size("height")
+	switch {
+	case hasWidth && hasHeight:
+		width, height = w, h
+	case hasWidth:
+		width, height = w, height*w/width
+	case hasHeight:
+		width, height = width*h/height, h
+	}
+
+	if width > im.opts.MaxImageWidth {
+		height = height * im.opts.MaxImageWidth / width
+		width = im.opts.MaxImageWidth
+	}
+
+	return width, height
+}
+
+// decodeDataURI returns the content of an image data URI and the file extension of its type.
+func decodeDataURI(uri string) ([]byte, string, error) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok {
+		return nil, "", fmt.Errorf("invalid data URI")
+	}
+
+	params := strings.Split(header, ";")
+	ext, ok := imageExtensions[strings.ToLower(strings.TrimSpace(params[0]))]
+	if !ok {
+		return nil, "", fmt.Errorf("unsupported image type %q in data URI", params[0])
+	}
+
+	base64Encoded := false
+	for _, param := range params[1:] {
+		if strings.EqualFold(strings.TrimSpace(param), "base64") {
+			base64Encoded = true
+		}
+	}
+
+	if !base64Encoded {
+		data, err := url.PathUnescape(payload)
+		return []byte(data), ext, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(payload), ""))
+	return data, ext, err
+}
+
+// isHTMLSpace reports whether r is ASCII white space, which HTML collapses.
+func isHTMLSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f'
+}
+
+func leadingSpace(s string) string {
+	if s != "" && isHTMLSpace(rune(s[0])) {
+		return " "
+	}
+	return ""
+}
+
+func trailingSpace(s string) string {
+	if s != "" && isHTMLSpace(rune(s[len(s)-1])) {
+		return " "
+	}
+	return ""
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
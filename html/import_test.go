@@ -0,0 +1,331 @@
+package html
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/docx"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func importHTML(t *testing.T, rd *docx.RootDoc, input string) []docx.DocumentChild {
+	t.Helper()
+
+	require.NoError(t, Import(rd, strings.NewReader(input), ImportOptions{}))
+	return rd.Document.Body.Children
+}
+
+// paraText returns the text of a paragraph, including the text of its links.
+func paraText(p *docx.Paragraph) string {
+	var sb strings.Builder
+	for _, child := range p.GetCT().Children {
+		run := child.Run
+		if child.Link != nil {
+			run = child.Link.Run
+		}
+		if run == nil {
+			continue
+		}
+		for _, rc := range run.Children {
+			if rc.Text != nil {
+				sb.WriteString(rc.Text.Text)
+			}
+		}
+	}
+	return sb.String()
+}
+
+func TestParseHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"ImplicitParagraphEnd", "<p>one<p>two", "p(one)p(two)"},
+		{"ImplicitItemEnd", "<ul><li>a<li>b</ul>", "ul(li(a)li(b))"},
+		{"VoidElements", "a<br>b<img src=x>c", "abr()bimg()c"},
+		{"Entities", "<p>&lt;&amp;&nbsp;&eacute;</p>", "p(<& é)"},
+		{"StrayEndTag", "<b>a</i>b</b>", "b(ab)"},
+		{"TableCells", "<table><tr><td>1<td>2<tr><td>3</table>", "table(tr(td(1)td(2))tr(td(3)))"},
+	}
+
+	var render func(n *node) string
+	render = func(n *node) string {
+		if n.tag == "" {
+			return n.text
+		}
+		var sb strings.Builder
+		for _, child := range n.children {
+			sb.WriteString(render(child))
+		}
+		return n.tag + "(" + sb.String() + ")"
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := parseHTML(strings.NewReader(tt.input))
+			require.NoError(t, err)
+
+			var sb strings.Builder
+			for _, child := range root.children {
+				sb.WriteString(render(child))
+			}
+			assert.Equal(t, tt.expected, sb.String())
+		})
+	}
+}
+
+func TestParseCSSValues(t *testing.T) {
+	color, ok := parseColor("rgb(255, 128, 0)")
+	assert.True(t, ok)
+	assert.Equal(t, "FF8000", color)
+
+	color, ok = parseColor("#abc")
+	assert.True(t, ok)
+	assert.Equal(t, "AABBCC", color)
+
+	_, ok = parseColor("inherit")
+	assert.False(t, ok)
+
+	size, ok := parseFontSize("150%", 12)
+	assert.True(t, ok)
+	assert.Equal(t, 18.0, size)
+
+	twips, ok := parseLength("1in")
+	assert.True(t, ok)
+	assert.Equal(t, 1440, twips)
+}
+
+func TestImport_HeadingsAndInline(t *testing.T) {
+	rd := loadTemplate(t)
+
+	children := importHTML(t, rd, `
+		<h1>Title</h1>
+		<p style="text-align: center">Plain <strong>bold</strong> and
+		   <em>italic <u>under</u></em> <s>gone</s><br>
+		   <span style="color: red; font-size: 14pt; font-family: 'Georgia', serif">styled</span></p>
+		<blockquote>Quoted</blockquote>`)
+	require.Len(t, children, 3)
+
+	heading := children[0].Para
+	assert.Equal(t, "Heading1", heading.GetCT().Property.Style.Val)
+	assert.Equal(t, "Title", paraText(heading))
+
+	p := children[1].Para
+	assert.Equal(t, stypes.JustificationCenter, p.GetCT().Property.Justification.Val)
+	assert.Equal(t, "Plain bold and italic under gonestyled", paraText(p))
+
+	runs := p.GetCT().Children
+	assert.Nil(t, runs[0].Run.Property)
+	assert.True(t, runs[1].Run.Property.Bold.Bool())
+	assert.True(t, runs[3].Run.Property.Italic.Bool())
+	assert.True(t, runs[4].Run.Property.Italic.Bool())
+	assert.Equal(t, stypes.UnderlineSingle, runs[4].Run.Property.Underline.Val)
+	assert.True(t, runs[6].Run.Property.Strike.Bool())
+	assert.NotNil(t, runs[7].Run.Children[0].Break)
+
+	styled := runs[8].Run.Property
+	assert.Equal(t, "FF0000", styled.Color.Val)
+	assert.Equal(t, uint64(28), styled.Size.Value)
+	assert.Equal(t, "Georgia", styled.Fonts.Ascii)
+
+	assert.Equal(t, "Quote", children[2].Para.GetCT().Property.Style.Val)
+}
+
+func TestImport_Lists(t *testing.T) {
+	rd := loadTemplate(t)
+
+	children := importHTML(t, rd, `
+		<ol type="a" start="2">
+			<li>One
+				<ul><li>Nested</li></ul>
+				After
+			</li>
+			<li>Two</li>
+		</ol>`)
+	require.Len(t, children, 4)
+
+	numPr := children[0].Para.GetCT().Property.NumProp
+	require.NotNil(t, numPr)
+	assert.Equal(t, 0, numPr.ILvl.Val)
+	numID := numPr.NumID.Val
+
+	nested := children[1].Para.GetCT().Property.NumProp
+	assert.Equal(t, numID, nested.NumID.Val)
+	assert.Equal(t, 1, nested.ILvl.Val)
+	assert.Equal(t, "Nested", paraText(children[1].Para))
+
+	// Content after a nested list continues the item without a number
+	after := children[2].Para.GetCT().Property
+	assert.Nil(t, after.NumProp)
+	assert.Equal(t, docx.ListIndentStep, *after.Indent.Left)
+	assert.Equal(t, "After", paraText(children[2].Para))
+
+	assert.Equal(t, 0, children[3].Para.GetCT().Property.NumProp.ILvl.Val)
+
+	first := rd.Numbering.Level(numID, 0)
+	require.NotNil(t, first)
+	assert.Equal(t, stypes.NumFmtLowerLetter, first.NumFmt.Val)
+	assert.Equal(t, 2, first.Start.Val)
+	assert.Equal(t, stypes.NumFmtBullet, rd.Numbering.Level(numID, 1).NumFmt.Val)
+}
+
+func TestImport_Tables(t *testing.T) {
+	rd := loadTemplate(t)
+
+	children := importHTML(t, rd, `
+		<table>
+			<thead><tr><th colspan="2">Head</th></tr></thead>
+			<tr><td rowspan="2" style="background-color: #eeeeee">Tall</td><td>b</td></tr>
+			<tr><td>c</td></tr>
+		</table>`)
+	require.Len(t, children, 1)
+
+	tbl := children[0].Table.GetCT()
+	assert.Equal(t, "TableGrid", tbl.TableProp.Style.Val)
+	require.Len(t, tbl.RowContents, 3)
+
+	header := tbl.RowContents[0].Row
+	assert.True(t, header.Property.Header.Bool())
+	require.Len(t, header.Contents, 1)
+	assert.Equal(t, 2, header.Contents[0].Cell.Property.GridSpan.Val)
+
+	tall := tbl.RowContents[1].Row.Contents[0].Cell
+	assert.Equal(t, stypes.MergeCellRestart, *tall.Property.VMerge.Val)
+	assert.Equal(t, "EEEEEE", *tall.Property.Shading.Fill)
+
+	last := tbl.RowContents[2].Row
+	require.Len(t, last.Contents, 2)
+	assert.Equal(t, stypes.MergeCellContinue, *last.Contents[0].Cell.Property.VMerge.Val)
+	assert.NotEmpty(t, last.Contents[0].Cell.Contents)
+
+	text := last.Contents[1].Cell.Contents[0].Paragraph
+	require.NotNil(t, text)
+	assert.Equal(t, "c", text.Children[0].Run.Children[0].Text.Text)
+}
+
+func TestImport_LinksAndImages(t *testing.T) {
+	rd := loadTemplate(t)
+
+	var img bytes.Buffer
+	require.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 192, 96))))
+	src := "data:image/png;base64," + base64.StdEncoding.EncodeToString(img.Bytes())
+
+	children := importHTML(t, rd, `<p><a href="https://example.com">see <b>here</b></a> <img src="`+src+`" alt="A chart"></p>`)
+	require.Len(t, children, 1)
+
+	ct := children[0].Para.GetCT()
+	require.NotNil(t, ct.Children[0].Link)
+	require.NotNil(t, ct.Children[1].Link)
+	assert.Equal(t, ct.Children[0].Link.ID, ct.Children[1].Link.ID)
+	assert.True(t, ct.Children[1].Link.Run.Property.Bold.Bool())
+	assert.Equal(t, "https://example.com", rd.Document.GetRelationByID(ct.Children[0].Link.ID).Target)
+
+	drawing := ct.Children[len(ct.Children)-1].Run.Children[0].Drawing
+	require.NotNil(t, drawing)
+	inline := drawing.Inline[0]
+	assert.Equal(t, "A chart", inline.DocProp.Description)
+	assert.Equal(t, uint64(2*914400), inline.Extent.Width)
+	assert.Equal(t, uint64(914400), inline.Extent.Height)
+}
+
+func TestInsert(t *testing.T) {
+	rd := loadTemplate(t)
+	rd.AddParagraph("first")
+	rd.AddParagraph("last")
+
+	require.NoError(t, Insert(rd, 1, strings.NewReader("<p>a</p><p>b</p>"), ImportOptions{}))
+
+	var texts []string
+	for _, child := range rd.Document.Body.Children {
+		texts = append(texts, paraText(child.Para))
+	}
+	assert.Equal(t, []string{"first", "a", "b", "last"}, texts)
+
+	assert.Error(t, Insert(rd, 10, strings.NewReader("<p>x</p>"), ImportOptions{}))
+
+	// Content imported before an error is removed together with its parts and definitions
+	files := func() []string {
+		var names []string
+		rd.FileMap.Range(func(key, _ any) bool {
+			names = append(names, key.(string))
+			return true
+		})
+		sort.Strings(names)
+		return names
+	}
+	before := files()
+	rels := len(rd.Document.DocRels.Relationships)
+	contentTypes := rd.ContentType
+	numbering := rd.Numbering
+	var nums int
+	if numbering != nil {
+		nums = len(numbering.Nums)
+	}
+
+	var img bytes.Buffer
+	require.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 8, 8))))
+	input := `<ol><li>x</li></ol><p><a href="https://example.com">link</a>` +
+		`<img src="data:image/png;base64,` + base64.StdEncoding.EncodeToString(img.Bytes()) + `"></p>` +
+		`<p><img src="data:image/png;base64,!"></p>`
+	assert.Error(t, Insert(rd, 1, strings.NewReader(input), ImportOptions{}))
+
+	texts = nil
+	for _, child := range rd.Document.Body.Children {
+		texts = append(texts, paraText(child.Para))
+	}
+	assert.Equal(t, []string{"first", "a", "b", "last"}, texts)
+	assert.Equal(t, before, files())
+	assert.Len(t, rd.Document.DocRels.Relationships, rels)
+	assert.Equal(t, contentTypes, rd.ContentType)
+	assert.Same(t, numbering, rd.Numbering)
+	if numbering != nil {
+		assert.Len(t, numbering.Nums, nums)
+	}
+}
+
+func TestImport_LocalImages(t *testing.T) {
+	var img bytes.Buffer
+	require.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 96, 96))))
+
+	root := t.TempDir()
+	baseDir := filepath.Join(root, "site")
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "images"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "images", "logo.png"), img.Bytes(), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret.png"), img.Bytes(), 0o644))
+	secret := filepath.ToSlash(filepath.Join(root, "secret.png"))
+
+	// Local files are not read unless allowed
+	rd := loadTemplate(t)
+	children := importHTML(t, rd, `<p><img src="images/logo.png" alt="Logo"><img src="`+secret+`"></p>`)
+	require.Len(t, children, 1)
+	assert.Equal(t, "Logo", paraText(children[0].Para))
+	for _, rel := range rd.Document.DocRels.Relationships {
+		assert.NotEqual(t, constants.SourceRelationshipImage, rel.Type)
+	}
+
+	opts := ImportOptions{AllowLocalFiles: true, BaseDir: baseDir}
+	rd = loadTemplate(t)
+	require.NoError(t, Import(rd, strings.NewReader(`<p><img src="images/logo.png" alt="Logo"></p>`), opts))
+	children = rd.Document.Body.Children
+	require.Len(t, children, 1)
+	require.NotNil(t, children[0].Para.GetCT().Children[0].Run.Children[0].Drawing)
+
+	for _, src := range []string{secret, "../secret.png", "images/../../secret.png", "images/missing.png"} {
+		t.Run(src, func(t *testing.T) {
+			rd := loadTemplate(t)
+			assert.Error(t, Import(rd, strings.NewReader(`<p>before</p><img src="`+src+`">`), opts))
+			assert.Empty(t, rd.Document.Body.Children)
+		})
+	}
+}
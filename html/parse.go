@@ -0,0 +1,268 @@
+package html
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// node is an element or text node of a parsed HTML fragment.
+type node struct {
+	// tag is the lower-case element name; it is empty for text nodes
+	tag      string
+	attrs    map[string]string
+	text     string
+	children []*node
+}
+
+// voidElements never have content or an end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// implicitEnds lists, for an element, the open elements its start tag closes, as an HTML
+// parser would for omitted end tags.
+var implicitEnds = map[string][]string{
+	"p":          {"p"},
+	"li":         {"li", "p"},
+	"td":         {"td", "th", "p"},
+	"th":         {"td", "th", "p"},
+	"tr":         {"tr", "td", "th", "p"},
+	"thead":      {"thead", "tbody", "tfoot", "tr", "td", "th", "p"},
+	"tbody":      {"thead", "tbody", "tfoot", "tr", "td", "th", "p"},
+	"tfoot":      {"thead", "tbody", "tfoot", "tr", "td", "th", "p"},
+	"h1":         {"p"},
+	"h2":         {"p"},
+	"h3":         {"p"},
+	"h4":         {"p"},
+	"h5":         {"p"},
+	"h6":         {"p"},
+	"div":        {"p"},
+	"ul":         {"p"},
+	"ol":         {"p"},
+	"table":      {"p"},
+	"blockquote": {"p"},
+	"pre":        {"p"},
+	"hr":         {"p"},
+}
+
+// parseHTML parses an HTML fragment into a tree below a synthetic root node.
+//
+// The tokenizer of encoding/xml is used in non-strict mode with the HTML entities. Nesting is
+// tracked here rather than by the decoder so that void elements, omitted end tags and stray
+// end tags are tolerated.
+func parseHTML(r io.Reader) (*node, error) {
+	d := xml.NewDecoder(r)
+	d.Strict = false
+	d.Entity = xml.HTMLEntity
+
+	root := &node{tag: "#root"}
+	stack := []*node{root}
+
+	for {
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{tag: elementName(t.Name), attrs: make(map[string]string)}
+			for _, attr := range t.Attr {
+				n.attrs[strings.ToLower(elementName(attr.Name))] = attr.Value
+			}
+
+			for len(stack) > 1 && containsTag(implicitEnds[n.tag], stack[len(stack)-1].tag) {
+				stack = stack[:len(stack)-1]
+			}
+
+			top := stack[len(stack)-1]
+			top.children = append(top.children, n)
+			if !voidElements[n.tag] {
+				stack = append(stack, n)
+			}
+
+		case xml.EndElement:
+			name := elementName(t.Name)
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].tag == name {
+					stack = stack[:i]
+					break
+				}
+			}
+
+		case xml.CharData:
+			top := stack[len(stack)-1]
+			top.children = append(top.children, &node{text: string(t)})
+		}
+	}
+
+	return root, nil
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func elementName(name xml.Name) string {
+	if name.Space != "" {
+		return strings.ToLower(name.Space + ":" + name.Local)
+	}
+	return strings.ToLower(name.Local)
+}
+
+// parseStyle parses an inline style attribute into lower-case property names and values.
+func parseStyle(style string) map[string]string {
+	props := make(map[string]string)
+	for _, decl := range strings.Split(style, ";") {
+		name, value, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+		props[strings.ToLower(strings.TrimSpace(name))] = value
+	}
+	return props
+}
+
+// namedColors maps the basic CSS color keywords to hexadecimal colors.
+var namedColors = map[string]string{
+	"black": "000000", "white": "FFFFFF", "red": "FF0000", "green": "008000", "blue": "0000FF",
+	"yellow": "FFFF00", "gray": "808080", "grey": "808080", "silver": "C0C0C0", "maroon": "800000",
+	"purple": "800080", "fuchsia": "FF00FF", "magenta": "FF00FF", "lime": "00FF00", "olive": "808000",
+	"navy": "000080", "teal": "008080", "aqua": "00FFFF", "cyan": "00FFFF", "orange": "FFA500",
+}
+
+// parseColor converts a CSS color to an upper-case hexadecimal color without the leading #.
+func parseColor(value string) (string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if hex, ok := namedColors[value]; ok {
+		return hex, true
+	}
+
+	if strings.HasPrefix(value, "#") {
+		hex := value[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if len(hex) != 6 {
+			return "", false
+		}
+		if _, err := strconv.ParseUint(hex, 16, 32); err != nil {
+			return "", false
+		}
+		return strings.ToUpper(hex), true
+	}
+
+	if strings.HasPrefix(value, "rgb(") || strings.HasPrefix(value, "rgba(") {
+		args := value[strings.Index(value, "(")+1:]
+		args = strings.TrimSuffix(args, ")")
+		parts := strings.FieldsFunc(args, func(r rune) bool { return r == ',' || r == ' ' || r == '/' })
+		if len(parts) < 3 {
+			return "", false
+		}
+
+		var hex strings.Builder
+		for _, part := range parts[:3] {
+			var v float64
+			var err error
+			if strings.HasSuffix(part, "%") {
+				v, err = strconv.ParseFloat(strings.TrimSuffix(part, "%"), 64)
+				v = v * 255 / 100
+			} else {
+				v, err = strconv.ParseFloat(part, 64)
+			}
+			if err != nil {
+				return "", false
+			}
+			fmt.Fprintf(&hex, "%02X", int(math.Max(0, math.Min(255, math.Round(v)))))
+		}
+		return hex.String(), true
+	}
+
+	return "", false
+}
+
+// fontSizeKeywords maps the CSS absolute font size keywords to points.
+var fontSizeKeywords = map[string]float64{
+	"xx-small": 7, "x-small": 7.5, "small": 10, "medium": 12, "large": 13.5, "x-large": 18, "xx-large": 24,
+}
+
+// parseFontSize converts a CSS font size to points. Relative sizes are resolved against the
+// current font size.
+func parseFontSize(value string, current float64) (float64, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if pt, ok := fontSizeKeywords[value]; ok {
+		return pt, true
+	}
+
+	switch {
+	case strings.HasSuffix(value, "%"):
+		v, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		return current * v / 100, err == nil
+	case strings.HasSuffix(value, "rem"):
+		v, err := strconv.ParseFloat(strings.TrimSuffix(value, "rem"), 64)
+		return 12 * v, err == nil
+	case strings.HasSuffix(value, "em"):
+		v, err := strconv.ParseFloat(strings.TrimSuffix(value, "em"), 64)
+		return current * v, err == nil
+	}
+
+	twips, ok := parseLength(value)
+	return float64(twips) / 20, ok
+}
+
+// parseLength converts a CSS length to twips. Unitless values are taken as pixels, as in the
+// width and height attributes.
+func parseLength(value string) (int, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	units := []struct {
+		suffix string
+		twips  float64
+	}{
+		{"pt", 20}, {"px", 15}, {"in", 1440}, {"cm", 567}, {"mm", 56.7}, {"pc", 240}, {"em", 240}, {"", 15},
+	}
+
+	for _, unit := range units {
+		if !strings.HasSuffix(value, unit.suffix) {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, unit.suffix)), 64)
+		if err != nil {
+			return 0, false
+		}
+		return int(math.Round(v * unit.twips)), true
+	}
+
+	return 0, false
+}
+
+// firstFontFamily returns the first family of a CSS font-family list.
+func firstFontFamily(value string) string {
+	family, _, _ := strings.Cut(value, ",")
+	family = strings.Trim(strings.TrimSpace(family), `"'`)
+
+	switch strings.ToLower(family) {
+	case "monospace":
+		return "Courier New"
+	case "serif":
+		return "Times New Roman"
+	case "sans-serif":
+		return "Arial"
+	}
+	return family
+}
@@ -3,8 +3,11 @@ package internal
 import (
 	"archive/zip"
 	"bytes"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 func ReadFileFromZip(file *zip.File) ([]byte, error) {
@@ -37,3 +40,30 @@ func FileToByte(fileName string) ([]byte, error) {
 
 	return fileBytes, nil
 }
+
+// LocalPath resolves a slash separated path against the base directory, rejecting absolute
+// paths and paths leading outside the directory, also through symbolic links. An empty base
+// directory is the working directory.
+func LocalPath(baseDir, src string) (string, error) {
+	path := filepath.FromSlash(src)
+	if filepath.IsAbs(path) || filepath.VolumeName(path) != "" || strings.HasPrefix(path, string(filepath.Separator)) {
+		return "", fmt.Errorf("%s: absolute paths are not allowed", src)
+	}
+
+	if baseDir == "" {
+		baseDir = "."
+	}
+	base, err := filepath.EvalSymlinks(baseDir)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Join(base, path))
+	if err != nil {
+		return "", err
+	}
+	if rel, err := filepath.Rel(base, resolved); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the base directory", src)
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,7 @@
+// Package markdown converts documents between the DOCX and Markdown formats.
+//
+// Export writes the body of a docx.RootDoc as CommonMark with GitHub Flavored Markdown
+// tables, resolving headings, lists, emphasis and links from the document styles,
+// numbering and relationships. Import parses Markdown and appends it to a document
+// using the regular docx building functions.
+package markdown
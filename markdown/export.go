@@ -0,0 +1,923 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bfoley13/godocx/dml"
+	"github.com/bfoley13/godocx/docx"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// MergedCellMode controls how tables with merged cells are exported.
+type MergedCellMode int
+
+const (
+	// MergedCellEmpty leaves the cells covered by a merge empty.
+	MergedCellEmpty MergedCellMode = iota
+
+	// MergedCellRepeat repeats the content of the merged cell in every covered cell.
+	MergedCellRepeat
+
+	// MergedCellHTML writes tables containing merged cells as HTML tables with colspan and rowspan.
+	MergedCellHTML
+)
+
+// DefaultCodeFonts lists the fonts whose runs are exported as inline code.
+var DefaultCodeFonts = []string{"Consolas", "Courier", "Courier New", "Lucida Console", "Menlo", "Monaco", "Source Code Pro"}
+
+// DefaultCodeStyles lists the paragraph styles whose paragraphs are exported as fenced code blocks.
+var DefaultCodeStyles = []string{"MacroText", "HTMLPreformatted", "SourceCode"}
+
+// ExportOptions configures the Markdown exporter.
+type ExportOptions struct {
+	// ImageDir is the directory images are written to. Images are not written when it is empty,
+	// but they are still referenced by their media file name.
+	ImageDir string
+
+	// ImageLinkPrefix is prepended to image file names in image links. It defaults to ImageDir.
+	ImageLinkPrefix string
+
+	// MergedCells controls how merged table cells are exported.
+	MergedCells MergedCellMode
+
+	// CodeFonts lists the fonts exported as inline code; DefaultCodeFonts is used when nil.
+	CodeFonts []string
+
+	// CodeStyles lists the paragraph styles exported as code blocks; DefaultCodeStyles is used when nil.
+	CodeStyles []string
+}
+
+// blockKind identifies the last Markdown block written, to decide how blocks are separated.
+type blockKind int
+
+const (
+	blockNone blockKind = iota
+	blockPara
+	blockList
+	blockCode
+	blockTable
+)
+
+// note is a footnote or endnote referenced from the body, numbered in order of appearance.
+type note struct {
+	label   int
+	content *ctypes.Footnote
+}
+
+type exporter struct {
+	rd   *docx.RootDoc
+	opts ExportOptions
+	out  strings.Builder
+
+	// para is the paragraph being exported; its style contributes to the run formatting
+	para *ctypes.Paragraph
+
+	// headerRow is set while exporting the first table row, whose cells are bold already
+	headerRow bool
+
+	last      blockKind
+	code      []string
+	counters  map[int][]int
+	notes     []note
+	footnotes *ctypes.Footnotes
+	endnotes  *ctypes.Footnotes
+}
+
+// Export writes the body of a document as CommonMark with GitHub Flavored Markdown tables.
+//
+// Headings are derived from the paragraph style or outline level, bold, italic and strikethrough
+// from the effective run properties, and lists from the numbering of each paragraph. Hyperlinks
+// are resolved through the document relationships, images are written to ImageDir and footnotes
+// and endnotes are appended as Markdown footnotes.
+//
+// Parameters:
+//   - rd: The document to export.
+//   - w: The writer receiving the Markdown output.
+//   - opts: Options controlling the export.
+//
+// Returns:
+//   - error: An error if a notes part cannot be decoded or an image or the output cannot be written.
+func Export(rd *docx.RootDoc, w io.Writer, opts ExportOptions) error {
+	if opts.CodeFonts == nil {
+		opts.CodeFonts = DefaultCodeFonts
+	}
+	if opts.CodeStyles == nil {
+		opts.CodeStyles = DefaultCodeStyles
+	}
+	if opts.ImageLinkPrefix == "" {
+		opts.ImageLinkPrefix = filepath.ToSlash(opts.ImageDir)
+	}
+
+	e := &exporter{
+		rd:       rd,
+		opts:     opts,
+		counters: make(map[int][]int),
+	}
+
+	var err error
+	if e.footnotes, err = rd.Footnotes(); err != nil {
+		return err
+	}
+	if e.endnotes, err = rd.Endnotes(); err != nil {
+		return err
+	}
+
+	if rd.Document != nil && rd.Document.Body != nil {
+		for _, child := range rd.Document.Body.Children {
+			if child.Para != nil {
+				if err := e.paragraph(child.Para.GetCT()); err != nil {
+					return err
+				}
+			}
+			if child.Table != nil {
+				if err := e.table(child.Table.GetCT()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	e.flushCode()
+
+	if err := e.writeNotes(); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, e.out.String())
+	return err
+}
+
+// startBlock writes the separator required before a block of the given kind.
+func (e *exporter) startBlock(kind blockKind) {
+	if kind != blockCode {
+		e.flushCode()
+	}
+
+	switch {
+	case e.last == blockNone:
+	case e.last == blockList && kind == blockList:
+	default:
+		e.out.WriteString("\n")
+	}
+
+	e.last = kind
+}
+
+// flushCode writes the pending code block paragraphs as a fenced code block.
+func (e *exporter) flushCode() {
+	if len(e.code) == 0 {
+		return
+	}
+
+	lines := e.code
+	e.code = nil
+
+	fence := "```"
+	for strings.Contains(strings.Join(lines, "\n"), fence) {
+		fence += "`"
+	}
+
+	if e.last != blockNone {
+		e.out.WriteString("\n")
+	}
+	e.out.WriteString(fence + "\n" + strings.Join(lines, "\n") + "\n" + fence + "\n")
+	e.last = blockCode
+}
+
+func (e *exporter) paragraph(p *ctypes.Paragraph) error {
+	props := e.rd.EffectiveParaProp(p)
+
+	if props.Style != nil && containsStyle(e.opts.CodeStyles, props.Style.Val) {
+		e.code = append(e.code, plainText(p))
+		return nil
+	}
+
+	// Heading styles are conveyed by the heading marker, so only run formatting is kept
+	level := e.rd.OutlineLevel(p)
+	e.para = p
+	if level >= 0 {
+		e.para = nil
+	}
+
+	text, err := e.inline(p.Children, false)
+	if err != nil {
+		return err
+	}
+
+	if level >= 0 {
+		if level > 5 {
+			level = 5
+		}
+		e.startBlock(blockPara)
+		e.out.WriteString(strings.Repeat("#", level+1) + " " + strings.ReplaceAll(text, "  \n", " ") + "\n")
+		return nil
+	}
+
+	if props.NumProp != nil && props.NumProp.NumID != nil && props.NumProp.NumID.Val > 0 {
+		ilvl := 0
+		if props.NumProp.ILvl != nil {
+			ilvl = props.NumProp.ILvl.Val
+		}
+		e.startBlock(blockList)
+		marker := e.listMarker(props.NumProp.NumID.Val, ilvl)
+		indent := strings.Repeat("    ", ilvl)
+		text = strings.ReplaceAll(text, "  \n", "  \n"+indent+strings.Repeat(" ", len(marker)))
+		e.out.WriteString(indent + marker + text + "\n")
+		return nil
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	e.startBlock(blockPara)
+	e.out.WriteString(escapeLineStart(text) + "\n")
+	return nil
+}
+
+// listMarker returns the list marker for the next item of the given list level and advances
+// the item counter.
+func (e *exporter) listMarker(numID, ilvl int) string {
+	var lvl *ctypes.NumLevel
+	if e.rd.Numbering != nil {
+		lvl = e.rd.Numbering.Level(numID, ilvl)
+	}
+
+	if lvl == nil || lvl.NumFmt == nil || lvl.NumFmt.Val == stypes.NumFmtBullet || lvl.NumFmt.Val == stypes.NumFmtNone {
+		return "- "
+	}
+
+	counters := e.counters[numID]
+	for len(counters) <= ilvl {
+		counters = append(counters, 0)
+	}
+	// Items at a shallower level restart the numbering of deeper levels
+	counters = counters[:ilvl+1]
+
+	if counters[ilvl] == 0 {
+		counters[ilvl] = 1
+		if lvl.Start != nil {
+			counters[ilvl] = lvl.Start.Val
+		}
+	} else {
+		counters[ilvl]++
+	}
+	e.counters[numID] = counters
+
+	return strconv.Itoa(counters[ilvl]) + ". "
+}
+
+// segment is a piece of inline text sharing the same formatting.
+type segment struct {
+	text                       string
+	bold, italic, strike, code bool
+
+	// raw segments are written as is, as Markdown from text or as HTML from html
+	raw  bool
+	html string
+}
+
+// lineBreak returns the segment of a line break, which is written as <br> in table cells.
+func lineBreak(inTable bool) segment {
+	if inTable {
+		return segment{text: "<br>", html: "<br>", raw: true}
+	}
+	return segment{text: "  \n", html: "<br>", raw: true}
+}
+
+// inline renders paragraph content as Markdown inline text. In table cells line breaks are
+// written as <br>.
+func (e *exporter) inline(children []ctypes.ParagraphChild, inTable bool) (string, error) {
+	segments, err := e.segments(children, inTable)
+	if err != nil {
+		return "", err
+	}
+
+	return renderSegments(segments), nil
+}
+
+func (e *exporter) segments(children []ctypes.ParagraphChild, inTable bool) ([]segment, error) {
+	var segments []segment
+
+	for _, child := range children {
+		switch {
+		case child.Run != nil:
+			runSegs, err := e.runSegments(child.Run, inTable)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, runSegs...)
+		case child.Link != nil:
+			linkSegs, err := e.linkSegment(child.Link, inTable)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, linkSegs...)
+		case child.Sdt != nil && child.Sdt.Content != nil:
+			for _, sdtChild := range child.Sdt.Content.Children {
+				var inner []ctypes.ParagraphChild
+				if sdtChild.Run != nil {
+					inner = append(inner, ctypes.ParagraphChild{Run: sdtChild.Run})
+				}
+				if sdtChild.Paragraph != nil {
+					inner = append(inner, sdtChild.Paragraph.Children...)
+				}
+				sdtSegs, err := e.segments(inner, inTable)
+				if err != nil {
+					return nil, err
+				}
+				segments = append(segments, sdtSegs...)
+			}
+		}
+	}
+
+	return segments, nil
+}
+
+// linkSegment renders a hyperlink as a single raw segment.
+func (e *exporter) linkSegment(link *ctypes.Hyperlink, inTable bool) ([]segment, error) {
+	var children []ctypes.ParagraphChild
+	if link.Run != nil {
+		children = append(children, ctypes.ParagraphChild{Run: link.Run})
+	}
+	children = append(children, link.Children...)
+
+	inner, err := e.segments(children, inTable)
+	if err != nil {
+		return nil, err
+	}
+
+	target := ""
+	if rel := e.rd.Document.GetRelationByID(link.ID); rel != nil {
+		target = rel.Target
+	}
+
+	if target == "" {
+		return inner, nil
+	}
+
+	seg := segment{text: "[" + renderSegments(inner) + "](" + escapeURL(target) + ")", raw: true}
+	seg.html = renderHTML(inner)
+	if safeHref(target) {
+		seg.html = `<a href="` + html.EscapeString(target) + `">` + seg.html + "</a>"
+	}
+	return []segment{seg}, nil
+}
+
+func (e *exporter) runSegments(run *ctypes.Run, inTable bool) ([]segment, error) {
+	props := e.rd.EffectiveRunProp(e.para, run)
+	if props.Vanish.Bool() {
+		return nil, nil
+	}
+
+	base := segment{
+		bold:   props.Bold.Bool() && !e.headerRow,
+		italic: props.Italic.Bool(),
+		strike: props.Strike.Bool() || props.DoubleStrike.Bool(),
+		code:   props.Fonts != nil && containsFold(e.opts.CodeFonts, props.Fonts.Ascii),
+	}
+
+	var segments []segment
+	addText := func(text string) {
+		seg := base
+		seg.text = text
+		segments = append(segments, seg)
+	}
+
+	for _, child := range run.Children {
+		switch {
+		case child.Text != nil:
+			addText(child.Text.Text)
+		case child.Tab != nil:
+			addText("\t")
+		case child.NoBreakHyphen != nil:
+			addText("-")
+		case child.Break != nil:
+			if child.Break.BreakType != nil && *child.Break.BreakType != stypes.BreakTypeTextWrapping {
+				continue
+			}
+			segments = append(segments, lineBreak(inTable))
+		case child.CarrRtn != nil:
+			segments = append(segments, lineBreak(inTable))
+		case child.Drawing != nil:
+			img, err := e.image(child.Drawing)
+			if err != nil {
+				return nil, err
+			}
+			if img.text != "" {
+				segments = append(segments, img)
+			}
+		case child.FootnoteReference != nil:
+			segments = append(segments, e.noteRef(e.footnotes, child.FootnoteReference.ID)...)
+		case child.EndnoteReference != nil:
+			segments = append(segments, e.noteRef(e.endnotes, child.EndnoteReference.ID)...)
+		}
+	}
+
+	return segments, nil
+}
+
+// noteRef registers a referenced note and returns the segment of its Markdown footnote
+// reference. In HTML the reference is written as the superscript label.
+func (e *exporter) noteRef(notes *ctypes.Footnotes, id int) []segment {
+	if notes == nil {
+		return nil
+	}
+
+	content := notes.NoteByID(id)
+	if content == nil {
+		return nil
+	}
+
+	label := len(e.notes) + 1
+	e.notes = append(e.notes, note{label: label, content: content})
+
+	return []segment{{text: fmt.Sprintf("[^%d]", label), html: fmt.Sprintf("<sup>%d</sup>", label), raw: true}}
+}
+
+// writeNotes appends the footnote definitions collected while exporting the body.
+func (e *exporter) writeNotes() error {
+	e.para = nil
+	for i, n := range e.notes {
+		var parts []string
+		for _, block := range n.content.Contents {
+			if block.Paragraph == nil {
+				continue
+			}
+			text, err := e.inline(block.Paragraph.Children, false)
+			if err != nil {
+				return err
+			}
+			if text = strings.TrimSpace(text); text != "" {
+				parts = append(parts, text)
+			}
+		}
+
+		if i == 0 && e.last != blockNone {
+			e.out.WriteString("\n")
+		}
+		fmt.Fprintf(&e.out, "[^%d]: %s\n", n.label, strings.Join(parts, " "))
+	}
+
+	return nil
+}
+
+// image writes the pictures of a drawing to the image directory and returns the segment of
+// their Markdown images.
+func (e *exporter) image(drawing *dml.Drawing) (segment, error) {
+	type picture struct {
+		graphic *dml.Graphic
+		docProp dml.DocProp
+	}
+
+	var pictures []picture
+	for i := range drawing.Inline {
+		pictures = append(pictures, picture{&drawing.Inline[i].Graphic, drawing.Inline[i].DocProp})
+	}
+	for _, anchor := range drawing.Anchor {
+		pictures = append(pictures, picture{&anchor.Graphic, anchor.DocProp})
+	}
+
+	var images, htmlImages []string
+	for _, pic := range pictures {
+		if pic.graphic.Data == nil || pic.graphic.Data.Pic == nil || pic.graphic.Data.Pic.BlipFill.Blip == nil {
+			continue
+		}
+
+		rel := e.rd.Document.GetRelationByID(pic.graphic.Data.Pic.BlipFill.Blip.EmbedID)
+		if rel == nil {
+			continue
+		}
+
+		fileName := path.Base(rel.Target)
+		if e.opts.ImageDir != "" {
+			content, ok := e.rd.ReadPart(rel.Target)
+			if !ok {
+				return segment{}, fmt.Errorf("image %s not found in package", rel.Target)
+			}
+			if err := os.MkdirAll(e.opts.ImageDir, 0o755); err != nil {
+				return segment{}, err
+			}
+			if err := os.WriteFile(filepath.Join(e.opts.ImageDir, fileName), content, 0o644); err != nil {
+				return segment{}, err
+			}
+		}
+
+		alt := pic.docProp.Description
+		if alt == "" {
+			alt = pic.docProp.Name
+		}
+
+		link := fileName
+		if e.opts.ImageLinkPrefix != "" {
+			link = path.Join(e.opts.ImageLinkPrefix, fileName)
+		}
+
+		images = append(images, "!["+escapeText(alt)+"]("+escapeURL(link)+")")
+		htmlImages = append(htmlImages, `<img src="`+html.EscapeString(link)+`" alt="`+html.EscapeString(alt)+`">`)
+	}
+
+	return segment{text: strings.Join(images, " "), html: strings.Join(htmlImages, " "), raw: true}, nil
+}
+
+// tableCell is a cell of the logical table grid. Its content is kept as Markdown text and as
+// HTML for tables written in HTML.
+type tableCell struct {
+	text, html       string
+	rowSpan, colSpan int
+
+	// covered marks grid positions hidden by a merge; origin points at the merged cell
+	covered bool
+	origin  *tableCell
+}
+
+func (e *exporter) table(tbl *ctypes.Table) error {
+	grid, merged, err := e.tableGrid(tbl)
+	if err != nil {
+		return err
+	}
+	if len(grid) == 0 {
+		return nil
+	}
+
+	e.startBlock(blockTable)
+
+	if merged && e.opts.MergedCells == MergedCellHTML {
+		e.htmlTable(grid)
+		return nil
+	}
+
+	cols := 0
+	for _, row := range grid {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+
+	for r, row := range grid {
+		cells := make([]string, cols)
+		for c := range cells {
+			if c >= len(row) {
+				continue
+			}
+			cell := row[c]
+			switch {
+			case cell == nil:
+			case !cell.covered:
+				cells[c] = cell.text
+			case e.opts.MergedCells == MergedCellRepeat:
+				cells[c] = cell.origin.text
+			}
+		}
+		e.out.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+
+		if r == 0 {
+			sep := make([]string, cols)
+			for c := range sep {
+				sep[c] = "---"
+			}
+			e.out.WriteString("| " + strings.Join(sep, " | ") + " |\n")
+		}
+	}
+
+	return nil
+}
+
+// tableGrid lays the table cells out on the logical grid of the table, with nil for the grid
+// columns skipped by a row. Rows end with their last cell. It reports whether any cells are
+// merged.
+func (e *exporter) tableGrid(tbl *ctypes.Table) ([][]*tableCell, bool, error) {
+	table := docx.NewTable(e.rd)
+	*table.GetCT() = *tbl
+
+	var (
+		grid   [][]*tableCell
+		merged bool
+	)
+
+	origins := make(map[*docx.Cell]*tableCell)
+	for r, positions := range table.LogicalGrid() {
+		row := make([]*tableCell, len(positions))
+		for c, gc := range positions {
+			if gc.Cell == nil {
+				continue
+			}
+
+			origin, ok := origins[gc.Cell]
+			if !ok {
+				e.headerRow = gc.Row == 0
+				text, htmlText, err := e.cellText(gc.Cell.GetCT())
+				e.headerRow = false
+				if err != nil {
+					return nil, false, err
+				}
+				origin = &tableCell{text: text, html: htmlText, rowSpan: gc.RowSpan, colSpan: gc.ColSpan}
+				origins[gc.Cell] = origin
+			}
+
+			if gc.Row == r && gc.Col == c {
+				row[c] = origin
+			} else {
+				row[c] = &tableCell{covered: true, origin: origin}
+			}
+			merged = merged || gc.Merged()
+		}
+
+		for len(row) > 0 && row[len(row)-1] == nil {
+			row = row[:len(row)-1]
+		}
+		grid = append(grid, row)
+	}
+
+	return grid, merged, nil
+}
+
+// cellText renders the content of a table cell on a single line, as Markdown and as HTML.
+func (e *exporter) cellText(cell *ctypes.Cell) (string, string, error) {
+	var parts, htmlParts []string
+	for _, block := range cell.Contents {
+		switch {
+		case block.Paragraph != nil:
+			e.para = block.Paragraph
+			segments, err := e.segments(block.Paragraph.Children, true)
+			if err != nil {
+				return "", "", err
+			}
+			if text := renderSegments(segments); text != "" {
+				parts = append(parts, text)
+				htmlParts = append(htmlParts, renderHTML(segments))
+			}
+		case block.Table != nil:
+			for _, row := range block.Table.RowContents {
+				if row.Row == nil {
+					continue
+				}
+				for _, inner := range row.Row.Contents {
+					if inner.Cell == nil {
+						continue
+					}
+					text, htmlText, err := e.cellText(inner.Cell)
+					if err != nil {
+						return "", "", err
+					}
+					if text != "" {
+						parts = append(parts, text)
+						htmlParts = append(htmlParts, htmlText)
+					}
+				}
+			}
+		}
+	}
+
+	text := strings.Join(parts, "<br>")
+	return strings.ReplaceAll(text, "|", `\|`), strings.Join(htmlParts, "<br>"), nil
+}
+
+// htmlTable writes a table with merged cells as an HTML table.
+func (e *exporter) htmlTable(grid [][]*tableCell) {
+	e.out.WriteString("<table>\n")
+	for r, row := range grid {
+		e.out.WriteString("<tr>")
+		tag := "td"
+		if r == 0 {
+			tag = "th"
+		}
+		for _, cell := range row {
+			if cell == nil {
+				e.out.WriteString("<" + tag + "></" + tag + ">")
+				continue
+			}
+			if cell.covered {
+				continue
+			}
+			e.out.WriteString("<" + tag)
+			if cell.colSpan > 1 {
+				fmt.Fprintf(&e.out, ` colspan="%d"`, cell.colSpan)
+			}
+			if cell.rowSpan > 1 {
+				fmt.Fprintf(&e.out, ` rowspan="%d"`, cell.rowSpan)
+			}
+			e.out.WriteString(">" + cell.html + "</" + tag + ">")
+		}
+		e.out.WriteString("</tr>\n")
+	}
+	e.out.WriteString("</table>\n")
+}
+
+// renderSegments merges adjacent segments with identical formatting and writes them as
+// Markdown inline text.
+func renderSegments(segments []segment) string {
+	var sb strings.Builder
+	for _, seg := range mergeSegments(segments) {
+		if seg.raw {
+			sb.WriteString(seg.text)
+			continue
+		}
+		sb.WriteString(formatSegment(seg))
+	}
+
+	return sb.String()
+}
+
+// renderHTML writes segments as HTML inline markup, for content written inside HTML blocks
+// where Markdown is not parsed.
+func renderHTML(segments []segment) string {
+	var sb strings.Builder
+	for _, seg := range mergeSegments(segments) {
+		if seg.raw {
+			sb.WriteString(seg.html)
+			continue
+		}
+
+		text := html.EscapeString(seg.text)
+		if seg.code {
+			text = "<code>" + text + "</code>"
+		}
+		if seg.strike {
+			text = "<del>" + text + "</del>"
+		}
+		if seg.italic {
+			text = "<em>" + text + "</em>"
+		}
+		if seg.bold {
+			text = "<strong>" + text + "</strong>"
+		}
+		sb.WriteString(text)
+	}
+
+	return sb.String()
+}
+
+// mergeSegments joins adjacent segments with identical formatting.
+func mergeSegments(segments []segment) []segment {
+	var merged []segment
+	for _, seg := range segments {
+		if n := len(merged); n > 0 && !seg.raw && !merged[n-1].raw && sameFormat(merged[n-1], seg) {
+			merged[n-1].text += seg.text
+			continue
+		}
+		merged = append(merged, seg)
+	}
+	return merged
+}
+
+func sameFormat(a, b segment) bool {
+	return a.bold == b.bold && a.italic == b.italic && a.strike == b.strike && a.code == b.code
+}
+
+// formatSegment escapes the segment text and wraps it in the emphasis markers of its format.
+// Surrounding whitespace is kept outside of the markers, as CommonMark requires.
+func formatSegment(seg segment) string {
+	trimmed := strings.TrimSpace(seg.text)
+	if trimmed == "" {
+		return seg.text
+	}
+
+	start := strings.Index(seg.text, trimmed)
+	lead, trail := seg.text[:start], seg.text[start+len(trimmed):]
+
+	var text string
+	if seg.code {
+		fence := "`"
+		for strings.Contains(trimmed, fence) {
+			fence += "`"
+		}
+		if strings.HasPrefix(trimmed, "`") || strings.HasSuffix(trimmed, "`") {
+			text = fence + " " + trimmed + " " + fence
+		} else {
+			text = fence + trimmed + fence
+		}
+	} else {
+		text = escapeText(trimmed)
+	}
+
+	if seg.strike {
+		text = "~~" + text + "~~"
+	}
+	if seg.italic {
+		text = "*" + text + "*"
+	}
+	if seg.bold {
+		text = "**" + text + "**"
+	}
+
+	return lead + text + trail
+}
+
+// escapeText escapes the characters that have an inline meaning in Markdown.
+func escapeText(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		switch r {
+		case '\\', '`', '*', '_', '[', ']', '<', '>', '~':
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// escapeLineStart escapes text at the start of a paragraph that would otherwise be parsed as
+// a heading, list item or block quote.
+func escapeLineStart(text string) string {
+	if text == "" {
+		return text
+	}
+
+	switch text[0] {
+	case '#', '-', '+', '=':
+		return `\` + text
+	}
+
+	digits := 0
+	for digits < len(text) && text[digits] >= '0' && text[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits < len(text) && (text[digits] == '.' || text[digits] == ')') {
+		return text[:digits] + `\` + text[digits:]
+	}
+
+	return text
+}
+
+// escapeURL escapes the characters that would end a Markdown link destination.
+func escapeURL(target string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(target)
+}
+
+// safeHref reports whether a link target can be written as an href in HTML: a relative URL
+// or fragment, or an http, https or mailto URL. Other schemes such as javascript: are not
+// written.
+func safeHref(target string) bool {
+	u, err := url.Parse(strings.TrimSpace(target))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+// plainText returns the text of a paragraph without any formatting.
+func plainText(p *ctypes.Paragraph) string {
+	var sb strings.Builder
+	var writeRun func(run *ctypes.Run)
+	writeRun = func(run *ctypes.Run) {
+		for _, child := range run.Children {
+			switch {
+			case child.Text != nil:
+				sb.WriteString(child.Text.Text)
+			case child.Tab != nil:
+				sb.WriteString("\t")
+			case child.Break != nil, child.CarrRtn != nil:
+				sb.WriteString("\n")
+			}
+		}
+	}
+
+	for _, child := range p.Children {
+		if child.Run != nil {
+			writeRun(child.Run)
+		}
+		if child.Link != nil {
+			for _, linkChild := range child.Link.Content() {
+				if linkChild.Run != nil {
+					writeRun(linkChild.Run)
+				}
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// containsStyle reports whether a style ID or display name is in the list. Spaces are
+// ignored, so "Macro Text" matches the style ID "MacroText".
+func containsStyle(list []string, style string) bool {
+	style = strings.ReplaceAll(style, " ", "")
+	for _, item := range list {
+		if strings.EqualFold(strings.ReplaceAll(item, " ", ""), style) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+package markdown
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/docx"
+	"github.com/bfoley13/godocx/packager"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTemplate(t *testing.T) *docx.RootDoc {
+	t.Helper()
+
+	content, err := os.ReadFile("../templates/default.docx")
+	require.NoError(t, err)
+
+	rd, err := packager.Unpack(&content)
+	require.NoError(t, err)
+
+	rd.Document.Body.Children = nil
+	return rd
+}
+
+func export(t *testing.T, rd *docx.RootDoc, opts ExportOptions) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, Export(rd, &buf, opts))
+	return buf.String()
+}
+
+func TestExport_HeadingsAndInline(t *testing.T) {
+	rd := loadTemplate(t)
+
+	_, err := rd.AddHeading("Report", 0)
+	require.NoError(t, err)
+	_, err = rd.AddHeading("Details", 2)
+	require.NoError(t, err)
+
+	p := rd.AddParagraph("Plain ")
+	p.AddText("bold").Bold(true)
+	p.AddText(" and ")
+	p.AddText("italic").Italic(true)
+	p.AddText(" with ")
+	p.AddText("code").Font("Courier New")
+	p.AddText(" and 2*3.")
+
+	rd.AddParagraph("# not a heading")
+
+	expected := "# Report\n" +
+		"\n## Details\n" +
+		"\nPlain **bold** and *italic* with `code` and 2\\*3.\n" +
+		"\n\\# not a heading\n"
+	assert.Equal(t, expected, export(t, rd, ExportOptions{}))
+}
+
+func TestExport_Lists(t *testing.T) {
+	rd := loadTemplate(t)
+
+	rd.AddParagraph("First").Style("List Bullet")
+	rd.AddParagraph("Second").Style("List Bullet")
+	rd.AddParagraph("Between")
+
+	one := rd.AddParagraph("One")
+	one.Numbering(5, 0)
+	two := rd.AddParagraph("Two")
+	two.Numbering(5, 0)
+	nested := rd.AddParagraph("Nested")
+	nested.Numbering(1, 1)
+
+	expected := "- First\n" +
+		"- Second\n" +
+		"\nBetween\n" +
+		"\n1. One\n" +
+		"2. Two\n" +
+		"    - Nested\n"
+	assert.Equal(t, expected, export(t, rd, ExportOptions{}))
+}
+
+func TestExport_LinksAndCode(t *testing.T) {
+	rd := loadTemplate(t)
+
+	p := rd.AddParagraph("See ")
+	p.AddLink("the docs", "https://example.com/a b")
+	rd.AddParagraph("x := 1").Style("Macro Text")
+	rd.AddParagraph("y := 2").Style("Macro Text")
+	rd.AddParagraph("After")
+
+	expected := "See [the docs](https://example.com/a%20b)\n" +
+		"\n```\nx := 1\ny := 2\n```\n" +
+		"\nAfter\n"
+	assert.Equal(t, expected, export(t, rd, ExportOptions{}))
+}
+
+func TestExport_Tables(t *testing.T) {
+	newTable := func(rd *docx.RootDoc) {
+		tbl := rd.AddTable()
+		header := tbl.AddRow()
+		header.AddCell().AddParagraph("Name")
+		// Header cells are bold already, so their bold runs are not marked up again
+		header.AddCell().AddEmptyPara().AddText("Notes").Bold(true)
+
+		row := tbl.AddRow()
+		row.AddCell().AddParagraph("a|b")
+		cell := row.AddCell()
+		cell.AddParagraph("one")
+		cell.AddParagraph("two")
+
+		last := tbl.AddRow()
+		last.AddCell().AddParagraph("ignored")
+		last.AddCell().AddParagraph("three")
+
+		// Merge the first column of the last two rows
+		rows := tbl.GetCT().RowContents
+		restart := stypes.MergeCellRestart
+		rows[1].Row.Contents[0].Cell.Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{Val: &restart}
+		rows[2].Row.Contents[0].Cell.Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{}
+	}
+
+	tests := []struct {
+		name     string
+		mode     MergedCellMode
+		expected string
+	}{
+		{
+			name: "Empty",
+			mode: MergedCellEmpty,
+			expected: "| Name | Notes |\n| --- | --- |\n" +
+				"| a\\|b | one<br>two |\n" +
+				"|  | three |\n",
+		},
+		{
+			name: "Repeat",
+			mode: MergedCellRepeat,
+			expected: "| Name | Notes |\n| --- | --- |\n" +
+				"| a\\|b | one<br>two |\n" +
+				"| a\\|b | three |\n",
+		},
+		{
+			name: "HTML",
+			mode: MergedCellHTML,
+			expected: "<table>\n<tr><th>Name</th><th>Notes</th></tr>\n" +
+				"<tr><td rowspan=\"2\">a|b</td><td>one<br>two</td></tr>\n" +
+				"<tr><td>three</td></tr>\n</table>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rd := loadTemplate(t)
+			newTable(rd)
+			assert.Equal(t, tt.expected, export(t, rd, ExportOptions{MergedCells: tt.mode}))
+		})
+	}
+}
+
+func TestExport_TableSkippedColumns(t *testing.T) {
+	newTable := func(rd *docx.RootDoc) {
+		tbl := rd.AddTable()
+		first := tbl.AddRow()
+		for _, text := range []string{"a", "b", "c"} {
+			first.AddCell().AddParagraph(text)
+		}
+
+		// The second row starts at the second grid column, the third merges two cells with hMerge
+		skipped := tbl.AddRow()
+		skipped.GetCT().Property = &ctypes.RowProperty{GridBefore: ctypes.NewDecimalNum(1)}
+		skipped.AddCell().AddParagraph("d")
+		skipped.AddCell().AddParagraph("e")
+
+		merged := tbl.AddRow()
+		merged.AddCell().AddParagraph("f")
+		merged.AddCell().AddEmptyPara()
+		merged.AddCell().AddParagraph("g")
+		restart := stypes.MergeCellRestart
+		cells := merged.GetCT().Contents
+		cells[0].Cell.Property.HMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{Val: &restart}
+		cells[1].Cell.Property.HMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{}
+	}
+
+	tests := []struct {
+		name     string
+		mode     MergedCellMode
+		expected string
+	}{
+		{
+			name:     "Empty",
+			mode:     MergedCellEmpty,
+			expected: "| a | b | c |\n| --- | --- | --- |\n|  | d | e |\n| f |  | g |\n",
+		},
+		{
+			name:     "Repeat",
+			mode:     MergedCellRepeat,
+			expected: "| a | b | c |\n| --- | --- | --- |\n|  | d | e |\n| f | f | g |\n",
+		},
+		{
+			name: "HTML",
+			mode: MergedCellHTML,
+			expected: "<table>\n<tr><th>a</th><th>b</th><th>c</th></tr>\n" +
+				"<tr><td></td><td>d</td><td>e</td></tr>\n" +
+				"<tr><td colspan=\"2\">f</td><td>g</td></tr>\n</table>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rd := loadTemplate(t)
+			newTable(rd)
+			assert.Equal(t, tt.expected, export(t, rd, ExportOptions{MergedCells: tt.mode}))
+		})
+	}
+}
+
+func TestExport_HTMLTableContent(t *testing.T) {
+	rd := loadTemplate(t)
+	tbl := rd.AddTable()
+	header := tbl.AddRow()
+	header.AddCell().ColSpan(2).AddParagraph("<script>alert(1)</script>")
+	header.AddCell().AddParagraph("a|b")
+
+	row := tbl.AddRow()
+	p := row.AddCell().AddEmptyPara()
+	p.AddText("bold").Bold(true)
+	p.AddText(" & [x](y) \\<")
+	p = row.AddCell().AddEmptyPara()
+	p.AddLink("docs", `https://example.com/?a=1&b="2"`)
+	p.AddLink("bad", "javascript:alert(1)")
+	row.AddCell().AddEmptyPara().AddText("code").Font("Consolas")
+
+	// Merged tables are written as HTML, so cell content is HTML and not Markdown
+	expected := "<table>\n<tr><th colspan=\"2\">&lt;script&gt;alert(1)&lt;/script&gt;</th><th>a|b</th></tr>\n" +
+		"<tr><td><strong>bold</strong> &amp; [x](y) \\&lt;</td>" +
+		"<td><a href=\"https://example.com/?a=1&amp;b=&#34;2&#34;\">docs</a>bad</td>" +
+		"<td><code>code</code></td></tr>\n</table>\n"
+	assert.Equal(t, expected, export(t, rd, ExportOptions{MergedCells: MergedCellHTML}))
+}
+
+func TestExport_ImagesAndFootnotes(t *testing.T) {
+	rd := loadTemplate(t)
+
+	imgPath := filepath.Join(t.TempDir(), "pic.png")
+	require.NoError(t, os.WriteFile(imgPath, []byte("png-bytes"), 0o644))
+
+	p := rd.AddParagraph("Figure ")
+	_, err := p.AddPicture(imgPath, 1, 1)
+	require.NoError(t, err)
+
+	notes := `<w:footnotes xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:footnote w:type="separator" w:id="-1"><w:p><w:r><w:separator/></w:r></w:p></w:footnote>` +
+		`<w:footnote w:id="1"><w:p><w:r><w:footnoteRef/></w:r><w:r><w:t xml:space="preserve"> A note.</w:t></w:r></w:p></w:footnote>` +
+		`</w:footnotes>`
+	rd.FileMap.Store("word/footnotes.xml", []byte(notes))
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &docx.Relationship{
+		ID:     "rIdNotes",
+		Type:   constants.FootnotesType,
+		Target: "footnotes.xml",
+	})
+
+	noted := rd.AddParagraph("Noted")
+	noted.GetCT().Children = append(noted.GetCT().Children, ctypes.ParagraphChild{Run: &ctypes.Run{
+		Children: []ctypes.RunChild{{FootnoteReference: &ctypes.FootnoteReference{ID: 1}}},
+	}})
+
+	imageDir := filepath.Join(t.TempDir(), "images")
+	output := export(t, rd, ExportOptions{ImageDir: imageDir, ImageLinkPrefix: "images"})
+
+	assert.Contains(t, output, "Figure ![")
+	assert.Contains(t, output, "](images/image")
+	assert.Contains(t, output, "\nNoted[^1]\n\n[^1]: A note.\n")
+
+	entries, err := os.ReadDir(imageDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	content, err := os.ReadFile(filepath.Join(imageDir, entries[0].Name()))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png-bytes"), content)
+}
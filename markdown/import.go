@@ -0,0 +1,465 @@
+package markdown
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF decoding for image sizes
+	_ "image/jpeg" // register JPEG decoding for image sizes
+	_ "image/png"  // register PNG decoding for image sizes
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bfoley13/godocx/common/units"
+	"github.com/bfoley13/godocx/docx"
+	"github.com/bfoley13/godocx/internal"
+	"github.com/bfoley13/godocx/wml/ctypes"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// StyleMap maps Markdown elements to the document styles used for them. Empty fields fall
+// back to the values of DefaultStyleMap.
+type StyleMap struct {
+	// Paragraph is the style of body paragraphs; the document default style is used when empty.
+	Paragraph string
+
+	// Headings are the styles of heading levels 1 to 6.
+	Headings [6]string
+
+	// Quote is the style of block quote paragraphs.
+	Quote string
+
+	// Code is the style of code block paragraphs.
+	Code string
+
+	// Table is the style of tables.
+	Table string
+}
+
+// DefaultStyleMap returns the style mapping matching the styles of the default template.
+func DefaultStyleMap() StyleMap {
+	return StyleMap{
+		Headings: [6]string{"Heading1", "Heading2", "Heading3", "Heading4", "Heading5", "Heading6"},
+		Quote:    "Quote",
+		Code:     "MacroText",
+		Table:    "TableGrid",
+	}
+}
+
+// ImportOptions configures the Markdown importer.
+type ImportOptions struct {
+	// Styles maps Markdown elements to document styles.
+	Styles StyleMap
+
+	// CodeFont is the font of inline code, and of code blocks when the code style is not
+	// defined in the document. It defaults to "Courier New".
+	CodeFont string
+
+	// AllowLocalFiles embeds images whose source is a local file path. Paths are resolved
+	// against BaseDir and must stay within it; absolute paths are rejected. When it is not set,
+	// such images are replaced by their alternative text, so that untrusted Markdown cannot read
+	// local files into the document.
+	AllowLocalFiles bool
+
+	// BaseDir is the directory relative image paths are resolved against.
+	BaseDir string
+
+	// MaxImageWidth limits the width of images; larger images are scaled down keeping their
+	// aspect ratio. It defaults to 6 inches.
+	MaxImageWidth units.Inch
+}
+
+// defaultImageDPI is the resolution assumed when converting image pixels to inches.
+const defaultImageDPI = 96
+
+// Task list items are prefixed with a ballot box reflecting their state.
+const (
+	taskOpen = "☐ "
+	taskDone = "☒ "
+)
+
+type importer struct {
+	rd   *docx.RootDoc
+	opts ImportOptions
+}
+
+// blockContext carries the list level and quote state of the blocks being imported.
+type blockContext struct {
+	// numID and depth locate the enclosing list item; numID is 0 outside of lists
+	numID int
+	depth int
+
+	quote bool
+}
+
+// Import parses Markdown and appends its content to the body of the document.
+//
+// CommonMark block and inline syntax is supported together with the GitHub Flavored Markdown
+// extensions for tables, task lists, strikethrough and autolinks. Headings are added with
+// AddHeading, lists are backed by numbering definitions, links are added with AddLink and
+// local images, with AllowLocalFiles, as pictures. Code blocks use the code style of the style
+// mapping.
+//
+// Parameters:
+//   - rd: The document receiving the content.
+//   - r: The reader providing the Markdown text.
+//   - opts: Options controlling the import.
+//
+// Returns:
+//   - error: An error if the input cannot be read or an image cannot be added.
+func Import(rd *docx.RootDoc, r io.Reader, opts ImportOptions) error {
+	defaults := DefaultStyleMap()
+	for i, style := range opts.Styles.Headings {
+		if style == "" {
+			opts.Styles.Headings[i] = defaults.Headings[i]
+		}
+	}
+	if opts.Styles.Quote == "" {
+		opts.Styles.Quote = defaults.Quote
+	}
+	if opts.Styles.Code == "" {
+		opts.Styles.Code = defaults.Code
+	}
+	if opts.Styles.Table == "" {
+		opts.Styles.Table = defaults.Table
+	}
+	if opts.CodeFont == "" {
+		opts.CodeFont = "Courier New"
+	}
+	if opts.MaxImageWidth == 0 {
+		opts.MaxImageWidth = 6
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	im := &importer{rd: rd, opts: opts}
+	return im.blocks(parseBlocks(lines), blockContext{})
+}
+
+func (im *importer) blocks(nodes []*node, ctx blockContext) error {
+	for _, n := range nodes {
+		if err := im.block(n, ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (im *importer) block(n *node, ctx blockContext) error {
+	switch n.kind {
+	case nodeHeading:
+		p, err := im.rd.AddHeading("", uint(n.level))
+		if err != nil {
+			return err
+		}
+		// AddHeading adds an empty run for the text; the parsed inline content replaces it
+		p.GetCT().Children = nil
+		if style := im.opts.Styles.Headings[n.level-1]; style != fmt.Sprintf("Heading%d", n.level) {
+			p.Style(style)
+		}
+		return im.inlines(p, parseInlines(n.text))
+
+	case nodeParagraph:
+		p := im.paragraph(ctx)
+		return im.inlines(p, parseInlines(n.text))
+
+	case nodeCode:
+		styled := im.rd.GetStyleByID(im.opts.Styles.Code, stypes.StyleTypeParagraph) != nil
+		for _, line := range n.lines {
+			p := im.paragraph(ctx)
+			if styled {
+				p.Style(im.opts.Styles.Code)
+			}
+			if line == "" {
+				continue
+			}
+			run := p.AddText(line)
+			if !styled {
+				run.Font(im.opts.CodeFont)
+			}
+		}
+
+	case nodeQuote:
+		ctx.quote = true
+		return im.blocks(n.children, ctx)
+
+	case nodeRule:
+		p := im.paragraph(blockContext{})
+		ct := p.GetCT()
+		if ct.Property == nil {
+			ct.Property = ctypes.DefaultParaProperty()
+		}
+		ct.Property.Border = &ctypes.ParaBorder{
+			Bottom: &ctypes.Border{Val: stypes.BorderStyleSingle, Size: internal.ToPtr(6), Space: internal.ToPtr("1"), Color: internal.ToPtr("auto")},
+		}
+
+	case nodeList:
+		return im.list(n, ctx)
+
+	case nodeTable:
+		return im.table(n)
+	}
+
+	return nil
+}
+
+// paragraph appends an empty paragraph styled for the given context.
+func (im *importer) paragraph(ctx blockContext) *docx.Paragraph {
+	p := im.rd.AddEmptyParagraph()
+
+	switch {
+	case ctx.quote:
+		p.Style(im.opts.Styles.Quote)
+	case im.opts.Styles.Paragraph != "":
+		p.Style(im.opts.Styles.Paragraph)
+	}
+
+	if ctx.numID != 0 {
+		p.Indent(&ctypes.Indent{Left: internal.ToPtr(docx.ListIndentStep * (ctx.depth + 1))})
+	}
+
+	return p
+}
+
+// list imports a top-level or nested list. Top-level lists get their own numbering definition
+// whose levels follow the list types found at each nesting depth.
+func (im *importer) list(n *node, ctx blockContext) error {
+	depth := 0
+	if ctx.numID == 0 {
+		var levels []docx.ListLevel
+		collectListLevels(n, 0, &levels)
+		ctx.numID = im.rd.AddListDefinition(levels...)
+	} else {
+		depth = ctx.depth + 1
+	}
+
+	for _, item := range n.items {
+		itemCtx := ctx
+		itemCtx.depth = depth
+		numbered := false
+
+		for _, child := range item.children {
+			if child.kind != nodeParagraph || numbered {
+				if err := im.block(child, itemCtx); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// The first paragraph of the item carries the list number
+			p := im.rd.AddEmptyParagraph()
+			if ctx.quote {
+				p.Style(im.opts.Styles.Quote)
+			}
+			p.Numbering(ctx.numID, min(depth, 8))
+			if item.task != nil {
+				prefix := taskOpen
+				if *item.task {
+					prefix = taskDone
+				}
+				p.AddText(prefix)
+			}
+			if err := im.inlines(p, parseInlines(child.text)); err != nil {
+				return err
+			}
+			numbered = true
+		}
+
+		// Items without a leading paragraph still need their list number
+		if !numbered {
+			p := im.rd.AddEmptyParagraph()
+			p.Numbering(ctx.numID, min(depth, 8))
+		}
+	}
+
+	return nil
+}
+
+// collectListLevels records the list type found first at each nesting depth.
+func collectListLevels(n *node, depth int, levels *[]docx.ListLevel) {
+	if depth >= 9 {
+		return
+	}
+
+	if len(*levels) <= depth {
+		level := docx.ListLevel{Format: stypes.NumFmtBullet}
+		if n.ordered {
+			formats := []stypes.NumFmt{stypes.NumFmtDecimal, stypes.NumFmtLowerLetter, stypes.NumFmtLowerRoman}
+			level = docx.ListLevel{Format: formats[depth%len(formats)], Start: n.start}
+		}
+		*levels = append(*levels, level)
+	}
+
+	for _, item := range n.items {
+		for _, child := range item.children {
+			if child.kind == nodeList {
+				collectListLevels(child, depth+1, levels)
+			}
+		}
+	}
+}
+
+func (im *importer) table(n *node) error {
+	tbl := im.rd.AddTable()
+	tbl.Style(im.opts.Styles.Table)
+	tbl.Width(5000, stypes.TableWidthPct)
+
+	rows := append([][]string{n.header}, n.rows...)
+	for r, cells := range rows {
+		row := tbl.AddRow()
+		for c := range n.header {
+			text := ""
+			if c < len(cells) {
+				text = cells[c]
+			}
+
+			p := row.AddCell().AddEmptyPara()
+			switch n.align[c] {
+			case "center":
+				p.Justification(stypes.JustificationCenter)
+			case "right":
+				p.Justification(stypes.JustificationRight)
+			case "left":
+				p.Justification(stypes.JustificationLeft)
+			}
+
+			inlines := parseInlines(text)
+			if r == 0 {
+				for i := range inlines {
+					inlines[i].bold = true
+				}
+			}
+			if err := im.inlines(p, inlines); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// inlines adds parsed inline content to a paragraph.
+func (im *importer) inlines(p *docx.Paragraph, inlines []inline) error {
+	for i := 0; i < len(inlines); i++ {
+		in := inlines[i]
+
+		switch {
+		case in.hardBreak:
+			p.AddRun().AddBreak(nil)
+
+		case in.image:
+			if err := im.image(p, in); err != nil {
+				return err
+			}
+
+		case in.href != "":
+			// Consecutive pieces of the same link become a single hyperlink
+			text := in.text
+			for i+1 < len(inlines) && inlines[i+1].href == in.href && !inlines[i+1].image && !inlines[i+1].hardBreak {
+				i++
+				text += inlines[i].text
+			}
+			link := p.AddLink(text, in.href)
+			if in.bold {
+				link.Bold(true)
+			}
+			if in.italic {
+				link.Italic(true)
+			}
+			if in.strike {
+				link.Strike(true)
+			}
+
+		default:
+			run := p.AddText(in.text)
+			if in.bold {
+				run.Bold(true)
+			}
+			if in.italic {
+				run.Italic(true)
+			}
+			if in.strike {
+				run.Strike(true)
+			}
+			if in.code {
+				run.Font(im.opts.CodeFont)
+			}
+		}
+	}
+
+	return nil
+}
+
+// image adds a local image as a picture sized from its pixel dimensions. Remote images are
+// added as links labelled with their alternative text, and local images are only read with
+// AllowLocalFiles.
+func (im *importer) image(p *docx.Paragraph, in inline) error {
+	if strings.Contains(in.src, "://") {
+		label := in.text
+		if label == "" {
+			label = in.src
+		}
+		p.AddLink(label, in.src)
+		return nil
+	}
+
+	if !im.opts.AllowLocalFiles {
+		if in.text != "" {
+			p.AddText(in.text)
+		}
+		return nil
+	}
+
+	path, err := internal.LocalPath(im.opts.BaseDir, in.src)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	width, height := imageSize(data)
+
+	if width > im.opts.MaxImageWidth {
+		height = height * im.opts.MaxImageWidth / width
+		width = im.opts.MaxImageWidth
+	}
+
+	pic, err := p.AddPictureBytes(data, filepath.Ext(path), width, height)
+	if err != nil {
+		return err
+	}
+	pic.Inline.DocProp.Description = in.text
+
+	return nil
+}
+
+// imageSize returns the size of an image in inches at the default resolution. Images that
+// cannot be decoded get a 4:3 size of 4 inches wide.
+func imageSize(data []byte) (units.Inch, units.Inch) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width == 0 || cfg.Height == 0 {
+		return 4, 3
+	}
+
+	return units.Inch(cfg.Width) / defaultImageDPI, units.Inch(cfg.Height) / defaultImageDPI
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
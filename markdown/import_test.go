@@ -0,0 +1,261 @@
+package markdown
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/common/units"
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInlines(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []inline
+	}{
+		{
+			name:     "Emphasis",
+			input:    "a **b** *c* ***d*** ~~e~~",
+			expected: []inline{{text: "a "}, {text: "b", bold: true}, {text: " "}, {text: "c", italic: true}, {text: " "}, {text: "d", bold: true, italic: true}, {text: " "}, {text: "e", strike: true}},
+		},
+		{
+			name:     "Nested emphasis",
+			input:    "**bold *both***",
+			expected: []inline{{text: "bold ", bold: true}, {text: "both", bold: true, italic: true}},
+		},
+		{
+			name:     "Intraword underscore",
+			input:    "snake_case_name",
+			expected: []inline{{text: "snake_case_name"}},
+		},
+		{
+			name:     "Code span",
+			input:    "run `go test ./...` now",
+			expected: []inline{{text: "run "}, {text: "go test ./...", code: true}, {text: " now"}},
+		},
+		{
+			name:     "Link",
+			input:    "see [the **docs**](https://example.com \"Title\")",
+			expected: []inline{{text: "see "}, {text: "the ", href: "https://example.com"}, {text: "docs", bold: true, href: "https://example.com"}},
+		},
+		{
+			name:     "Autolinks",
+			input:    "<me@example.com> and www.example.com.",
+			expected: []inline{{text: "me@example.com", href: "mailto:me@example.com"}, {text: " and "}, {text: "www.example.com", href: "http://www.example.com"}, {text: "."}},
+		},
+		{
+			name:     "Image",
+			input:    "![alt text](img/a.png)",
+			expected: []inline{{image: true, src: "img/a.png", text: "alt text"}},
+		},
+		{
+			name:     "Escapes and breaks",
+			input:    "\\*literal\\*  \nnext\nsoft",
+			expected: []inline{{text: "*literal*"}, {hardBreak: true}, {text: "next soft"}},
+		},
+		{
+			name:     "Unmatched delimiters",
+			input:    "2 * 3 and [x",
+			expected: []inline{{text: "2 * 3 and [x"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseInlines(tt.input))
+		})
+	}
+}
+
+func TestParseBlocks(t *testing.T) {
+	input := strings.Join([]string{
+		"Title",
+		"=====",
+		"",
+		"Some text",
+		"continued.",
+		"",
+		"- [x] done",
+		"- [ ] todo",
+		"  1. nested",
+		"  2. nested",
+		"",
+		"> quoted",
+		"",
+		"```go",
+		"func main() {}",
+		"```",
+		"",
+		"| A | B |",
+		"|:--|--:|",
+		"| 1 | 2 \\| 3 |",
+		"",
+		"---",
+	}, "\n")
+
+	nodes := parseBlocks(strings.Split(input, "\n"))
+	require.Len(t, nodes, 7)
+
+	assert.Equal(t, nodeHeading, nodes[0].kind)
+	assert.Equal(t, 1, nodes[0].level)
+	assert.Equal(t, "Title", nodes[0].text)
+
+	assert.Equal(t, nodeParagraph, nodes[1].kind)
+	assert.Equal(t, "Some text\ncontinued.", nodes[1].text)
+
+	list := nodes[2]
+	assert.Equal(t, nodeList, list.kind)
+	assert.False(t, list.ordered)
+	require.Len(t, list.items, 2)
+	assert.True(t, *list.items[0].task)
+	assert.False(t, *list.items[1].task)
+	require.Len(t, list.items[1].children, 2)
+	nested := list.items[1].children[1]
+	assert.Equal(t, nodeList, nested.kind)
+	assert.True(t, nested.ordered)
+	assert.Len(t, nested.items, 2)
+
+	assert.Equal(t, nodeQuote, nodes[3].kind)
+	assert.Equal(t, "quoted", nodes[3].children[0].text)
+
+	assert.Equal(t, nodeCode, nodes[4].kind)
+	assert.Equal(t, []string{"func main() {}"}, nodes[4].lines)
+
+	table := nodes[5]
+	assert.Equal(t, nodeTable, table.kind)
+	assert.Equal(t, []string{"A", "B"}, table.header)
+	assert.Equal(t, []string{"left", "right"}, table.align)
+	assert.Equal(t, [][]string{{"1", "2 | 3"}}, table.rows)
+
+	assert.Equal(t, nodeRule, nodes[6].kind)
+}
+
+func TestImport_RoundTrip(t *testing.T) {
+	input := "# Release 1.2\n" +
+		"\n## Highlights\n" +
+		"\nThe **fast** path is *default* now, see [notes](https://example.com/notes).\n" +
+		"\n- Faster startup\n" +
+		"- Smaller binaries\n" +
+		"    1. linux\n" +
+		"    2. darwin\n" +
+		"\n```\nmake release\n  --verbose\n```\n" +
+		"\n| Platform | Size |\n| --- | --- |\n| linux | 10 MB |\n"
+
+	rd := loadTemplate(t)
+	require.NoError(t, Import(rd, strings.NewReader(input), ImportOptions{}))
+
+	assert.Equal(t, input, export(t, rd, ExportOptions{}))
+}
+
+func TestImport_Lists(t *testing.T) {
+	rd := loadTemplate(t)
+	nums := len(rd.Numbering.Nums)
+
+	input := "3. three\n4. four\n\ntext\n\n- [ ] open\n- [x] closed\n"
+	require.NoError(t, Import(rd, strings.NewReader(input), ImportOptions{}))
+
+	// Each top-level list gets its own numbering definition
+	require.Len(t, rd.Numbering.Nums, nums+2)
+	ordered := rd.Numbering.Level(rd.Numbering.Nums[nums].ID, 0)
+	require.NotNil(t, ordered)
+	assert.Equal(t, stypes.NumFmtDecimal, ordered.NumFmt.Val)
+	assert.Equal(t, 3, ordered.Start.Val)
+
+	expected := "3. three\n4. four\n" +
+		"\ntext\n" +
+		"\n- ☐ open\n- ☒ closed\n"
+	assert.Equal(t, expected, export(t, rd, ExportOptions{}))
+}
+
+func TestImport_StylesAndImages(t *testing.T) {
+	dir := t.TempDir()
+
+	img := image.NewRGBA(image.Rect(0, 0, 960, 480))
+	img.Set(0, 0, color.Black)
+	f, err := os.Create(filepath.Join(dir, "chart.png"))
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(f, img))
+	require.NoError(t, f.Close())
+
+	rd := loadTemplate(t)
+	opts := ImportOptions{
+		Styles:          StyleMap{Paragraph: "BodyText", Headings: [6]string{"Title"}},
+		AllowLocalFiles: true,
+		BaseDir:         dir,
+		MaxImageWidth:   5,
+	}
+	require.NoError(t, Import(rd, strings.NewReader("# Notes\n\nBody ![Chart](chart.png)\n\n> Quote\n"), opts))
+
+	children := rd.Document.Body.Children
+	require.Len(t, children, 3)
+	assert.Equal(t, "Title", children[0].Para.GetCT().Property.Style.Val)
+	assert.Equal(t, "BodyText", children[1].Para.GetCT().Property.Style.Val)
+	assert.Equal(t, "Quote", children[2].Para.GetCT().Property.Style.Val)
+
+	var drawing bool
+	for _, child := range children[1].Para.GetCT().Children {
+		if child.Run == nil {
+			continue
+		}
+		for _, runChild := range child.Run.Children {
+			if runChild.Drawing == nil {
+				continue
+			}
+			drawing = true
+			inline := runChild.Drawing.Inline[0]
+			assert.Equal(t, "Chart", inline.DocProp.Description)
+			// 960x480 pixels at 96 DPI is 10x5 inches, scaled down to the 5 inch maximum width
+			assert.Equal(t, uint64(units.Inch(5).ToEmu()), inline.Extent.Width)
+			assert.Equal(t, uint64(units.Inch(2.5).ToEmu()), inline.Extent.Height)
+		}
+	}
+	assert.True(t, drawing)
+}
+
+func TestImport_LocalImages(t *testing.T) {
+	root := t.TempDir()
+	baseDir := filepath.Join(root, "site")
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "images"), 0o755))
+	for _, name := range []string{filepath.Join(baseDir, "images", "logo.png"), filepath.Join(root, "secret.png")} {
+		f, err := os.Create(name)
+		require.NoError(t, err)
+		require.NoError(t, png.Encode(f, image.NewRGBA(image.Rect(0, 0, 96, 96))))
+		require.NoError(t, f.Close())
+	}
+	secret := filepath.ToSlash(filepath.Join(root, "secret.png"))
+
+	// Local files are not read unless allowed
+	rd := loadTemplate(t)
+	input := "![Logo](images/logo.png)\n\n![](" + secret + ")\n"
+	require.NoError(t, Import(rd, strings.NewReader(input), ImportOptions{BaseDir: baseDir}))
+	assert.Equal(t, "Logo\n", export(t, rd, ExportOptions{}))
+	for _, rel := range rd.Document.DocRels.Relationships {
+		assert.NotEqual(t, constants.SourceRelationshipImage, rel.Type)
+	}
+
+	opts := ImportOptions{AllowLocalFiles: true, BaseDir: baseDir}
+	rd = loadTemplate(t)
+	require.NoError(t, Import(rd, strings.NewReader("![Logo](images/logo.png)\n"), opts))
+	require.Len(t, rd.Document.Body.Children, 1)
+	require.NotNil(t, rd.Document.Body.Children[0].Para.GetCT().Children[0].Run.Children[0].Drawing)
+
+	sources := []string{secret, "../secret.png", "images/../../secret.png", "images/missing.png"}
+	if err := os.Symlink(filepath.Join(root, "secret.png"), filepath.Join(baseDir, "images", "link.png")); err == nil {
+		sources = append(sources, "images/link.png")
+	}
+	for _, src := range sources {
+		t.Run(src, func(t *testing.T) {
+			rd := loadTemplate(t)
+			assert.Error(t, Import(rd, strings.NewReader("![secret]("+src+")\n"), opts))
+		})
+	}
+}
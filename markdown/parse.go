@@ -0,0 +1,742 @@
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// nodeKind identifies the type of a parsed Markdown block.
+type nodeKind int
+
+const (
+	nodeParagraph nodeKind = iota
+	nodeHeading
+	nodeCode
+	nodeQuote
+	nodeList
+	nodeTable
+	nodeRule
+)
+
+// node is a block of a parsed Markdown document.
+type node struct {
+	kind nodeKind
+
+	// text holds the raw inline content of paragraphs and headings
+	text  string
+	level int
+
+	// lines holds the lines of a code block
+	lines []string
+
+	// children holds the content of a block quote
+	children []*node
+
+	// list fields
+	ordered bool
+	start   int
+	items   []*listItem
+
+	// table fields
+	header []string
+	align  []string
+	rows   [][]string
+}
+
+// listItem is an item of a list block. task is nil for items that are not task list items.
+type listItem struct {
+	children []*node
+	task     *bool
+}
+
+var (
+	atxHeadingRe   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	fenceRe        = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})(.*)$")
+	thematicRe     = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	listItemRe     = regexp.MustCompile(`^( {0,3})([-+*]|\d{1,9}[.)])([ \t]+|$)(.*)$`)
+	quoteRe        = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	setextRe       = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	tableDelimRe   = regexp.MustCompile(`^[ \t]*\|?[ \t]*:?-+:?[ \t]*(\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	taskMarkerRe   = regexp.MustCompile(`^\[([ xX])\](?:[ \t]+|$)`)
+	indentedCodeRe = regexp.MustCompile(`^(?: {4}|\t)`)
+)
+
+// parseBlocks parses Markdown text into a list of blocks.
+func parseBlocks(lines []string) []*node {
+	var (
+		nodes []*node
+		para  []string
+	)
+
+	flushPara := func() {
+		if len(para) > 0 {
+			nodes = append(nodes, &node{kind: nodeParagraph, text: strings.Join(para, "\n")})
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if strings.TrimSpace(line) == "" {
+			flushPara()
+			continue
+		}
+
+		// Setext headings underline the paragraph that precedes them
+		if len(para) > 0 {
+			if m := setextRe.FindStringSubmatch(line); m != nil {
+				level := 1
+				if m[1][0] == '-' {
+					level = 2
+				}
+				nodes = append(nodes, &node{kind: nodeHeading, level: level, text: strings.Join(para, "\n")})
+				para = nil
+				continue
+			}
+		}
+
+		if m := fenceRe.FindStringSubmatch(line); m != nil && !(m[2][0] == '`' && strings.Contains(m[3], "`")) {
+			flushPara()
+			indent, fence := len(m[1]), m[2]
+			code := &node{kind: nodeCode}
+			for i++; i < len(lines); i++ {
+				trimmed := strings.TrimSpace(lines[i])
+				if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+					break
+				}
+				code.lines = append(code.lines, trimIndent(lines[i], indent))
+			}
+			nodes = append(nodes, code)
+			continue
+		}
+
+		if m := atxHeadingRe.FindStringSubmatch(line); m != nil {
+			flushPara()
+			nodes = append(nodes, &node{kind: nodeHeading, level: len(m[1]), text: strings.TrimSpace(m[2])})
+			continue
+		}
+
+		if thematicRe.MatchString(line) {
+			flushPara()
+			nodes = append(nodes, &node{kind: nodeRule})
+			continue
+		}
+
+		if quoteRe.MatchString(line) {
+			flushPara()
+			var inner []string
+			for ; i < len(lines); i++ {
+				if m := quoteRe.FindStringSubmatch(lines[i]); m != nil {
+					inner = append(inner, m[1])
+					continue
+				}
+				// Lazy continuation lines extend the quoted paragraph
+				if strings.TrimSpace(lines[i]) != "" && len(inner) > 0 && strings.TrimSpace(inner[len(inner)-1]) != "" && !startsBlock(lines[i]) {
+					inner = append(inner, lines[i])
+					continue
+				}
+				break
+			}
+			i--
+			nodes = append(nodes, &node{kind: nodeQuote, children: parseBlocks(inner)})
+			continue
+		}
+
+		if m := listItemRe.FindStringSubmatch(line); m != nil && (len(para) == 0 || canInterruptParagraph(m)) {
+			flushPara()
+			list, next := parseList(lines, i)
+			nodes = append(nodes, list)
+			i = next - 1
+			continue
+		}
+
+		if len(para) == 0 && i+1 < len(lines) && strings.Contains(line, "|") && tableDelimRe.MatchString(lines[i+1]) {
+			header := splitTableRow(line)
+			align := parseAlignment(lines[i+1])
+			if len(header) == len(align) {
+				table := &node{kind: nodeTable, header: header, align: align}
+				for i += 2; i < len(lines); i++ {
+					if strings.TrimSpace(lines[i]) == "" || startsBlock(lines[i]) {
+						break
+					}
+					table.rows = append(table.rows, splitTableRow(lines[i]))
+				}
+				i--
+				nodes = append(nodes, table)
+				continue
+			}
+		}
+
+		if len(para) == 0 && indentedCodeRe.MatchString(line) {
+			code := &node{kind: nodeCode}
+			for ; i < len(lines); i++ {
+				if strings.TrimSpace(lines[i]) == "" {
+					code.lines = append(code.lines, "")
+					continue
+				}
+				if !indentedCodeRe.MatchString(lines[i]) {
+					break
+				}
+				code.lines = append(code.lines, trimIndent(lines[i], 4))
+			}
+			i--
+			// Trailing blank lines are not part of the code block
+			for len(code.lines) > 0 && code.lines[len(code.lines)-1] == "" {
+				code.lines = code.lines[:len(code.lines)-1]
+			}
+			nodes = append(nodes, code)
+			continue
+		}
+
+		para = append(para, strings.TrimLeft(line, " \t"))
+	}
+	flushPara()
+
+	return nodes
+}
+
+// parseList parses the list starting at lines[start]. It returns the list and the index of
+// the first line after it.
+func parseList(lines []string, start int) (*node, int) {
+	first := listItemRe.FindStringSubmatch(lines[start])
+	marker := first[2]
+	ordered := marker[0] >= '0' && marker[0] <= '9'
+
+	list := &node{kind: nodeList, ordered: ordered, start: 1}
+	if ordered {
+		list.start, _ = strconv.Atoi(marker[:len(marker)-1])
+	}
+
+	i := start
+	for i < len(lines) {
+		m := listItemRe.FindStringSubmatch(lines[i])
+		if m == nil || !sameListType(marker, m[2]) {
+			break
+		}
+
+		// Content of the item is indented to the column after the marker
+		contentIndent := len(m[1]) + len(m[2]) + len(m[3])
+		if len(m[3]) > 4 || m[4] == "" {
+			contentIndent = len(m[1]) + len(m[2]) + 1
+		}
+
+		itemLines := []string{m[4]}
+		if len(m[3]) > 4 {
+			itemLines[0] = strings.Repeat(" ", len(m[3])-1) + m[4]
+		}
+		blank := false
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				blank = true
+				itemLines = append(itemLines, "")
+				continue
+			}
+			if leadingSpaces(line) >= contentIndent {
+				blank = false
+				itemLines = append(itemLines, trimIndent(line, contentIndent))
+				continue
+			}
+			// Lazy continuation of the item paragraph
+			if !blank && !startsBlock(line) && !listItemRe.MatchString(line) {
+				itemLines = append(itemLines, strings.TrimLeft(line, " \t"))
+				continue
+			}
+			break
+		}
+
+		item := &listItem{}
+		if tm := taskMarkerRe.FindStringSubmatch(itemLines[0]); tm != nil {
+			done := tm[1] != " "
+			item.task = &done
+			itemLines[0] = itemLines[0][len(tm[0]):]
+		}
+		item.children = parseBlocks(itemLines)
+		list.items = append(list.items, item)
+
+		// A blank line followed by content that is not a list item ends the list
+		if i < len(lines) && blank && !listItemRe.MatchString(lines[i]) {
+			break
+		}
+	}
+
+	return list, i
+}
+
+// startsBlock reports whether a line starts a block that interrupts a paragraph.
+func startsBlock(line string) bool {
+	if atxHeadingRe.MatchString(line) || thematicRe.MatchString(line) || quoteRe.MatchString(line) {
+		return true
+	}
+	if m := fenceRe.FindStringSubmatch(line); m != nil {
+		return true
+	}
+	if m := listItemRe.FindStringSubmatch(line); m != nil {
+		return canInterruptParagraph(m)
+	}
+	return false
+}
+
+// canInterruptParagraph reports whether a list item match may start a list directly after a
+// paragraph line. Only empty items and ordered lists starting at 1 are excluded, as in CommonMark.
+func canInterruptParagraph(m []string) bool {
+	if m[4] == "" {
+		return false
+	}
+	marker := m[2]
+	if marker[0] >= '0' && marker[0] <= '9' {
+		return marker[:len(marker)-1] == "1"
+	}
+	return true
+}
+
+// sameListType reports whether two list markers belong to the same list.
+func sameListType(a, b string) bool {
+	aOrdered := a[0] >= '0' && a[0] <= '9'
+	bOrdered := b[0] >= '0' && b[0] <= '9'
+	if aOrdered != bOrdered {
+		return false
+	}
+	return a[len(a)-1] == b[len(b)-1]
+}
+
+// splitTableRow splits a GFM table row into its trimmed cells. Escaped pipes are kept in the cell text.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var (
+		cells []string
+		cell  strings.Builder
+		code  bool
+	)
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '`':
+			code = !code
+			cell.WriteByte('`')
+		case line[i] == '|' && !code:
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	cells = append(cells, strings.TrimSpace(cell.String()))
+
+	return cells
+}
+
+// parseAlignment parses the delimiter row of a GFM table into "left", "center", "right" or "".
+func parseAlignment(line string) []string {
+	var align []string
+	for _, cell := range splitTableRow(line) {
+		left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			align = append(align, "center")
+		case right:
+			align = append(align, "right")
+		case left:
+			align = append(align, "left")
+		default:
+			align = append(align, "")
+		}
+	}
+	return align
+}
+
+// leadingSpaces counts the indentation of a line, expanding tabs to four columns.
+func leadingSpaces(line string) int {
+	n := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			n++
+		case '\t':
+			n += 4 - n%4
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// trimIndent removes up to n columns of indentation from a line.
+func trimIndent(line string, n int) string {
+	col := 0
+	for i, r := range line {
+		if col >= n {
+			return line[i:]
+		}
+		switch r {
+		case ' ':
+			col++
+		case '\t':
+			col += 4 - col%4
+			if col > n {
+				return strings.Repeat(" ", col-n) + line[i+1:]
+			}
+		default:
+			return line[i:]
+		}
+	}
+	return ""
+}
+
+// inline is a piece of inline Markdown content: formatted text, a hard break or an image.
+type inline struct {
+	text string
+
+	bold, italic, strike, code bool
+
+	// href is the destination of a link
+	href string
+
+	// image marks an image; src is its source and text its alternative text
+	image bool
+	src   string
+
+	// hardBreak marks a line break
+	hardBreak bool
+}
+
+// inlineFormat is the formatting state applied while parsing nested inline content.
+type inlineFormat struct {
+	bold, italic, strike bool
+	href                 string
+}
+
+var autolinkRe = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^\s<>]*|[a-zA-Z0-9.!#$%&'*+/=?^_{|}~-]+@[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)+)>`)
+var bareURLRe = regexp.MustCompile(`^(?:https?://|www\.)[^\s<]*`)
+
+// parseInlines parses Markdown inline content.
+func parseInlines(text string) []inline {
+	return parseInlinesWith(text, inlineFormat{})
+}
+
+func parseInlinesWith(text string, format inlineFormat) []inline {
+	var (
+		result []inline
+		buf    strings.Builder
+	)
+
+	flush := func() {
+		if buf.Len() > 0 {
+			result = append(result, inline{
+				text:   buf.String(),
+				bold:   format.bold,
+				italic: format.italic,
+				strike: format.strike,
+				href:   format.href,
+			})
+			buf.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+
+		switch {
+		case c == '\\' && i+1 < len(text) && text[i+1] == '\n':
+			flush()
+			result = append(result, inline{hardBreak: true})
+			i += 2
+			continue
+
+		case c == '\\' && i+1 < len(text) && isASCIIPunct(text[i+1]):
+			buf.WriteByte(text[i+1])
+			i += 2
+			continue
+
+		case c == '\n':
+			// Two or more trailing spaces make a hard line break
+			content := buf.String()
+			trimmed := strings.TrimRight(content, " ")
+			buf.Reset()
+			buf.WriteString(trimmed)
+			if len(content)-len(trimmed) >= 2 {
+				flush()
+				result = append(result, inline{hardBreak: true})
+			} else {
+				buf.WriteByte(' ')
+			}
+			i++
+			for i < len(text) && text[i] == ' ' {
+				i++
+			}
+			continue
+
+		case c == '`':
+			n := runLength(text, i, '`')
+			if end := findBacktickCloser(text, i+n, n); end >= 0 {
+				flush()
+				code := strings.ReplaceAll(text[i+n:end], "\n", " ")
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+					code = code[1 : len(code)-1]
+				}
+				result = append(result, inline{text: code, code: true, bold: format.bold, italic: format.italic, strike: format.strike, href: format.href})
+				i = end + n
+				continue
+			}
+			buf.WriteString(text[i : i+n])
+			i += n
+			continue
+
+		case c == '!' && i+1 < len(text) && text[i+1] == '[':
+			if label, dest, end, ok := parseLink(text, i+1); ok {
+				flush()
+				result = append(result, inline{image: true, src: dest, text: plainInline(label), href: format.href})
+				i = end
+				continue
+			}
+
+		case c == '[' && format.href == "":
+			if label, dest, end, ok := parseLink(text, i); ok {
+				flush()
+				inner := format
+				inner.href = dest
+				result = append(result, parseInlinesWith(label, inner)...)
+				i = end
+				continue
+			}
+
+		case c == '<':
+			if m := autolinkRe.FindStringSubmatch(text[i:]); m != nil && format.href == "" {
+				flush()
+				dest := m[1]
+				if strings.Contains(dest, "@") && !strings.Contains(dest, ":") {
+					dest = "mailto:" + dest
+				}
+				result = append(result, inline{text: m[1], href: dest, bold: format.bold, italic: format.italic, strike: format.strike})
+				i += len(m[0])
+				continue
+			}
+
+		case (c == 'h' || c == 'w') && format.href == "" && (i == 0 || !isAlnum(text[i-1])):
+			if m := bareURLRe.FindString(text[i:]); m != "" {
+				m = strings.TrimRight(m, ".,:;!?\"')*_~")
+				if len(m) > len("www.") {
+					flush()
+					dest := m
+					if strings.HasPrefix(dest, "www.") {
+						dest = "http://" + dest
+					}
+					result = append(result, inline{text: m, href: dest, bold: format.bold, italic: format.italic, strike: format.strike})
+					i += len(m)
+					continue
+				}
+			}
+
+		case c == '~' && strings.HasPrefix(text[i:], "~~"):
+			if end := findCloser(text, i+2, "~~"); end >= 0 {
+				flush()
+				inner := format
+				inner.strike = true
+				result = append(result, parseInlinesWith(text[i+2:end], inner)...)
+				i = end + 2
+				continue
+			}
+
+		case c == '*' || c == '_':
+			n := runLength(text, i, c)
+			if canOpen(text, i, n, c) {
+				if n >= 3 {
+					if end := findCloser(text, i+3, strings.Repeat(string(c), 3)); end >= 0 {
+						flush()
+						inner := format
+						inner.bold, inner.italic = true, true
+						result = append(result, parseInlinesWith(text[i+3:end], inner)...)
+						i = end + 3
+						continue
+					}
+				}
+				if n >= 2 {
+					if end := findCloser(text, i+2, strings.Repeat(string(c), 2)); end >= 0 {
+						flush()
+						inner := format
+						inner.bold = true
+						result = append(result, parseInlinesWith(text[i+2:end], inner)...)
+						i = end + 2
+						continue
+					}
+				}
+				if end := findCloser(text, i+1, string(c)); end >= 0 {
+					flush()
+					inner := format
+					inner.italic = true
+					result = append(result, parseInlinesWith(text[i+1:end], inner)...)
+					i = end + 1
+					continue
+				}
+			}
+			buf.WriteString(text[i : i+n])
+			i += n
+			continue
+		}
+
+		buf.WriteByte(c)
+		i++
+	}
+	flush()
+
+	return result
+}
+
+// parseLink parses a link or image starting at the opening bracket text[start]. It returns
+// the label, the destination and the index after the link.
+func parseLink(text string, start int) (string, string, int, bool) {
+	depth := 0
+	closeIdx := -1
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closeIdx = i
+			}
+		}
+		if closeIdx >= 0 {
+			break
+		}
+	}
+
+	if closeIdx < 0 || closeIdx+1 >= len(text) || text[closeIdx+1] != '(' {
+		return "", "", 0, false
+	}
+
+	depth = 0
+	for i := closeIdx + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				dest := strings.TrimSpace(text[closeIdx+2 : i])
+				// Drop an optional link title
+				if idx := strings.IndexAny(dest, " \t\n"); idx >= 0 {
+					dest = dest[:idx]
+				}
+				dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+				return text[start+1 : closeIdx], dest, i + 1, true
+			}
+		}
+	}
+
+	return "", "", 0, false
+}
+
+// findBacktickCloser returns the index of a backtick run of exactly n backticks at or after from.
+func findBacktickCloser(text string, from, n int) int {
+	for i := from; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+		run := runLength(text, i, '`')
+		if run == n {
+			return i
+		}
+		i += run
+	}
+	return -1
+}
+
+// findCloser returns the index of the delimiter run closing an emphasis that starts at from.
+func findCloser(text string, from int, delim string) int {
+	c := delim[0]
+	for i := from; i < len(text); i++ {
+		switch {
+		case text[i] == '\\':
+			i++
+		case text[i] == '`':
+			n := runLength(text, i, '`')
+			if end := findBacktickCloser(text, i+n, n); end >= 0 {
+				i = end + n - 1
+			} else {
+				i += n - 1
+			}
+		case strings.HasPrefix(text[i:], delim) && i > from:
+			n := runLength(text, i, c)
+			if c != '~' && n > len(delim) {
+				// A longer run closes a nested emphasis first
+				if canClose(text, i+n-len(delim), len(delim), c) && !canOpen(text, i, n, c) {
+					return i + n - len(delim)
+				}
+				i += n - 1
+				continue
+			}
+			if canClose(text, i, len(delim), c) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// canOpen reports whether a delimiter run can open emphasis.
+func canOpen(text string, i, n int, c byte) bool {
+	if i+n >= len(text) || isSpace(text[i+n]) {
+		return false
+	}
+	if c == '_' && i > 0 && isAlnum(text[i-1]) {
+		return false
+	}
+	return true
+}
+
+// canClose reports whether a delimiter run can close emphasis.
+func canClose(text string, i, n int, c byte) bool {
+	if i == 0 || isSpace(text[i-1]) {
+		return false
+	}
+	if c == '_' && i+n < len(text) && isAlnum(text[i+n]) {
+		return false
+	}
+	return true
+}
+
+func runLength(text string, i int, c byte) int {
+	n := 0
+	for i+n < len(text) && text[i+n] == c {
+		n++
+	}
+	return n
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isAlnum(c byte) bool {
+	return c < 0x80 && (unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)))
+}
+
+func isASCIIPunct(c byte) bool {
+	return c < 0x80 && unicode.IsPunct(rune(c)) || strings.IndexByte("$+<=>^`|~", c) >= 0
+}
+
+// plainInline returns the text of Markdown inline content without formatting.
+func plainInline(text string) string {
+	var sb strings.Builder
+	for _, in := range parseInlines(text) {
+		sb.WriteString(in.text)
+	}
+	return sb.String()
+}
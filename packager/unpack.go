@@ -127,6 +127,19 @@ func Unpack(content *[]byte) (*docx.RootDoc, error) {
 			}
 			delete(fileIndex, stylesPath)
 			rd.DocStyles = stylesObj
+		case constants.NumberingType:
+			if relation.Target == "" {
+				continue
+			}
+			numberingPath := path.Join(wordDir, relation.Target)
+
+			// Load Numbering
+			numberingObj, err := docx.LoadNumbering(numberingPath, fileIndex[numberingPath])
+			if err != nil {
+				return nil, err
+			}
+			// The stored part is kept, and written instead of the model while it is unchanged
+			rd.Numbering = numberingObj
 		}
 	}
 
@@ -0,0 +1,43 @@
+package ctypes
+
+import (
+	"encoding/xml"
+)
+
+// AltChunk imports external content, such as HTML, RTF, plain text or another
+// WordprocessingML document, stored in the part its relationship refers to. Word converts the
+// content when the document is opened.
+type AltChunk struct {
+	ID string `xml:"id,attr"` //Relationship to the imported part
+
+	// Whether the imported content keeps its own formatting
+	MatchSrc *OnOff `xml:"altChunkPr>matchSrc,omitempty"`
+}
+
+func (a AltChunk) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:altChunk"
+	start.Attr = nil
+
+	if a.ID != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "r:id"}, Value: a.ID})
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if a.MatchSrc != nil {
+		prStart := xml.StartElement{Name: xml.Name{Local: "w:altChunkPr"}}
+		if err := e.EncodeToken(prStart); err != nil {
+			return err
+		}
+		if err := a.MatchSrc.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:matchSrc"}}); err != nil {
+			return err
+		}
+		if err := e.EncodeToken(prStart.End()); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
@@ -0,0 +1,51 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAltChunk_MarshalXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    AltChunk
+		expected string
+	}{
+		{
+			name:     "ID only",
+			input:    AltChunk{ID: "rId4"},
+			expected: `<w:altChunk r:id="rId4"></w:altChunk>`,
+		},
+		{
+			name:     "With matchSrc",
+			input:    AltChunk{ID: "rId4", MatchSrc: OnOffFromBool(true)},
+			expected: `<w:altChunk r:id="rId4"><w:altChunkPr><w:matchSrc w:val="true"></w:matchSrc></w:altChunkPr></w:altChunk>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result strings.Builder
+			encoder := xml.NewEncoder(&result)
+
+			require.NoError(t, tt.input.MarshalXML(encoder, xml.StartElement{}))
+			require.NoError(t, encoder.Flush())
+			assert.Equal(t, tt.expected, result.String())
+		})
+	}
+}
+
+func TestAltChunk_UnmarshalXML(t *testing.T) {
+	input := `<w:altChunk xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:id="rId9">` +
+		`<w:altChunkPr><w:matchSrc/></w:altChunkPr></w:altChunk>`
+
+	var chunk AltChunk
+	require.NoError(t, xml.Unmarshal([]byte(input), &chunk))
+	assert.Equal(t, "rId9", chunk.ID)
+	assert.True(t, chunk.MatchSrc.Bool())
+}
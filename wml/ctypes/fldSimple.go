@@ -0,0 +1,83 @@
+package ctypes
+
+import (
+	"encoding/xml"
+
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// SimpleField represents a field whose instruction is held in an attribute (w:fldSimple).
+// The content of the element is the current result of the field.
+type SimpleField struct {
+	// Field Codes
+	Instr string `xml:"instr,attr"`
+
+	// Field Should Not Be Recalculated
+	Lock *OnOff `xml:"fldLock,attr,omitempty"`
+
+	// Field Result Invalidated
+	Dirty *OnOff `xml:"dirty,attr,omitempty"`
+
+	// Field Result
+	Children []ParagraphChild
+}
+
+// MarshalXML implements xml.Marshaler for SimpleField
+func (f SimpleField) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:fldSimple"
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:instr"}, Value: f.Instr})
+
+	if f.Lock != nil && f.Lock.Val != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:fldLock"}, Value: string(*f.Lock.Val)})
+	}
+
+	if f.Dirty != nil && f.Dirty.Val != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:dirty"}, Value: string(*f.Dirty.Val)})
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if err := marshalParagraphChildren(e, f.Children); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// UnmarshalXML implements xml.Unmarshaler for SimpleField
+func (f *SimpleField) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "instr":
+			f.Instr = attr.Value
+		case "fldLock":
+			val := stypes.OnOff(attr.Value)
+			f.Lock = &OnOff{Val: &val}
+		case "dirty":
+			val := stypes.OnOff(attr.Value)
+			f.Dirty = &OnOff{Val: &val}
+		}
+	}
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			child, ok, err := unmarshalParagraphChild(d, elem)
+			if err != nil {
+				return err
+			}
+			if ok {
+				f.Children = append(f.Children, child)
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
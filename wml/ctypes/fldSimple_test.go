@@ -0,0 +1,70 @@
+package ctypes
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/bfoley13/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimpleField_MarshalXML(t *testing.T) {
+	dirty := stypes.OnOffTrue
+
+	tests := []struct {
+		name     string
+		field    SimpleField
+		expected string
+	}{
+		{
+			name:     "Instruction only",
+			field:    SimpleField{Instr: " PAGE "},
+			expected: `<w:fldSimple w:instr=" PAGE "></w:fldSimple>`,
+		},
+		{
+			name: "Dirty field with result",
+			field: SimpleField{
+				Instr: "NUMPAGES",
+				Dirty: &OnOff{Val: &dirty},
+				Children: []ParagraphChild{
+					{Run: &Run{Children: []RunChild{{Text: TextFromString("3")}}}},
+				},
+			},
+			expected: `<w:fldSimple w:instr="NUMPAGES" w:dirty="true"><w:r><w:t>3</w:t></w:r></w:fldSimple>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder := xml.NewEncoder(&buf)
+			require.NoError(t, tt.field.MarshalXML(encoder, xml.StartElement{}))
+			require.NoError(t, encoder.Flush())
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestSimpleField_Paragraph_RoundTrip(t *testing.T) {
+	input := `<w:p xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:r><w:t>Page </w:t></w:r>` +
+		`<w:fldSimple w:instr=" PAGE " w:fldLock="1"><w:r><w:t>2</w:t></w:r></w:fldSimple>` +
+		`</w:p>`
+
+	var p Paragraph
+	require.NoError(t, xml.Unmarshal([]byte(input), &p))
+	require.Len(t, p.Children, 2)
+
+	field := p.Children[1].SimpleField
+	require.NotNil(t, field)
+	assert.Equal(t, " PAGE ", field.Instr)
+	assert.True(t, field.Lock.Bool())
+	require.Len(t, field.Children, 1)
+	assert.Equal(t, "2", field.Children[0].Run.Children[0].Text.Text)
+
+	output, err := xml.Marshal(p)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), `<w:fldSimple w:instr=" PAGE " w:fldLock="1"><w:r><w:t>2</w:t></w:r></w:fldSimple>`)
+}
@@ -0,0 +1,242 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// FootnoteReference represents a footnote or endnote reference mark within a run
+// (w:footnoteReference / w:endnoteReference)
+type FootnoteReference struct {
+	// Suppress Footnote/Endnote Reference Mark
+	CustomMarkFollows *stypes.OnOff `xml:"customMarkFollows,attr,omitempty"`
+
+	// Footnote/Endnote ID Reference
+	ID int `xml:"id,attr"`
+}
+
+// Footnotes represents the footnotes or endnotes part of a document (w:footnotes / w:endnotes)
+type Footnotes struct {
+	RelativePath string `xml:"-"`
+	Attr         []xml.Attr
+
+	// Endnotes marks the part as an endnotes part (w:endnotes)
+	Endnotes bool `xml:"-"`
+
+	Notes []Footnote
+}
+
+// Footnote represents a single footnote or endnote (w:footnote / w:endnote)
+type Footnote struct {
+	// Footnote/Endnote Type (separator, continuationSeparator, continuationNotice); nil for normal notes
+	Type *string `xml:"type,attr,omitempty"`
+
+	// Footnote/Endnote ID
+	ID int `xml:"id,attr"`
+
+	// Block level content of the note
+	Contents []TCBlockContent
+}
+
+// MarshalXML implements xml.Marshaler for FootnoteReference
+func (f FootnoteReference) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if start.Name.Local == "" {
+		start.Name.Local = "w:footnoteReference"
+	}
+
+	if f.CustomMarkFollows != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:customMarkFollows"}, Value: string(*f.CustomMarkFollows)})
+	}
+
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(f.ID)})
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// UnmarshalXML implements xml.Unmarshaler for FootnoteReference
+func (f *FootnoteReference) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "id":
+			id, err := strconv.Atoi(attr.Value)
+			if err != nil {
+				return err
+			}
+			f.ID = id
+		case "customMarkFollows":
+			val := stypes.OnOff(attr.Value)
+			f.CustomMarkFollows = &val
+		}
+	}
+
+	return d.Skip()
+}
+
+// MarshalXML implements xml.Marshaler for Footnotes
+func (f *Footnotes) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:footnotes"
+	noteName := "w:footnote"
+	if f.Endnotes {
+		start.Name.Local = "w:endnotes"
+		noteName = "w:endnote"
+	}
+
+	if len(f.Attr) == 0 {
+		start.Attr = append(start.Attr,
+			xml.Attr{Name: xml.Name{Local: "xmlns:w"}, Value: constants.WMLNamespace},
+			xml.Attr{Name: xml.Name{Local: "xmlns:r"}, Value: constants.XMLNS_R},
+		)
+	} else {
+		start.Attr = f.Attr
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, note := range f.Notes {
+		if err := note.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: noteName}}); err != nil {
+			return fmt.Errorf("%s: %w", noteName, err)
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML implements xml.Unmarshaler for Footnotes
+func (f *Footnotes) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	f.Endnotes = start.Name.Local == "endnotes"
+	f.Attr = make([]xml.Attr, 0, len(start.Attr))
+
+	for _, attr := range start.Attr {
+		ns := attr.Name.Space
+		if ns != "xmlns" {
+			local, ok := constants.NSToLocal[ns]
+			ns = local
+			if !ok {
+				continue
+			}
+		}
+
+		f.Attr = append(f.Attr, xml.Attr{
+			Name:  xml.Name{Local: fmt.Sprintf("%s:%s", ns, attr.Name.Local)},
+			Value: attr.Value,
+		})
+	}
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "footnote", "endnote":
+				note := Footnote{}
+				if err := d.DecodeElement(&note, &elem); err != nil {
+					return err
+				}
+				f.Notes = append(f.Notes, note)
+			default:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// NoteByID returns the note with the given ID, or nil if it does not exist.
+func (f *Footnotes) NoteByID(id int) *Footnote {
+	for i := range f.Notes {
+		if f.Notes[i].ID == id {
+			return &f.Notes[i]
+		}
+	}
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler for Footnote
+func (f Footnote) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if start.Name.Local == "" {
+		start.Name.Local = "w:footnote"
+	}
+
+	if f.Type != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:type"}, Value: *f.Type})
+	}
+
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(f.ID)})
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, content := range f.Contents {
+		if err := content.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// UnmarshalXML implements xml.Unmarshaler for Footnote
+func (f *Footnote) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "id":
+			id, err := strconv.Atoi(attr.Value)
+			if err != nil {
+				return err
+			}
+			f.ID = id
+		case "type":
+			val := attr.Value
+			f.Type = &val
+		}
+	}
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "p":
+				para := &Paragraph{}
+				if err := d.DecodeElement(para, &elem); err != nil {
+					return err
+				}
+				f.Contents = append(f.Contents, TCBlockContent{Paragraph: para})
+			case "tbl":
+				table := &Table{}
+				if err := d.DecodeElement(table, &elem); err != nil {
+					return err
+				}
+				f.Contents = append(f.Contents, TCBlockContent{Table: table})
+			default:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestFootnotes_UnmarshalXML(t *testing.T) {
+	input := `<w:footnotes xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:footnote w:type="separator" w:id="-1"><w:p><w:r><w:separator/></w:r></w:p></w:footnote>` +
+		`<w:footnote w:id="1"><w:p><w:r><w:rPr><w:rStyle w:val="FootnoteReference"/></w:rPr><w:footnoteRef/></w:r><w:r><w:t xml:space="preserve"> A note.</w:t></w:r></w:p></w:footnote>` +
+		`</w:footnotes>`
+
+	var notes Footnotes
+	if err := xml.Unmarshal([]byte(input), &notes); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(notes.Notes) != 2 {
+		t.Fatalf("Expected 2 notes, got %d", len(notes.Notes))
+	}
+
+	if notes.Notes[0].Type == nil || *notes.Notes[0].Type != "separator" {
+		t.Errorf("Expected separator type, got %v", notes.Notes[0].Type)
+	}
+
+	note := notes.NoteByID(1)
+	if note == nil {
+		t.Fatalf("Expected note with ID 1")
+	}
+
+	if len(note.Contents) != 1 || note.Contents[0].Paragraph == nil {
+		t.Fatalf("Expected a single paragraph in note, got %+v", note.Contents)
+	}
+
+	if notes.NoteByID(7) != nil {
+		t.Errorf("Expected nil for unknown note ID")
+	}
+}
+
+func TestFootnoteReference_Run(t *testing.T) {
+	input := `<w:r xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:footnoteReference w:id="3"/><w:endnoteReference w:id="4"/></w:r>`
+
+	var run Run
+	if err := xml.Unmarshal([]byte(input), &run); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(run.Children) != 2 {
+		t.Fatalf("Expected 2 run children, got %d", len(run.Children))
+	}
+
+	if run.Children[0].FootnoteReference == nil || run.Children[0].FootnoteReference.ID != 3 {
+		t.Errorf("Expected footnote reference 3, got %+v", run.Children[0])
+	}
+
+	if run.Children[1].EndnoteReference == nil || run.Children[1].EndnoteReference.ID != 4 {
+		t.Errorf("Expected endnote reference 4, got %+v", run.Children[1])
+	}
+
+	var result strings.Builder
+	encoder := xml.NewEncoder(&result)
+	if err := run.MarshalXML(encoder, xml.StartElement{}); err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+	encoder.Flush()
+
+	expected := `<w:r><w:footnoteReference w:id="3"></w:footnoteReference><w:endnoteReference w:id="4"></w:endnoteReference></w:r>`
+	if result.String() != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, result.String())
+	}
+}
+
+func TestFootnote_MarshalXML(t *testing.T) {
+	notes := Footnotes{
+		Attr:     []xml.Attr{{Name: xml.Name{Local: "xmlns:w"}, Value: "http://schemas.openxmlformats.org/wordprocessingml/2006/main"}},
+		Endnotes: true,
+		Notes: []Footnote{
+			{ID: 1, Contents: []TCBlockContent{{Paragraph: AddParagraph("Note")}}},
+		},
+	}
+
+	var result strings.Builder
+	encoder := xml.NewEncoder(&result)
+	if err := notes.MarshalXML(encoder, xml.StartElement{}); err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+	encoder.Flush()
+
+	expected := `<w:endnotes xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:endnote w:id="1"><w:p><w:r><w:t>Note</w:t></w:r></w:p></w:endnote></w:endnotes>`
+	if result.String() != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, result.String())
+	}
+}
@@ -0,0 +1,102 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/bfoley13/godocx/common/constants"
+)
+
+// HdrFtr represents the content of a header or footer part (w:hdr / w:ftr)
+type HdrFtr struct {
+	RelativePath string `xml:"-"`
+	Attr         []xml.Attr
+
+	// Footer marks the part as a footer part (w:ftr)
+	Footer bool `xml:"-"`
+
+	// Block level content of the header or footer
+	Contents []TCBlockContent
+}
+
+// MarshalXML implements xml.Marshaler for HdrFtr
+func (h *HdrFtr) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:hdr"
+	if h.Footer {
+		start.Name.Local = "w:ftr"
+	}
+
+	if len(h.Attr) == 0 {
+		start.Attr = append(start.Attr,
+			xml.Attr{Name: xml.Name{Local: "xmlns:w"}, Value: constants.WMLNamespace},
+			xml.Attr{Name: xml.Name{Local: "xmlns:r"}, Value: constants.XMLNS_R},
+		)
+	} else {
+		start.Attr = h.Attr
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, content := range h.Contents {
+		if err := content.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML implements xml.Unmarshaler for HdrFtr
+func (h *HdrFtr) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	h.Footer = start.Name.Local == "ftr"
+	h.Attr = make([]xml.Attr, 0, len(start.Attr))
+
+	for _, attr := range start.Attr {
+		ns := attr.Name.Space
+		if ns != "xmlns" {
+			local, ok := constants.NSToLocal[ns]
+			ns = local
+			if !ok {
+				continue
+			}
+		}
+
+		h.Attr = append(h.Attr, xml.Attr{
+			Name:  xml.Name{Local: fmt.Sprintf("%s:%s", ns, attr.Name.Local)},
+			Value: attr.Value,
+		})
+	}
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "p":
+				para := &Paragraph{}
+				if err := d.DecodeElement(para, &elem); err != nil {
+					return err
+				}
+				h.Contents = append(h.Contents, TCBlockContent{Paragraph: para})
+			case "tbl":
+				table := &Table{}
+				if err := d.DecodeElement(table, &elem); err != nil {
+					return err
+				}
+				h.Contents = append(h.Contents, TCBlockContent{Table: table})
+			default:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
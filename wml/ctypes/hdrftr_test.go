@@ -0,0 +1,32 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestHdrFtr_Roundtrip(t *testing.T) {
+	input := `<w:ftr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:p><w:r><w:t>Page</w:t></w:r></w:p></w:ftr>`
+
+	var footer HdrFtr
+	if err := xml.Unmarshal([]byte(input), &footer); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if !footer.Footer {
+		t.Errorf("Expected footer part")
+	}
+
+	if len(footer.Contents) != 1 || footer.Contents[0].Paragraph == nil {
+		t.Fatalf("Expected one paragraph, got %+v", footer.Contents)
+	}
+
+	output, err := xml.Marshal(&footer)
+	if err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+
+	if string(output) != input {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", input, output)
+	}
+}
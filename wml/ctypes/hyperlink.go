@@ -0,0 +1,112 @@
+package ctypes
+
+import (
+	"encoding/xml"
+
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// Hyperlink represents a hyperlink (w:hyperlink). An external link targets the relationship
+// given by ID, an internal link targets the bookmark given by Anchor.
+type Hyperlink struct {
+	// Hyperlink Target Relationship ID
+	ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+
+	// Hyperlink Anchor
+	Anchor string `xml:"anchor,attr,omitempty"`
+
+	// Associated String
+	Tooltip string `xml:"tooltip,attr,omitempty"`
+
+	// Add To Viewed Hyperlinks
+	History *OnOff `xml:"history,attr,omitempty"`
+
+	// Hyperlink Text, written before Children. Hyperlinks read from a document hold all
+	// their content in Children.
+	Run *Run
+
+	// Hyperlink Content: runs, fields, content controls and bookmarks
+	Children []ParagraphChild
+}
+
+// Content returns the content of the hyperlink: Run, when set, followed by Children.
+func (h *Hyperlink) Content() []ParagraphChild {
+	if h.Run == nil {
+		return h.Children
+	}
+	return append([]ParagraphChild{{Run: h.Run}}, h.Children...)
+}
+
+// MarshalXML implements xml.Marshaler for Hyperlink
+func (h Hyperlink) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:hyperlink"
+
+	if h.ID != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "r:id"}, Value: h.ID})
+	}
+
+	if h.Anchor != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:anchor"}, Value: h.Anchor})
+	}
+
+	if h.Tooltip != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:tooltip"}, Value: h.Tooltip})
+	}
+
+	if h.History != nil && h.History.Val != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:history"}, Value: string(*h.History.Val)})
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if h.Run != nil {
+		if err := h.Run.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:r"}}); err != nil {
+			return err
+		}
+	}
+
+	if err := marshalParagraphChildren(e, h.Children); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// UnmarshalXML implements xml.Unmarshaler for Hyperlink
+func (h *Hyperlink) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "id":
+			h.ID = attr.Value
+		case "anchor":
+			h.Anchor = attr.Value
+		case "tooltip":
+			h.Tooltip = attr.Value
+		case "history":
+			val := stypes.OnOff(attr.Value)
+			h.History = &OnOff{Val: &val}
+		}
+	}
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			child, ok, err := unmarshalParagraphChild(d, elem)
+			if err != nil {
+				return err
+			}
+			if ok {
+				h.Children = append(h.Children, child)
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHyperlink_MarshalXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     Hyperlink
+		expected string
+	}{
+		{
+			name:     "External",
+			link:     Hyperlink{ID: "rId4", Run: &Run{Children: []RunChild{{Text: TextFromString("site")}}}},
+			expected: `<w:hyperlink r:id="rId4"><w:r><w:t>site</w:t></w:r></w:hyperlink>`,
+		},
+		{
+			name: "Internal",
+			link: Hyperlink{
+				Anchor:  "_Toc1",
+				Tooltip: "Go to Intro",
+				History: OnOffFromBool(true),
+				Children: []ParagraphChild{
+					{Run: &Run{Children: []RunChild{{Text: TextFromString("Intro")}}}},
+					{Run: &Run{Children: []RunChild{{Tab: &Empty{}}}}},
+				},
+			},
+			expected: `<w:hyperlink w:anchor="_Toc1" w:tooltip="Go to Intro" w:history="true"><w:r><w:t>Intro</w:t></w:r><w:r><w:tab></w:tab></w:r></w:hyperlink>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := xml.Marshal(tt.link)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(output))
+		})
+	}
+}
+
+func TestHyperlink_UnmarshalXML(t *testing.T) {
+	input := `<w:hyperlink xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" ` +
+		`r:id="rId7" w:anchor="_Toc2" w:tooltip="See results" w:history="1">` +
+		`<w:r><w:t>Results</w:t></w:r><w:r><w:tab/></w:r></w:hyperlink>`
+
+	var link Hyperlink
+	require.NoError(t, xml.Unmarshal([]byte(input), &link))
+
+	assert.Equal(t, "rId7", link.ID)
+	assert.Equal(t, "_Toc2", link.Anchor)
+	assert.Equal(t, "See results", link.Tooltip)
+	assert.True(t, link.History.Bool())
+	require.Len(t, link.Children, 2)
+	assert.Equal(t, "Results", link.Children[0].Run.Children[0].Text.Text)
+	assert.NotNil(t, link.Children[1].Run.Children[0].Tab)
+}
+
+func TestHyperlink_Content(t *testing.T) {
+	first := &Run{Children: []RunChild{{Text: TextFromString("Go")}}}
+	second := &Run{Children: []RunChild{{Text: TextFromString("pher")}}}
+
+	link := Hyperlink{Children: []ParagraphChild{{Run: second}}}
+	require.Len(t, link.Content(), 1)
+	assert.Same(t, second, link.Content()[0].Run)
+
+	link.Run = first
+	content := link.Content()
+	require.Len(t, content, 2)
+	assert.Same(t, first, content[0].Run)
+	assert.Same(t, second, content[1].Run)
+	assert.Len(t, link.Children, 1)
+}
@@ -0,0 +1,499 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+
+	"github.com/bfoley13/godocx/common/constants"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+var defaultNumberingNSAttrs = map[string]string{
+	"xmlns:w":      "http://schemas.openxmlformats.org/wordprocessingml/2006/main",
+	"xmlns:r":      "http://schemas.openxmlformats.org/officeDocument/2006/relationships",
+	"xmlns:mc":     "http://schemas.openxmlformats.org/markup-compatibility/2006",
+	"xmlns:w14":    "http://schemas.microsoft.com/office/word/2010/wordml",
+	"mc:Ignorable": "w14",
+}
+
+// Numbering Definitions (w:numbering)
+type Numbering struct {
+	RelativePath string `xml:"-"`
+	Attr         []xml.Attr
+
+	// Sequence
+
+	//1. Picture Numbering Symbol Definition
+	PicBullets []NumPicBullet
+
+	//2. Abstract Numbering Definition
+	AbstractNums []AbstractNum
+
+	//3. Numbering Definition Instance
+	Nums []Num
+
+	//4. Last Reviewed Abstract Numbering Definition
+	NumIDMacAtCleanup *DecimalNum
+}
+
+// Picture Numbering Symbol Definition
+//
+// The picture content is kept as raw XML since it is only referenced from levels by ID.
+type NumPicBullet struct {
+	ID    int    `xml:"numPicBulletId,attr"`
+	Inner []byte `xml:",innerxml"`
+}
+
+// Abstract Numbering Definition
+type AbstractNum struct {
+	// Abstract Numbering Definition ID
+	ID int `xml:"abstractNumId,attr"`
+
+	//1. Abstract Numbering Definition Identifier
+	Nsid *CTString `xml:"nsid,omitempty"`
+
+	//2. Abstract Numbering Definition Type
+	MultiLevelType *CTString `xml:"multiLevelType,omitempty"`
+
+	//3. Numbering Template Code
+	Tmpl *CTString `xml:"tmpl,omitempty"`
+
+	//4. Abstract Numbering Definition Name
+	Name *CTString `xml:"name,omitempty"`
+
+	//5. Numbering Style Definition
+	StyleLink *CTString `xml:"styleLink,omitempty"`
+
+	//6. Numbering Style Reference
+	NumStyleLink *CTString `xml:"numStyleLink,omitempty"`
+
+	//7. Numbering Level Definition
+	Levels []NumLevel `xml:"lvl,omitempty"`
+}
+
+// Numbering Level Definition
+type NumLevel struct {
+	// Numbering Level
+	ILvl int `xml:"ilvl,attr"`
+
+	// Template Code
+	Tplc *string `xml:"tplc,attr,omitempty"`
+
+	// Tentative Numbering
+	Tentative *stypes.OnOff `xml:"tentative,attr,omitempty"`
+
+	//1. Starting Value
+	Start *DecimalNum `xml:"start,omitempty"`
+
+	//2. Numbering Format
+	NumFmt *GenSingleStrVal[stypes.NumFmt] `xml:"numFmt,omitempty"`
+
+	//3. Restart Numbering Level Symbol
+	LvlRestart *DecimalNum `xml:"lvlRestart,omitempty"`
+
+	//4. Paragraph Style's Associated Numbering Level
+	PStyle *CTString `xml:"pStyle,omitempty"`
+
+	//5. Display All Levels Using Arabic Numerals
+	IsLgl *OnOff `xml:"isLgl,omitempty"`
+
+	//6. Content Between Numbering Symbol and Paragraph Text
+	Suffix *CTString `xml:"suff,omitempty"`
+
+	//7. Numbering Level Text
+	LvlText *CTString `xml:"lvlText,omitempty"`
+
+	//8. Picture Numbering Symbol Definition Reference
+	LvlPicBulletID *DecimalNum `xml:"lvlPicBulletId,omitempty"`
+
+	//9. Justification
+	LvlJc *GenSingleStrVal[stypes.Justification] `xml:"lvlJc,omitempty"`
+
+	//10. Numbering Level Associated Paragraph Properties
+	ParaProp *ParagraphProp `xml:"pPr,omitempty"`
+
+	//11. Numbering Symbol Run Properties
+	RunProp *RunProperty `xml:"rPr,omitempty"`
+}
+
+// Numbering Definition Instance
+type Num struct {
+	// Numbering Definition Instance ID
+	ID int `xml:"numId,attr"`
+
+	//1. Abstract Numbering Definition Reference
+	AbstractNumID *DecimalNum `xml:"abstractNumId,omitempty"`
+
+	//2. Numbering Level Definition Override
+	LvlOverrides []LvlOverride `xml:"lvlOverride,omitempty"`
+}
+
+// Numbering Level Definition Override
+type LvlOverride struct {
+	// Numbering Level ID
+	ILvl int `xml:"ilvl,attr"`
+
+	//1. Numbering Level Starting Value Override
+	StartOverride *DecimalNum `xml:"startOverride,omitempty"`
+
+	//2. Numbering Level Override Definition
+	Lvl *NumLevel `xml:"lvl,omitempty"`
+}
+
+// NewNumbering creates an empty numbering definitions part.
+func NewNumbering() *Numbering {
+	return &Numbering{}
+}
+
+func (n *Numbering) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:numbering"
+
+	if len(n.Attr) == 0 {
+		for key, value := range defaultNumberingNSAttrs {
+			attr := xml.Attr{Name: xml.Name{Local: key}, Value: value}
+			start.Attr = append(start.Attr, attr)
+		}
+	} else {
+		start.Attr = n.Attr
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, pb := range n.PicBullets {
+		if err := pb.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:numPicBullet"}}); err != nil {
+			return fmt.Errorf("numPicBullet: %w", err)
+		}
+	}
+
+	for _, an := range n.AbstractNums {
+		if err := an.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:abstractNum"}}); err != nil {
+			return fmt.Errorf("abstractNum: %w", err)
+		}
+	}
+
+	for _, num := range n.Nums {
+		if err := num.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:num"}}); err != nil {
+			return fmt.Errorf("num: %w", err)
+		}
+	}
+
+	if n.NumIDMacAtCleanup != nil {
+		if err := n.NumIDMacAtCleanup.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:numIdMacAtCleanup"}}); err != nil {
+			return fmt.Errorf("numIdMacAtCleanup: %w", err)
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (n *Numbering) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	n.Attr = make([]xml.Attr, 0, len(start.Attr))
+
+	for _, attr := range start.Attr {
+		ns := attr.Name.Space
+		if ns != "xmlns" {
+			local, ok := constants.NSToLocal[ns]
+			ns = local
+			if !ok {
+				continue
+			}
+		}
+
+		n.Attr = append(n.Attr, xml.Attr{
+			Name: xml.Name{
+				Local: fmt.Sprintf("%s:%s", ns, attr.Name.Local),
+			},
+			Value: attr.Value,
+		})
+	}
+
+loop:
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "numPicBullet":
+				pb := NumPicBullet{}
+				if err = d.DecodeElement(&pb, &elem); err != nil {
+					return err
+				}
+				n.PicBullets = append(n.PicBullets, pb)
+			case "abstractNum":
+				an := AbstractNum{}
+				if err = d.DecodeElement(&an, &elem); err != nil {
+					return err
+				}
+				n.AbstractNums = append(n.AbstractNums, an)
+			case "num":
+				num := Num{}
+				if err = d.DecodeElement(&num, &elem); err != nil {
+					return err
+				}
+				n.Nums = append(n.Nums, num)
+			case "numIdMacAtCleanup":
+				n.NumIDMacAtCleanup = &DecimalNum{}
+				if err = d.DecodeElement(n.NumIDMacAtCleanup, &elem); err != nil {
+					return err
+				}
+			default:
+				if err = d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			break loop
+		}
+	}
+
+	return nil
+}
+
+func (p NumPicBullet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:numPicBullet"
+	start.Attr = []xml.Attr{{Name: xml.Name{Local: "w:numPicBulletId"}, Value: strconv.Itoa(p.ID)}}
+
+	return e.EncodeElement(struct {
+		Inner []byte `xml:",innerxml"`
+	}{Inner: p.Inner}, start)
+}
+
+func (a AbstractNum) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:abstractNum"
+	start.Attr = []xml.Attr{{Name: xml.Name{Local: "w:abstractNumId"}, Value: strconv.Itoa(a.ID)}}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	ctElems := []struct {
+		elem    *CTString
+		XMLName string
+	}{
+		{a.Nsid, "w:nsid"},
+		{a.MultiLevelType, "w:multiLevelType"},
+		{a.Tmpl, "w:tmpl"},
+		{a.Name, "w:name"},
+		{a.StyleLink, "w:styleLink"},
+		{a.NumStyleLink, "w:numStyleLink"},
+	}
+
+	for _, entry := range ctElems {
+		if entry.elem == nil {
+			continue
+		}
+		if err := entry.elem.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: entry.XMLName}}); err != nil {
+			return fmt.Errorf("%s: %w", entry.XMLName, err)
+		}
+	}
+
+	for _, lvl := range a.Levels {
+		if err := lvl.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("lvl: %w", err)
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (l NumLevel) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:lvl"
+	start.Attr = []xml.Attr{{Name: xml.Name{Local: "w:ilvl"}, Value: strconv.Itoa(l.ILvl)}}
+
+	if l.Tplc != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:tplc"}, Value: *l.Tplc})
+	}
+
+	if l.Tentative != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:tentative"}, Value: string(*l.Tentative)})
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if l.Start != nil {
+		if err := l.Start.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:start"}}); err != nil {
+			return fmt.Errorf("start: %w", err)
+		}
+	}
+
+	if l.NumFmt != nil {
+		if err := l.NumFmt.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:numFmt"}}); err != nil {
+			return fmt.Errorf("numFmt: %w", err)
+		}
+	}
+
+	if l.LvlRestart != nil {
+		if err := l.LvlRestart.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvlRestart"}}); err != nil {
+			return fmt.Errorf("lvlRestart: %w", err)
+		}
+	}
+
+	if l.PStyle != nil {
+		if err := l.PStyle.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:pStyle"}}); err != nil {
+			return fmt.Errorf("pStyle: %w", err)
+		}
+	}
+
+	if l.IsLgl != nil {
+		if err := l.IsLgl.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:isLgl"}}); err != nil {
+			return fmt.Errorf("isLgl: %w", err)
+		}
+	}
+
+	if l.Suffix != nil {
+		if err := l.Suffix.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:suff"}}); err != nil {
+			return fmt.Errorf("suff: %w", err)
+		}
+	}
+
+	if l.LvlText != nil {
+		if err := l.LvlText.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvlText"}}); err != nil {
+			return fmt.Errorf("lvlText: %w", err)
+		}
+	}
+
+	if l.LvlPicBulletID != nil {
+		if err := l.LvlPicBulletID.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvlPicBulletId"}}); err != nil {
+			return fmt.Errorf("lvlPicBulletId: %w", err)
+		}
+	}
+
+	if l.LvlJc != nil {
+		if err := l.LvlJc.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvlJc"}}); err != nil {
+			return fmt.Errorf("lvlJc: %w", err)
+		}
+	}
+
+	if l.ParaProp != nil {
+		if err := l.ParaProp.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("pPr: %w", err)
+		}
+	}
+
+	if l.RunProp != nil {
+		if err := l.RunProp.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:rPr"}}); err != nil {
+			return fmt.Errorf("rPr: %w", err)
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (n Num) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:num"
+	start.Attr = []xml.Attr{{Name: xml.Name{Local: "w:numId"}, Value: strconv.Itoa(n.ID)}}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if n.AbstractNumID != nil {
+		if err := n.AbstractNumID.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:abstractNumId"}}); err != nil {
+			return fmt.Errorf("abstractNumId: %w", err)
+		}
+	}
+
+	for _, override := range n.LvlOverrides {
+		if err := override.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("lvlOverride: %w", err)
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (o LvlOverride) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:lvlOverride"
+	start.Attr = []xml.Attr{{Name: xml.Name{Local: "w:ilvl"}, Value: strconv.Itoa(o.ILvl)}}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if o.StartOverride != nil {
+		if err := o.StartOverride.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:startOverride"}}); err != nil {
+			return fmt.Errorf("startOverride: %w", err)
+		}
+	}
+
+	if o.Lvl != nil {
+		if err := o.Lvl.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("lvl: %w", err)
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// NumByID returns the numbering definition instance with the given numId, or nil.
+func (n *Numbering) NumByID(numID int) *Num {
+	for i := range n.Nums {
+		if n.Nums[i].ID == numID {
+			return &n.Nums[i]
+		}
+	}
+	return nil
+}
+
+// AbstractNumByID returns the abstract numbering definition with the given abstractNumId, or nil.
+func (n *Numbering) AbstractNumByID(abstractNumID int) *AbstractNum {
+	for i := range n.AbstractNums {
+		if n.AbstractNums[i].ID == abstractNumID {
+			return &n.AbstractNums[i]
+		}
+	}
+	return nil
+}
+
+// Level resolves the level definition used by paragraphs referencing numID at ilvl.
+//
+// A level override on the numbering instance takes precedence over the abstract definition,
+// and a start override replaces the starting value of the resolved level.
+// It returns nil if the numbering instance or level does not exist.
+func (n *Numbering) Level(numID int, ilvl int) *NumLevel {
+	num := n.NumByID(numID)
+	if num == nil {
+		return nil
+	}
+
+	var startOverride *DecimalNum
+	for _, override := range num.LvlOverrides {
+		if override.ILvl != ilvl {
+			continue
+		}
+		if override.Lvl != nil {
+			return override.Lvl
+		}
+		startOverride = override.StartOverride
+	}
+
+	if num.AbstractNumID == nil {
+		return nil
+	}
+
+	abstract := n.AbstractNumByID(num.AbstractNumID.Val)
+	if abstract == nil {
+		return nil
+	}
+
+	for _, lvl := range abstract.Levels {
+		if lvl.ILvl != ilvl {
+			continue
+		}
+		if startOverride != nil {
+			lvl.Start = startOverride
+		}
+		return &lvl
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+const numberingXML = `<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:abstractNum w:abstractNumId="0">` +
+	`<w:multiLevelType w:val="hybridMultilevel"></w:multiLevelType>` +
+	`<w:lvl w:ilvl="0"><w:start w:val="1"></w:start><w:numFmt w:val="bullet"></w:numFmt><w:lvlText w:val="•"></w:lvlText><w:lvlJc w:val="left"></w:lvlJc></w:lvl>` +
+	`<w:lvl w:ilvl="1"><w:start w:val="3"></w:start><w:numFmt w:val="lowerLetter"></w:numFmt><w:lvlText w:val="%2)"></w:lvlText></w:lvl>` +
+	`</w:abstractNum>` +
+	`<w:num w:numId="1"><w:abstractNumId w:val="0"></w:abstractNumId></w:num>` +
+	`<w:num w:numId="2"><w:abstractNumId w:val="0"></w:abstractNumId><w:lvlOverride w:ilvl="1"><w:startOverride w:val="7"></w:startOverride></w:lvlOverride></w:num>` +
+	`</w:numbering>`
+
+func TestNumbering_UnmarshalXML(t *testing.T) {
+	var numbering Numbering
+	if err := xml.Unmarshal([]byte(numberingXML), &numbering); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(numbering.AbstractNums) != 1 {
+		t.Fatalf("Expected 1 abstractNum, got %d", len(numbering.AbstractNums))
+	}
+
+	abstract := numbering.AbstractNums[0]
+	if len(abstract.Levels) != 2 {
+		t.Fatalf("Expected 2 levels, got %d", len(abstract.Levels))
+	}
+
+	if abstract.Levels[0].NumFmt == nil || abstract.Levels[0].NumFmt.Val != stypes.NumFmtBullet {
+		t.Errorf("Expected level 0 to be a bullet, got %v", abstract.Levels[0].NumFmt)
+	}
+
+	if abstract.Levels[1].LvlText == nil || abstract.Levels[1].LvlText.Val != "%2)" {
+		t.Errorf("Expected level 1 text %q, got %v", "%2)", abstract.Levels[1].LvlText)
+	}
+
+	if len(numbering.Nums) != 2 {
+		t.Fatalf("Expected 2 nums, got %d", len(numbering.Nums))
+	}
+
+	if numbering.Nums[1].ID != 2 || len(numbering.Nums[1].LvlOverrides) != 1 {
+		t.Errorf("Unexpected num instance: %+v", numbering.Nums[1])
+	}
+}
+
+func TestNumbering_MarshalXML(t *testing.T) {
+	numbering := Numbering{
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns:w"}, Value: "http://schemas.openxmlformats.org/wordprocessingml/2006/main"}},
+		AbstractNums: []AbstractNum{
+			{
+				ID:             0,
+				MultiLevelType: NewCTString("hybridMultilevel"),
+				Levels: []NumLevel{
+					{
+						ILvl:    0,
+						Start:   NewDecimalNum(1),
+						NumFmt:  NewGenSingleStrVal(stypes.NumFmtBullet),
+						LvlText: NewCTString("•"),
+						LvlJc:   NewGenSingleStrVal(stypes.JustificationLeft),
+					},
+					{
+						ILvl:    1,
+						Start:   NewDecimalNum(3),
+						NumFmt:  NewGenSingleStrVal(stypes.NumFmtLowerLetter),
+						LvlText: NewCTString("%2)"),
+					},
+				},
+			},
+		},
+		Nums: []Num{
+			{ID: 1, AbstractNumID: NewDecimalNum(0)},
+			{ID: 2, AbstractNumID: NewDecimalNum(0), LvlOverrides: []LvlOverride{{ILvl: 1, StartOverride: NewDecimalNum(7)}}},
+		},
+	}
+
+	output, err := xml.Marshal(&numbering)
+	if err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+
+	if strings.TrimSpace(string(output)) != numberingXML {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", numberingXML, output)
+	}
+}
+
+func TestNumbering_Level(t *testing.T) {
+	var numbering Numbering
+	if err := xml.Unmarshal([]byte(numberingXML), &numbering); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		numID     int
+		ilvl      int
+		wantNil   bool
+		wantStart int
+	}{
+		{name: "Abstract level", numID: 1, ilvl: 1, wantStart: 3},
+		{name: "Start override", numID: 2, ilvl: 1, wantStart: 7},
+		{name: "Unknown num", numID: 9, ilvl: 0, wantNil: true},
+		{name: "Unknown level", numID: 1, ilvl: 5, wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lvl := numbering.Level(tt.numID, tt.ilvl)
+			if tt.wantNil {
+				if lvl != nil {
+					t.Errorf("Expected nil level, got %+v", lvl)
+				}
+				return
+			}
+
+			if lvl == nil || lvl.Start == nil {
+				t.Fatalf("Expected level with start value, got %+v", lvl)
+			}
+
+			if lvl.Start.Val != tt.wantStart {
+				t.Errorf("Expected start %d, got %d", tt.wantStart, lvl.Start.Val)
+			}
+		})
+	}
+
+	// Resolving a start override must not modify the abstract definition.
+	if numbering.AbstractNums[0].Levels[1].Start.Val != 3 {
+		t.Errorf("Abstract level start was modified: %d", numbering.AbstractNums[0].Levels[1].Start.Val)
+	}
+}
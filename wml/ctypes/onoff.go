@@ -51,3 +51,23 @@ func (n OnOff) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	}
 	return e.EncodeToken(xml.EndElement{Name: start.Name})
 }
+
+// Bool reports whether the property is turned on.
+//
+// A nil element is treated as off, and an element without the val attribute is treated as on.
+func (n *OnOff) Bool() bool {
+	if n == nil {
+		return false
+	}
+
+	if n.Val == nil {
+		return true
+	}
+
+	switch *n.Val {
+	case stypes.OnOffZero, stypes.OnOffFalse, stypes.OnOffOff:
+		return false
+	default:
+		return true
+	}
+}
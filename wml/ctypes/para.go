@@ -28,13 +28,11 @@ type ParagraphChild struct {
 	Link *Hyperlink             // w:hyperlink
 	Run  *Run                   // w:r
 	Sdt  *StructuredDocumentTag // w:sdt - Content Control
-}
 
-type Hyperlink struct {
-	XMLName  xml.Name `xml:"http://schemas.openxmlformats.org/wordprocessingml/2006/main hyperlink,omitempty"`
-	ID       string   `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
-	Run      *Run
-	Children []ParagraphChild
+	SimpleField *SimpleField // w:fldSimple
+
+	BookmarkStart *BookmarkStart // w:bookmarkStart
+	BookmarkEnd   *BookmarkEnd   // w:bookmarkEnd
 }
 
 func (p Paragraph) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
@@ -69,28 +67,8 @@ func (p Paragraph) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error
 		}
 	}
 
-	for _, cElem := range p.Children {
-		if cElem.Run != nil {
-			if err = cElem.Run.MarshalXML(e, xml.StartElement{
-				Name: xml.Name{Local: "w:r"},
-			}); err != nil {
-				return err
-			}
-		}
-
-		if cElem.Link != nil {
-			if err = e.EncodeElement(cElem.Link, xml.StartElement{
-				Name: xml.Name{Local: "w:hyperlink"},
-			}); err != nil {
-				return err
-			}
-		}
-
-		if cElem.Sdt != nil {
-			if err = cElem.Sdt.MarshalXML(e, xml.StartElement{}); err != nil {
-				return err
-			}
-		}
+	if err = marshalParagraphChildren(e, p.Children); err != nil {
+		return err
 	}
 
 	// Closing </w:p> element
@@ -124,36 +102,19 @@ loop:
 		switch elem := currentToken.(type) {
 		case xml.StartElement:
 			switch elem.Name.Local {
-			case "r":
-				r := NewRun()
-				if err = d.DecodeElement(r, &elem); err != nil {
-					return err
-				}
-
-				p.Children = append(p.Children, ParagraphChild{Run: r})
 			case "pPr":
 				p.Property = &ParagraphProp{}
 				if err = d.DecodeElement(p.Property, &elem); err != nil {
 					return err
 				}
-			case "sdt":
-				sdt := &StructuredDocumentTag{}
-				if err = d.DecodeElement(sdt, &elem); err != nil {
-					return err
-				}
-
-				p.Children = append(p.Children, ParagraphChild{Sdt: sdt})
-			case "hyperlink":
-				link := &Hyperlink{}
-				if err = d.DecodeElement(link, &elem); err != nil {
-					return err
-				}
-
-				p.Children = append(p.Children, ParagraphChild{Link: link})
 			default:
-				if err = d.Skip(); err != nil {
+				child, ok, err := unmarshalParagraphChild(d, elem)
+				if err != nil {
 					return err
 				}
+				if ok {
+					p.Children = append(p.Children, child)
+				}
 			}
 		case xml.EndElement:
 			break loop
@@ -163,6 +124,102 @@ loop:
 	return nil
 }
 
+// marshalParagraphChildren encodes the content of a paragraph, in order.
+func marshalParagraphChildren(e *xml.Encoder, children []ParagraphChild) (err error) {
+	for _, cElem := range children {
+		if cElem.Run != nil {
+			if err = cElem.Run.MarshalXML(e, xml.StartElement{
+				Name: xml.Name{Local: "w:r"},
+			}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.Link != nil {
+			if err = cElem.Link.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.Sdt != nil {
+			if err = cElem.Sdt.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.SimpleField != nil {
+			if err = cElem.SimpleField.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.BookmarkStart != nil {
+			if err = cElem.BookmarkStart.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.BookmarkEnd != nil {
+			if err = cElem.BookmarkEnd.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// unmarshalParagraphChild decodes a content element of a paragraph. It skips unsupported
+// elements and reports them with false.
+func unmarshalParagraphChild(d *xml.Decoder, elem xml.StartElement) (ParagraphChild, bool, error) {
+	switch elem.Name.Local {
+	case "r":
+		r := NewRun()
+		if err := d.DecodeElement(r, &elem); err != nil {
+			return ParagraphChild{}, false, err
+		}
+
+		return ParagraphChild{Run: r}, true, nil
+	case "sdt":
+		sdt := &StructuredDocumentTag{}
+		if err := d.DecodeElement(sdt, &elem); err != nil {
+			return ParagraphChild{}, false, err
+		}
+
+		return ParagraphChild{Sdt: sdt}, true, nil
+	case "hyperlink":
+		link := &Hyperlink{}
+		if err := d.DecodeElement(link, &elem); err != nil {
+			return ParagraphChild{}, false, err
+		}
+
+		return ParagraphChild{Link: link}, true, nil
+	case "fldSimple":
+		field := &SimpleField{}
+		if err := d.DecodeElement(field, &elem); err != nil {
+			return ParagraphChild{}, false, err
+		}
+
+		return ParagraphChild{SimpleField: field}, true, nil
+	case "bookmarkStart":
+		bookmark := &BookmarkStart{}
+		if err := d.DecodeElement(bookmark, &elem); err != nil {
+			return ParagraphChild{}, false, err
+		}
+
+		return ParagraphChild{BookmarkStart: bookmark}, true, nil
+	case "bookmarkEnd":
+		bookmark := &BookmarkEnd{}
+		if err := d.DecodeElement(bookmark, &elem); err != nil {
+			return ParagraphChild{}, false, err
+		}
+
+		return ParagraphChild{BookmarkEnd: bookmark}, true, nil
+	}
+
+	return ParagraphChild{}, false, d.Skip()
+}
+
 func (p *Paragraph) AddText(text string) *Run {
 	t := TextFromString(text)
 
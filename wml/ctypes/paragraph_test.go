@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/bfoley13/godocx/common/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParagraphXML(t *testing.T) {
@@ -48,3 +50,25 @@ func TestParagraphXML(t *testing.T) {
 		t.Errorf("Original and unmarshaled paragraphs are not equal.")
 	}
 }
+
+func TestParagraph_Bookmarks(t *testing.T) {
+	input := `<w:p xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:bookmarkStart w:id="3" w:name="Intro"></w:bookmarkStart>` +
+		`<w:r><w:t>Introduction</w:t></w:r>` +
+		`<w:bookmarkEnd w:id="3"></w:bookmarkEnd>` +
+		`</w:p>`
+
+	var p Paragraph
+	require.NoError(t, xml.Unmarshal([]byte(input), &p))
+	require.Len(t, p.Children, 3)
+	require.NotNil(t, p.Children[0].BookmarkStart)
+	assert.Equal(t, "Intro", p.Children[0].BookmarkStart.Name.Val)
+	assert.Equal(t, 3, p.Children[0].BookmarkStart.ID.Val)
+	require.NotNil(t, p.Children[2].BookmarkEnd)
+	assert.Equal(t, 3, p.Children[2].BookmarkEnd.ID.Val)
+
+	output, err := xml.Marshal(p)
+	require.NoError(t, err)
+	assert.Equal(t, `<w:p><w:bookmarkStart w:id="3" w:name="Intro"></w:bookmarkStart>`+
+		`<w:r><w:t>Introduction</w:t></w:r><w:bookmarkEnd w:id="3"></w:bookmarkEnd></w:p>`, string(output))
+}
@@ -94,13 +94,16 @@ type RunChild struct {
 	//Complex Field Character
 	FldChar *FieldChar `xml:"fldChar,omitempty"`
 
+	//Footnote Reference
+	FootnoteReference *FootnoteReference `xml:"footnoteReference,omitempty"`
+
+	//Endnote Reference
+	EndnoteReference *FootnoteReference `xml:"endnoteReference,omitempty"`
+
 	//TODO:
 	// 	w:object    Inline Embedded Object
 	// w:pict    VML Object
 	// w:ruby    Phonetic Guide
-	// w:footnoteReference    Footnote Reference
-	// w:endnoteReference    Endnote Reference
-	// w:commentReference    Comment Content Reference Mark
 
 	//Comment Content Reference Mark
 	CmntRef *Markup `xml:"commentReference,omitempty"`
@@ -224,6 +227,33 @@ loop:
 				r.Children = append(r.Children, RunChild{
 					Break: &br,
 				})
+			case "footnoteReference":
+				ref := &FootnoteReference{}
+				if err = d.DecodeElement(ref, &elem); err != nil {
+					return err
+				}
+
+				r.Children = append(r.Children, RunChild{
+					FootnoteReference: ref,
+				})
+			case "endnoteReference":
+				ref := &FootnoteReference{}
+				if err = d.DecodeElement(ref, &elem); err != nil {
+					return err
+				}
+
+				r.Children = append(r.Children, RunChild{
+					EndnoteReference: ref,
+				})
+			case "commentReference":
+				ref := &Markup{}
+				if err = d.DecodeElement(ref, &elem); err != nil {
+					return err
+				}
+
+				r.Children = append(r.Children, RunChild{
+					CmntRef: ref,
+				})
 			case "drawing":
 				drawingElem := &dml.Drawing{}
 				if err = d.DecodeElement(drawingElem, &elem); err != nil {
@@ -329,6 +359,10 @@ func (r *Run) MarshalChild(e *xml.Encoder) error {
 			err = child.PTab.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:ptab"}})
 		case child.CmntRef != nil:
 			err = child.CmntRef.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:commentReference"}})
+		case child.FootnoteReference != nil:
+			err = child.FootnoteReference.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:footnoteReference"}})
+		case child.EndnoteReference != nil:
+			err = child.EndnoteReference.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:endnoteReference"}})
 
 		}
 
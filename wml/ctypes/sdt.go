@@ -45,6 +45,7 @@ type SdtProperties struct {
 	Checkbox     *SdtCheckbox     `xml:"checkbox,omitempty"`
 	Group        *Empty           `xml:"group,omitempty"`
 	Citation     *Empty           `xml:"citation,omitempty"`
+	DocPartObj   *SdtDocPart      `xml:"docPartObj,omitempty"`
 }
 
 // SdtContent represents structured document tag content (w:sdtContent)
@@ -61,6 +62,19 @@ type SdtContentChild struct {
 	// Can add more content types as needed
 }
 
+// SdtDocPart represents a content control holding a building block, such as a table of
+// contents (w:docPartObj)
+type SdtDocPart struct {
+	// Document Part Gallery Filter
+	Gallery *CTString `xml:"docPartGallery,omitempty"`
+
+	// Document Part Category Filter
+	Category *CTString `xml:"docPartCategory,omitempty"`
+
+	// Built-In Document Part
+	Unique *OnOff `xml:"docPartUnique,omitempty"`
+}
+
 // SdtText represents text content control properties
 type SdtText struct {
 	// Multi-line
@@ -232,6 +246,12 @@ func (props SdtProperties) MarshalXML(e *xml.Encoder, start xml.StartElement) er
 		}
 	}
 
+	if props.DocPartObj != nil {
+		if err := props.DocPartObj.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
 	return e.EncodeToken(xml.EndElement{Name: start.Name})
 }
 
@@ -321,6 +341,11 @@ func (props *SdtProperties) UnmarshalXML(d *xml.Decoder, start xml.StartElement)
 				if err := d.DecodeElement(props.Citation, &elem); err != nil {
 					return err
 				}
+			case "docPartObj":
+				props.DocPartObj = &SdtDocPart{}
+				if err := props.DocPartObj.UnmarshalXML(d, elem); err != nil {
+					return err
+				}
 			default:
 				if err := d.Skip(); err != nil {
 					return err
@@ -454,6 +479,72 @@ func (content *SdtContent) UnmarshalXML(d *xml.Decoder, start xml.StartElement)
 	}
 }
 
+// MarshalXML implements xml.Marshaler for SdtDocPart
+func (part SdtDocPart) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:docPartObj"
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if part.Gallery != nil {
+		if err := e.EncodeElement(part.Gallery, xml.StartElement{Name: xml.Name{Local: "w:docPartGallery"}}); err != nil {
+			return err
+		}
+	}
+
+	if part.Category != nil {
+		if err := e.EncodeElement(part.Category, xml.StartElement{Name: xml.Name{Local: "w:docPartCategory"}}); err != nil {
+			return err
+		}
+	}
+
+	if part.Unique != nil {
+		if err := e.EncodeElement(part.Unique, xml.StartElement{Name: xml.Name{Local: "w:docPartUnique"}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// UnmarshalXML implements xml.Unmarshaler for SdtDocPart
+func (part *SdtDocPart) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "docPartGallery":
+				part.Gallery = &CTString{}
+				if err := d.DecodeElement(part.Gallery, &elem); err != nil {
+					return err
+				}
+			case "docPartCategory":
+				part.Category = &CTString{}
+				if err := d.DecodeElement(part.Category, &elem); err != nil {
+					return err
+				}
+			case "docPartUnique":
+				part.Unique = &OnOff{}
+				if err := d.DecodeElement(part.Unique, &elem); err != nil {
+					return err
+				}
+			default:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
 // MarshalXML implements xml.Marshaler for SdtText
 func (text SdtText) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	start.Name.Local = "w:text"
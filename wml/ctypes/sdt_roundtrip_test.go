@@ -259,4 +259,29 @@ func TestSDTDateControlRoundTrip(t *testing.T) {
 	assert.Equal(t, "MM/dd/yyyy", date.DateFormat.Val)
 	assert.Equal(t, stypes.CalendarTypeGregorian, date.Calendar.Val)
 	assert.Equal(t, "dateTime", date.StorageFormat.Val)
+}
+
+func TestSDTDocPartRoundTrip(t *testing.T) {
+	original := &StructuredDocumentTag{
+		Properties: &SdtProperties{
+			ID: NewDecimalNum(12),
+			DocPartObj: &SdtDocPart{
+				Gallery: NewCTString("Table of Contents"),
+				Unique:  &OnOff{},
+			},
+		},
+		Content: &SdtContent{},
+	}
+
+	xmlData, err := xml.Marshal(original)
+	assert.NoError(t, err)
+	assert.Contains(t, string(xmlData), `<w:docPartObj><w:docPartGallery w:val="Table of Contents"></w:docPartGallery><w:docPartUnique></w:docPartUnique></w:docPartObj>`)
+
+	var result StructuredDocumentTag
+	err = xml.Unmarshal(xmlData, &result)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.Properties.DocPartObj)
+	assert.Equal(t, "Table of Contents", result.Properties.DocPartObj.Gallery.Val)
+	assert.Nil(t, result.Properties.DocPartObj.Category)
+	assert.True(t, result.Properties.DocPartObj.Unique.Bool())
 }
\ No newline at end of file
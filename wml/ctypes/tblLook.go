@@ -0,0 +1,62 @@
+package ctypes
+
+import (
+	"encoding/xml"
+
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+// TableLook specifies which conditional formats of the table style apply to the table.
+//
+// Val is the legacy hexadecimal bit mask of the settings. Documents written by current
+// versions of Word carry both the mask and the individual attributes.
+type TableLook struct {
+	// Bit mask of the settings, such as "04A0"
+	Val string `xml:"val,attr,omitempty"`
+
+	// Apply the first row conditional formatting
+	FirstRow *stypes.OnOff `xml:"firstRow,attr,omitempty"`
+
+	// Apply the last row conditional formatting
+	LastRow *stypes.OnOff `xml:"lastRow,attr,omitempty"`
+
+	// Apply the first column conditional formatting
+	FirstColumn *stypes.OnOff `xml:"firstColumn,attr,omitempty"`
+
+	// Apply the last column conditional formatting
+	LastColumn *stypes.OnOff `xml:"lastColumn,attr,omitempty"`
+
+	// Do not apply the row banding conditional formatting
+	NoHBand *stypes.OnOff `xml:"noHBand,attr,omitempty"`
+
+	// Do not apply the column banding conditional formatting
+	NoVBand *stypes.OnOff `xml:"noVBand,attr,omitempty"`
+}
+
+// MarshalXML implements the xml.Marshaler interface for TableLook.
+func (t TableLook) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:tblLook"
+
+	if t.Val != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:val"}, Value: t.Val})
+	}
+
+	attrs := []struct {
+		name  string
+		value *stypes.OnOff
+	}{
+		{"w:firstRow", t.FirstRow},
+		{"w:lastRow", t.LastRow},
+		{"w:firstColumn", t.FirstColumn},
+		{"w:lastColumn", t.LastColumn},
+		{"w:noHBand", t.NoHBand},
+		{"w:noVBand", t.NoVBand},
+	}
+	for _, attr := range attrs {
+		if attr.value != nil {
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: attr.name}, Value: string(*attr.value)})
+		}
+	}
+
+	return e.EncodeElement("", start)
+}
@@ -0,0 +1,76 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/bfoley13/godocx/internal"
+	"github.com/bfoley13/godocx/wml/stypes"
+)
+
+func TestTableLook_MarshalXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    TableLook
+		expected string
+	}{
+		{
+			name:     "Val only",
+			input:    TableLook{Val: "04A0"},
+			expected: `<w:tblLook w:val="04A0"></w:tblLook>`,
+		},
+		{
+			name: "All attributes",
+			input: TableLook{
+				Val:         "04A0",
+				FirstRow:    internal.ToPtr(stypes.OnOffOne),
+				LastRow:     internal.ToPtr(stypes.OnOffZero),
+				FirstColumn: internal.ToPtr(stypes.OnOffOne),
+				LastColumn:  internal.ToPtr(stypes.OnOffZero),
+				NoHBand:     internal.ToPtr(stypes.OnOffZero),
+				NoVBand:     internal.ToPtr(stypes.OnOffOne),
+			},
+			expected: `<w:tblLook w:val="04A0" w:firstRow="1" w:lastRow="0" w:firstColumn="1" w:lastColumn="0" w:noHBand="0" w:noVBand="1"></w:tblLook>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result strings.Builder
+			encoder := xml.NewEncoder(&result)
+			if err := encoder.Encode(tt.input); err != nil {
+				t.Fatalf("Error marshaling XML: %v", err)
+			}
+
+			if got := result.String(); got != tt.expected {
+				t.Errorf("Expected XML:\n%s\nGot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTableLook_UnmarshalXML(t *testing.T) {
+	input := `<w:tblLook w:val="04A0" w:firstRow="1" w:lastRow="0" w:firstColumn="1" w:lastColumn="0" w:noHBand="0" w:noVBand="1"/>`
+
+	var result TableLook
+	if err := xml.Unmarshal([]byte(input), &result); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if result.Val != "04A0" {
+		t.Errorf("Expected Val 04A0, got %s", result.Val)
+	}
+	for name, pair := range map[string][2]*stypes.OnOff{
+		"FirstRow":    {internal.ToPtr(stypes.OnOffOne), result.FirstRow},
+		"LastRow":     {internal.ToPtr(stypes.OnOffZero), result.LastRow},
+		"FirstColumn": {internal.ToPtr(stypes.OnOffOne), result.FirstColumn},
+		"LastColumn":  {internal.ToPtr(stypes.OnOffZero), result.LastColumn},
+		"NoHBand":     {internal.ToPtr(stypes.OnOffZero), result.NoHBand},
+		"NoVBand":     {internal.ToPtr(stypes.OnOffOne), result.NoVBand},
+	} {
+		if err := internal.ComparePtr(name, pair[0], pair[1]); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -93,7 +93,7 @@ type TableProp struct {
 	CellMargin *CellMargins `xml:"tblCellMar,omitempty"`
 
 	// 15. Table Style Conditional Formatting Settings
-	TableLook *CTString `xml:"tblLook,omitempty"`
+	TableLook *TableLook `xml:"tblLook,omitempty"`
 
 	//16. Revision Information for Table Properties
 	PrChange *TblPrChange `xml:"tblPrChange,omitempty"`
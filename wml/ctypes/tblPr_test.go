@@ -58,7 +58,7 @@ func TestTableProp_MarshalXML(t *testing.T) {
 				Shading:    &Shading{Val: "clear"},
 				Layout:     &TableLayout{LayoutType: internal.ToPtr(stypes.TableLayoutAutoFit)},
 				CellMargin: &CellMargins{Top: NewTableWidth(40, stypes.TableWidthDxa)},
-				TableLook:  &CTString{Val: "001"},
+				TableLook:  &TableLook{Val: "001"},
 			},
 			expected: `<w:tblPr>` +
 				`<w:tblStyle w:val="TestStyle"></w:tblStyle>` +
@@ -170,7 +170,7 @@ func TestTableProp_UnmarshalXML(t *testing.T) {
 				Shading:    &Shading{Val: "clear"},
 				Layout:     &TableLayout{LayoutType: internal.ToPtr(stypes.TableLayoutAutoFit)},
 				CellMargin: &CellMargins{Top: NewTableWidth(40, stypes.TableWidthDxa)},
-				TableLook:  &CTString{Val: "001"},
+				TableLook:  &TableLook{Val: "001"},
 			},
 		},
 	}